@@ -0,0 +1,336 @@
+// Package abiutil 读取 ABI 文件，把命令行上的字符串参数按 ABI 类型转换成可打包的 Go 值，并把返回值与事件格式化为可读文本。
+package abiutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// LoadABI 支持纯 ABI 数组（solc --abi 输出）以及带 "abi" 字段的 Hardhat/Truffle 构建产物
+func LoadABI(path string) (abi.ABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("读取 ABI 文件失败: %w", err)
+	}
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "{") {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(data, &artifact); err != nil || len(artifact.ABI) == 0 {
+			return abi.ABI{}, fmt.Errorf("ABI 文件既不是 ABI 数组也不包含 abi 字段: %s", path)
+		}
+		trimmed = string(artifact.ABI)
+	}
+	parsed, err := abi.JSON(strings.NewReader(trimmed))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("解析 ABI 失败: %w", err)
+	}
+	return parsed, nil
+}
+
+// LoadBytecode 支持纯十六进制字节码（solc --bin 输出，可带 0x 前缀）以及带 "bytecode" 字段的构建产物
+// （Hardhat 为字符串，Foundry 为 {"object": "0x..."}）
+func LoadBytecode(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取字节码文件失败: %w", err)
+	}
+	text := strings.TrimSpace(string(data))
+	if strings.HasPrefix(text, "{") {
+		var artifact struct {
+			Bytecode json.RawMessage `json:"bytecode"`
+		}
+		if err := json.Unmarshal(data, &artifact); err != nil || len(artifact.Bytecode) == 0 {
+			return nil, fmt.Errorf("字节码文件既不是十六进制也不包含 bytecode 字段: %s", path)
+		}
+		var object struct {
+			Object string `json:"object"`
+		}
+		if err := json.Unmarshal(artifact.Bytecode, &text); err != nil {
+			if err := json.Unmarshal(artifact.Bytecode, &object); err != nil {
+				return nil, fmt.Errorf("无法解析 bytecode 字段: %s", path)
+			}
+			text = object.Object
+		}
+	}
+	if !strings.HasPrefix(text, "0x") && !strings.HasPrefix(text, "0X") {
+		text = "0x" + text
+	}
+	code, err := hexutil.Decode(text)
+	if err != nil {
+		return nil, fmt.Errorf("字节码不是合法的十六进制: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("字节码为空（接口或抽象合约无法部署）: %s", path)
+	}
+	return code, nil
+}
+
+// FindMethod 按完整签名（如 transfer(address,uint256)）查找方法；
+// 只给方法名时要求没有重载
+func FindMethod(parsed abi.ABI, signature string) (abi.Method, error) {
+	signature = strings.ReplaceAll(strings.TrimSpace(signature), " ", "")
+	if !strings.Contains(signature, "(") {
+		method, ok := parsed.Methods[signature]
+		if !ok {
+			return abi.Method{}, fmt.Errorf("ABI 中没有方法 %s", signature)
+		}
+		for name, m := range parsed.Methods {
+			if name != signature && m.RawName == method.RawName {
+				return abi.Method{}, fmt.Errorf("方法 %s 存在重载，请使用完整签名，例如 %s", signature, method.Sig)
+			}
+		}
+		return method, nil
+	}
+	for _, method := range parsed.Methods {
+		if method.Sig == signature {
+			return method, nil
+		}
+	}
+	return abi.Method{}, fmt.Errorf("ABI 中没有签名为 %s 的方法", signature)
+}
+
+// ParseArgs 按参数列表逐个转换命令行字符串
+func ParseArgs(args abi.Arguments, values []string) ([]interface{}, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("参数个数不匹配：需要 %d 个 %s，实际 %d 个", len(args), describe(args), len(values))
+	}
+	out := make([]interface{}, len(args))
+	for i, arg := range args {
+		v, err := ParseValue(arg.Type, values[i])
+		if err != nil {
+			name := arg.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i)
+			}
+			return nil, fmt.Errorf("参数 %s(%s): %w", name, arg.Type.String(), err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// ParseValue 把单个字符串转换为 abi.Type 对应的 Go 类型（与 abigen 生成代码使用的类型一致）。
+// 数组与 tuple 使用 JSON 表示：数组为 [1,2,3]，tuple 为按字段顺序的数组 ["0x..",5] 或按字段名的对象 {"to":"0x..","amount":5}
+func ParseValue(t abi.Type, s string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+	switch t.T {
+	case abi.SliceTy, abi.ArrayTy:
+		return parseArray(t, s)
+	case abi.TupleTy:
+		return parseTuple(t, s)
+	case abi.AddressTy:
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("地址格式不正确: %s", s)
+		}
+		return common.HexToAddress(s), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(s)
+	case abi.StringTy:
+		return s, nil
+	case abi.BytesTy:
+		return hexutil.Decode(s)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("需要 %d 字节，实际 %d 字节", t.Size, len(b))
+		}
+		v := reflect.New(t.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(b))
+		return v.Interface(), nil
+	case abi.IntTy, abi.UintTy:
+		return parseInteger(t, s)
+	default:
+		return nil, fmt.Errorf("暂不支持从命令行解析 %s 类型", t.String())
+	}
+}
+
+func parseArray(t abi.Type, s string) (interface{}, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(s), &items); err != nil {
+		return nil, fmt.Errorf("%s 需要 JSON 数组，例如 [1,2]: %w", t.String(), err)
+	}
+	if t.T == abi.ArrayTy && len(items) != t.Size {
+		return nil, fmt.Errorf("%s 需要 %d 个元素，实际 %d 个", t.String(), t.Size, len(items))
+	}
+	var v reflect.Value
+	if t.T == abi.SliceTy {
+		v = reflect.MakeSlice(t.GetType(), len(items), len(items))
+	} else {
+		v = reflect.New(t.GetType()).Elem()
+	}
+	for i, item := range items {
+		elem, err := ParseValue(*t.Elem, rawString(item))
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 个元素: %w", i, err)
+		}
+		v.Index(i).Set(reflect.ValueOf(elem))
+	}
+	return v.Interface(), nil
+}
+
+func parseTuple(t abi.Type, s string) (interface{}, error) {
+	items := make([]json.RawMessage, len(t.TupleElems))
+	if strings.HasPrefix(s, "{") {
+		var named map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(s), &named); err != nil {
+			return nil, fmt.Errorf("tuple 需要 JSON 对象或数组: %w", err)
+		}
+		if len(named) != len(t.TupleRawNames) {
+			return nil, fmt.Errorf("tuple 需要字段 %s，实际 %d 个", strings.Join(t.TupleRawNames, ","), len(named))
+		}
+		for i, name := range t.TupleRawNames {
+			item, ok := named[name]
+			if !ok {
+				return nil, fmt.Errorf("tuple 缺少字段 %s", name)
+			}
+			items[i] = item
+		}
+	} else {
+		var list []json.RawMessage
+		if err := json.Unmarshal([]byte(s), &list); err != nil {
+			return nil, fmt.Errorf("tuple 需要 JSON 对象或数组: %w", err)
+		}
+		if len(list) != len(t.TupleElems) {
+			return nil, fmt.Errorf("tuple 需要 %d 个字段，实际 %d 个", len(t.TupleElems), len(list))
+		}
+		copy(items, list)
+	}
+	v := reflect.New(t.GetType()).Elem()
+	for i, elemType := range t.TupleElems {
+		elem, err := ParseValue(*elemType, rawString(items[i]))
+		if err != nil {
+			return nil, fmt.Errorf("字段 %s: %w", t.TupleRawNames[i], err)
+		}
+		v.Field(i).Set(reflect.ValueOf(elem))
+	}
+	return v.Interface(), nil
+}
+
+// rawString 把 JSON 元素还原成命令行形式：字符串去掉引号，数字、布尔与嵌套数组/对象保持原文
+func rawString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// parseInteger 支持十进制与 0x 十六进制；位宽不超过 64 时返回对应的定长整数类型，否则返回 *big.Int
+func parseInteger(t abi.Type, s string) (interface{}, error) {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("不是合法的整数: %s", s)
+	}
+	if t.T == abi.UintTy && n.Sign() < 0 {
+		return nil, fmt.Errorf("无符号整数不能为负: %s", s)
+	}
+	bits := n.BitLen()
+	if t.T == abi.IntTy {
+		// 补码表示：负数 -x 需要的位数与 x-1 相同，再加一个符号位
+		if n.Sign() < 0 {
+			bits = new(big.Int).Sub(new(big.Int).Neg(n), big.NewInt(1)).BitLen()
+		}
+		bits++
+	}
+	if bits > t.Size {
+		return nil, fmt.Errorf("数值超出 %s 的范围: %s", t.String(), s)
+	}
+	goType := t.GetType()
+	if goType == reflect.TypeOf(&big.Int{}) {
+		return n, nil
+	}
+	v := reflect.New(goType).Elem()
+	if t.T == abi.UintTy {
+		v.SetUint(n.Uint64())
+	} else {
+		v.SetInt(n.Int64())
+	}
+	return v.Interface(), nil
+}
+
+// FormatValue 把解包出的返回值转成便于阅读的字符串
+func FormatValue(v interface{}) string {
+	switch val := v.(type) {
+	case common.Address:
+		return val.Hex()
+	case *big.Int:
+		return val.String()
+	case []byte:
+		return hexutil.Encode(val)
+	case string:
+		return strconv.Quote(val)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = FormatValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Struct:
+		// tuple：按字段名输出；abi 包动态生成的结构体在 json 标签中保留了 ABI 字段名
+		fields := make([]string, rv.NumField())
+		for i := range fields {
+			f := rv.Type().Field(i)
+			name := f.Tag.Get("json")
+			if name == "" {
+				name = f.Name
+			}
+			fields[i] = name + ": " + FormatValue(rv.Field(i).Interface())
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	}
+	return fmt.Sprint(v)
+}
+
+// FormatTyped 按 ABI 类型格式化：uint8[N] 与 bytesN 在 Go 中类型相同，只有借助 ABI 类型才能区分；
+// 值与 ABI 类型不对应时（例如 indexed 动态类型只保留了哈希）退回 FormatValue
+func FormatTyped(t abi.Type, v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Type() != t.GetType() {
+		return FormatValue(v)
+	}
+	switch t.T {
+	case abi.SliceTy, abi.ArrayTy:
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = FormatTyped(*t.Elem, rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case abi.TupleTy:
+		fields := make([]string, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			fields[i] = t.TupleRawNames[i] + ": " + FormatTyped(*elem, rv.Field(i).Interface())
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	}
+	return FormatValue(v)
+}
+
+func describe(args abi.Arguments) string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type.String()
+	}
+	return "(" + strings.Join(types, ",") + ")"
+}
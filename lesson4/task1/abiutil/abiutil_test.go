@@ -0,0 +1,196 @@
+package abiutil
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+const (
+	addrA = "0x00000000000000000000000000000000000000AA"
+	addrB = "0x00000000000000000000000000000000000000bb"
+)
+
+// 测试用的 tuple 组件：(address to, uint256 amount)
+var transferComponents = []abi.ArgumentMarshaling{
+	{Name: "to", Type: "address"},
+	{Name: "amount", Type: "uint256"},
+}
+
+// (uint64 id, (address to, uint256 amount)[] items)
+var orderComponents = []abi.ArgumentMarshaling{
+	{Name: "id", Type: "uint64"},
+	{Name: "items", Type: "tuple[]", Components: transferComponents},
+}
+
+func mustType(t *testing.T, typ string, components []abi.ArgumentMarshaling) abi.Type {
+	t.Helper()
+	parsed, err := abi.NewType(typ, "", components)
+	if err != nil {
+		t.Fatalf("abi.NewType(%s): %v", typ, err)
+	}
+	return parsed
+}
+
+func TestParseValue(t *testing.T) {
+	cases := []struct {
+		typ        string
+		components []abi.ArgumentMarshaling
+		in         string
+		want       string // FormatTyped 的输出
+	}{
+		{"uint8", nil, "255", "255"},
+		{"uint256", nil, "0xff", "255"},
+		{"uint256", nil, "115792089237316195423570985008687907853269984665640564039457584007913129639935", "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+		{"int8", nil, "-128", "-128"},
+		{"int8", nil, "127", "127"},
+		{"int64", nil, " -9223372036854775808 ", "-9223372036854775808"},
+		{"int256", nil, "-1", "-1"},
+		{"address", nil, strings.ToLower(addrA), addrA},
+		{"bool", nil, "true", "true"},
+		{"string", nil, "hello world", `"hello world"`},
+		{"bytes", nil, "0x0102", "0x0102"},
+		{"bytes4", nil, "0xdeadbeef", "0xdeadbeef"},
+		// uint8[N] 与 bytesN 的 Go 类型相同，按 ABI 类型分别格式化
+		{"uint8[4]", nil, "[222,173,190,239]", "[222, 173, 190, 239]"},
+		{"uint256[]", nil, "[]", "[]"},
+		{"uint256[][]", nil, `[[1],["2",3]]`, "[[1], [2, 3]]"},
+		{"address[2]", nil, `["` + addrA + `","` + addrB + `"]`, "[" + addrA + ", " + addrB + "]"},
+		{"string[]", nil, `["a","b,c"]`, `["a", "b,c"]`},
+		{"bytes2[][2]", nil, `[["0x0102"],[]]`, "[[0x0102], []]"},
+		{"tuple", transferComponents, `["` + addrA + `", 5]`, "{to: " + addrA + ", amount: 5}"},
+		{"tuple", transferComponents, `{"amount":"0x10","to":"` + addrB + `"}`, "{to: " + addrB + ", amount: 16}"},
+		{"tuple", orderComponents, `{"id":7,"items":[["` + addrA + `",1],{"to":"` + addrB + `","amount":2}]}`,
+			"{id: 7, items: [{to: " + addrA + ", amount: 1}, {to: " + addrB + ", amount: 2}]}"},
+		{"tuple[2]", transferComponents, `[["` + addrA + `",1],["` + addrB + `",2]]`,
+			"[{to: " + addrA + ", amount: 1}, {to: " + addrB + ", amount: 2}]"},
+	}
+	for _, c := range cases {
+		typ := mustType(t, c.typ, c.components)
+		v, err := ParseValue(typ, c.in)
+		if err != nil {
+			t.Errorf("ParseValue(%s, %s): %v", typ, c.in, err)
+			continue
+		}
+		if got := FormatTyped(typ, v); got != c.want {
+			t.Errorf("ParseValue(%s, %s) 格式化为 %s, want %s", typ, c.in, got, c.want)
+		}
+		// 转换结果必须能被 abi 包直接打包
+		if _, err := (abi.Arguments{{Type: typ}}).Pack(v); err != nil {
+			t.Errorf("Pack(%s, %T): %v", typ, v, err)
+		}
+	}
+}
+
+func TestParseValueErrors(t *testing.T) {
+	cases := []struct {
+		typ        string
+		components []abi.ArgumentMarshaling
+		in         string
+		want       string
+	}{
+		{"uint8", nil, "256", "数值超出 uint8 的范围"},
+		{"uint256", nil, "115792089237316195423570985008687907853269984665640564039457584007913129639936", "数值超出 uint256 的范围"},
+		{"uint256", nil, "-1", "无符号整数不能为负"},
+		{"int8", nil, "128", "数值超出 int8 的范围"},
+		{"int8", nil, "-129", "数值超出 int8 的范围"},
+		{"int256", nil, "57896044618658097711785492504343953926634992332820282019728792003956564819968", "数值超出 int256 的范围"},
+		{"uint64", nil, "1.5", "不是合法的整数"},
+		{"uint64", nil, "", "不是合法的整数"},
+		{"bool", nil, "yes", "invalid syntax"},
+		{"bytes4", nil, "0x0102", "需要 4 字节，实际 2 字节"},
+		{"bytes1", nil, "0x0102", "需要 1 字节，实际 2 字节"},
+		{"bytes32", nil, "deadbeef", "hex string without 0x prefix"},
+		{"bytes", nil, "0x123", "odd length"},
+		{"address", nil, "0x123", "地址格式不正确"},
+		{"address", nil, "vitalik.eth", "地址格式不正确"},
+		{"address", nil, "0x00000000000000000000000000000000000000zz", "地址格式不正确"},
+		{"uint8[3]", nil, "[1,2]", "需要 3 个元素，实际 2 个"},
+		{"uint8[]", nil, "1,2", "需要 JSON 数组"},
+		{"uint256[][]", nil, `[[1],["x"]]`, "第 1 个元素: 第 0 个元素: 不是合法的整数"},
+		{"int8[]", nil, "[1,200]", "第 1 个元素: 数值超出 int8 的范围"},
+		{"tuple", transferComponents, `["` + addrA + `"]`, "tuple 需要 2 个字段，实际 1 个"},
+		{"tuple", transferComponents, `{"to":"` + addrA + `","value":1}`, "tuple 缺少字段 amount"},
+		{"tuple", transferComponents, `{"to":"` + addrA + `"}`, "tuple 需要字段 to,amount"},
+		{"tuple", transferComponents, `["0x1", 1]`, "字段 to: 地址格式不正确"},
+		{"tuple", orderComponents, `{"id":1,"items":[["` + addrA + `",-1]]}`, "字段 items: 第 0 个元素: 字段 amount: 无符号整数不能为负"},
+		{"tuple", transferComponents, "5", "tuple 需要 JSON 对象或数组"},
+	}
+	for _, c := range cases {
+		typ := mustType(t, c.typ, c.components)
+		if v, err := ParseValue(typ, c.in); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("ParseValue(%s, %q) = %v, %v, want error %q", typ, c.in, v, err, c.want)
+		}
+	}
+}
+
+// 8/16/32/64 位返回定长整数，其余位宽返回 *big.Int，与 abigen 生成代码的参数类型一致
+func TestParseIntegerGoTypes(t *testing.T) {
+	cases := []struct {
+		typ  string
+		want interface{}
+	}{
+		{"uint8", uint8(7)},
+		{"uint32", uint32(7)},
+		{"uint64", uint64(7)},
+		{"int16", int16(7)},
+		{"uint24", big.NewInt(7)},
+		{"int256", big.NewInt(7)},
+	}
+	for _, c := range cases {
+		v, err := ParseValue(mustType(t, c.typ, nil), "7")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, ok := c.want.(*big.Int); ok {
+			if got, ok := v.(*big.Int); !ok || got.Cmp(want) != 0 {
+				t.Errorf("%s: got %T %v", c.typ, v, v)
+			}
+		} else if v != c.want {
+			t.Errorf("%s: got %T %v, want %T", c.typ, v, v, c.want)
+		}
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	args := abi.Arguments{
+		{Name: "to", Type: mustType(t, "address", nil)},
+		{Name: "", Type: mustType(t, "uint256", nil)},
+	}
+	values, err := ParseArgs(args, []string{addrA, "1000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := args.Pack(values...); err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+
+	cases := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{addrA}, "参数个数不匹配：需要 2 个 (address,uint256)，实际 1 个"},
+		{[]string{"0x1", "1"}, "参数 to(address): 地址格式不正确"},
+		// 未命名参数按位置报告
+		{[]string{addrA, "-1"}, "参数 #1(uint256): 无符号整数不能为负"},
+	}
+	for _, c := range cases {
+		if _, err := ParseArgs(args, c.values); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("ParseArgs(%v) err = %v, want %q", c.values, err, c.want)
+		}
+	}
+}
+
+// 值与 ABI 类型不对应时（indexed 动态类型只剩哈希）退回 FormatValue
+func TestFormatTypedFallback(t *testing.T) {
+	hash := [32]byte{0xab}
+	got := FormatTyped(mustType(t, "string", nil), hash)
+	if !strings.HasPrefix(got, "0xab00") || len(got) != 66 {
+		t.Fatalf("FormatTyped fallback = %s", got)
+	}
+	if got := FormatTyped(mustType(t, "uint256", nil), nil); got != "<nil>" {
+		t.Fatalf("FormatTyped(nil) = %s", got)
+	}
+}
@@ -0,0 +1,75 @@
+package abiutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrUnknownEvent 提供的 ABI 中没有与日志匹配的事件
+var ErrUnknownEvent = errors.New("未知事件")
+
+// Field 解码后的单个事件参数
+type Field struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+	Value   string `json:"value"`
+}
+
+// DecodedEvent 按 ABI 解码后的日志，参数顺序与事件定义一致
+type DecodedEvent struct {
+	Name      string  `json:"name"`
+	Signature string  `json:"signature"`
+	Fields    []Field `json:"fields"`
+}
+
+// DecodeLog 依次尝试每个 ABI；topic0 相同但 indexed 参数个数不同（例如 ERC-20 与 ERC-721 的 Transfer）时跳过
+func DecodeLog(abis []abi.ABI, log types.Log) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrUnknownEvent
+	}
+	for i := range abis {
+		event, err := abis[i].EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+		decoded, err := decodeEvent(event, log)
+		if err != nil {
+			continue
+		}
+		return decoded, nil
+	}
+	return nil, ErrUnknownEvent
+}
+
+func decodeEvent(event *abi.Event, log types.Log) (*DecodedEvent, error) {
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(log.Topics)-1 {
+		return nil, fmt.Errorf("indexed 参数个数不匹配")
+	}
+	values := make(map[string]interface{}, len(event.Inputs))
+	if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+		return nil, err
+	}
+	decoded := &DecodedEvent{Name: event.Name, Signature: event.Sig}
+	for _, input := range event.Inputs {
+		decoded.Fields = append(decoded.Fields, Field{
+			Name:    input.Name,
+			Type:    input.Type.String(),
+			Indexed: input.Indexed,
+			Value:   FormatTyped(input.Type, values[input.Name]),
+		})
+	}
+	return decoded, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// erc20ABI 只包含 send-tx 用到的 ERC-20 方法，以及 query-tx 解码日志用到的事件
+const erc20ABI = `[
+  {"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+  {"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"address","name":"account","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+  {"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"}],"name":"transfer","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},
+  {"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"from","type":"address"},{"indexed":true,"internalType":"address","name":"to","type":"address"},{"indexed":false,"internalType":"uint256","name":"value","type":"uint256"}],"name":"Transfer","type":"event"},
+  {"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"owner","type":"address"},{"indexed":true,"internalType":"address","name":"spender","type":"address"},{"indexed":false,"internalType":"uint256","name":"value","type":"uint256"}],"name":"Approval","type":"event"}
+]`
+
+var erc20 abi.ABI
+
+func init() {
+	var err error
+	erc20, err = abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// callView 调用只读方法并解包返回值
+func callView(ctx context.Context, caller ethereum.ContractCaller, contract common.Address, parsed abi.ABI, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	out, err := caller.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%s 返回为空，地址 %s 可能不是合约", method, contract.Hex())
+	}
+	return parsed.Unpack(method, out)
+}
+
+// tokenInfo 读取 decimals 与 symbol；symbol 非标准实现较多，读取失败时留空
+func tokenInfo(ctx context.Context, caller ethereum.ContractCaller, token common.Address) (uint8, string, error) {
+	vals, err := callView(ctx, caller, token, erc20, "decimals")
+	if err != nil {
+		return 0, "", fmt.Errorf("读取代币 decimals 失败: %w", err)
+	}
+	decimals := vals[0].(uint8)
+	symbol := ""
+	if vals, err := callView(ctx, caller, token, erc20, "symbol"); err == nil {
+		symbol = vals[0].(string)
+	}
+	return decimals, symbol, nil
+}
@@ -0,0 +1,595 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"lesson4/abiutil"
+	"lesson4/units"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// maxRangeBlocks 限制 query-range 单次扫描的区块数，避免对公共 RPC 发出过多请求
+const maxRangeBlocks = 2000
+
+// blockView query-block 的输出结构
+type blockView struct {
+	Number       uint64 `json:"number"`
+	Hash         string `json:"hash"`
+	ParentHash   string `json:"parentHash"`
+	Timestamp    uint64 `json:"timestamp"`
+	Time         string `json:"time"`
+	Transactions int    `json:"transactions"`
+	Miner        string `json:"miner"`
+	GasUsed      uint64 `json:"gasUsed"`
+	GasLimit     uint64 `json:"gasLimit"`
+	BaseFee      string `json:"baseFee,omitempty"`
+}
+
+// txView query-tx 的输出结构；金额均为 wei 十进制字符串
+type txView struct {
+	Hash              string    `json:"hash"`
+	Status            string    `json:"status"`
+	BlockNumber       *uint64   `json:"blockNumber,omitempty"`
+	BlockHash         string    `json:"blockHash,omitempty"`
+	Index             *uint     `json:"transactionIndex,omitempty"`
+	From              string    `json:"from"`
+	To                string    `json:"to,omitempty"`
+	ContractAddress   string    `json:"contractAddress,omitempty"`
+	Nonce             uint64    `json:"nonce"`
+	Type              uint8     `json:"type"`
+	Value             string    `json:"value"`
+	Gas               uint64    `json:"gas"`
+	GasPrice          string    `json:"gasPrice,omitempty"`
+	MaxFeePerGas      string    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFee    string    `json:"maxPriorityFeePerGas,omitempty"`
+	GasUsed           uint64    `json:"gasUsed,omitempty"`
+	EffectiveGasPrice string    `json:"effectiveGasPrice,omitempty"`
+	Fee               string    `json:"fee,omitempty"`
+	Input             string    `json:"input"`
+	Logs              []logView `json:"logs,omitempty"`
+}
+
+// logView 单条日志；能按 ABI 解码时附带 event
+type logView struct {
+	Index   uint                  `json:"logIndex"`
+	Address string                `json:"address"`
+	Topics  []string              `json:"topics"`
+	Data    string                `json:"data"`
+	Event   *abiutil.DecodedEvent `json:"event,omitempty"`
+}
+
+// addressView query-address 的输出结构
+type addressView struct {
+	Address      string  `json:"address"`
+	Block        string  `json:"block"`
+	Balance      string  `json:"balance"`
+	Nonce        uint64  `json:"nonce"`
+	PendingNonce *uint64 `json:"pendingNonce,omitempty"`
+	IsContract   bool    `json:"isContract"`
+	CodeSize     int     `json:"codeSize"`
+}
+
+// rangeView query-range 的统计结果
+type rangeView struct {
+	From             uint64  `json:"from"`
+	To               uint64  `json:"to"`
+	Blocks           uint64  `json:"blocks"`
+	Transactions     uint64  `json:"transactions"`
+	AvgTransactions  float64 `json:"avgTransactions"`
+	EmptyBlocks      uint64  `json:"emptyBlocks"`
+	GasUsed          uint64  `json:"gasUsed"`
+	GasLimit         uint64  `json:"gasLimit"`
+	GasUtilization   float64 `json:"gasUtilization"`
+	MinBaseFee       string  `json:"minBaseFee,omitempty"`
+	AvgBaseFee       string  `json:"avgBaseFee,omitempty"`
+	MaxBaseFee       string  `json:"maxBaseFee,omitempty"`
+	AvgBlockTimeSecs float64 `json:"avgBlockTime"`
+}
+
+// rangeBackend query-range 需要的链上读取能力
+type rangeBackend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error)
+}
+
+func dialRPC(ctx context.Context, rpcURL string) (*ethclient.Client, error) {
+	resolvedRPC := firstNonEmpty(rpcURL, os.Getenv("RPC_URL"))
+	if resolvedRPC == "" {
+		return nil, fmt.Errorf("-rpc 为空且未设置环境变量 RPC_URL")
+	}
+	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	if err != nil {
+		return nil, fmt.Errorf("连接 RPC 失败: %w", err)
+	}
+	return client, nil
+}
+
+// parseBlockArg 解析区块参数：空串或 latest 返回 nil（最新块），否则为十进制或 0x 十六进制区块号
+func parseBlockArg(s string) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.EqualFold(s, "latest") {
+		return nil, nil
+	}
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("无效区块号: %s（可用十进制、0x 十六进制或 latest）", s)
+	}
+	// 区块号在协议中是 uint64，更大的值转换时会被截断成另一个区块
+	if !n.IsUint64() {
+		return nil, fmt.Errorf("区块号超出范围: %s", s)
+	}
+	return n, nil
+}
+
+func printJSON(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func newBlockView(block *types.Block) blockView {
+	view := blockView{
+		Number:       block.NumberU64(),
+		Hash:         block.Hash().Hex(),
+		ParentHash:   block.ParentHash().Hex(),
+		Timestamp:    block.Time(),
+		Time:         time.Unix(int64(block.Time()), 0).UTC().Format(time.RFC3339),
+		Transactions: len(block.Transactions()),
+		Miner:        block.Coinbase().Hex(),
+		GasUsed:      block.GasUsed(),
+		GasLimit:     block.GasLimit(),
+	}
+	if block.BaseFee() != nil {
+		view.BaseFee = block.BaseFee().String()
+	}
+	return view
+}
+
+func printBlock(view blockView) {
+	fmt.Println("=== 区块信息 ===")
+	fmt.Printf("区块号: %d\n", view.Number)
+	fmt.Printf("区块哈希: %s\n", view.Hash)
+	fmt.Printf("父区块哈希: %s\n", view.ParentHash)
+	fmt.Printf("时间戳: %d (%s)\n", view.Timestamp, view.Time)
+	fmt.Printf("交易数量: %d\n", view.Transactions)
+	fmt.Printf("矿工地址(coinbase): %s\n", view.Miner)
+	fmt.Printf("GasUsed/GasLimit: %d / %d\n", view.GasUsed, view.GasLimit)
+	if view.BaseFee != "" {
+		fmt.Printf("BaseFee: %s wei\n", view.BaseFee)
+	}
+}
+
+func runQueryTx(args []string) error {
+	fs := flag.NewFlagSet("query-tx", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "Sepolia RPC URL，例如 Infura endpoint")
+	hashHex := fs.String("hash", "", "交易哈希")
+	abiPath := fs.String("abi", "", "可选：用于解码日志的 ABI 文件（默认只识别 ERC-20 Transfer/Approval）")
+	asJSON := fs.Bool("json", false, "以 JSON 输出")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *hashHex == "" {
+		return fmt.Errorf("缺少必要参数：-hash")
+	}
+	hashBytes, err := hexutil.Decode(*hashHex)
+	if err != nil || len(hashBytes) != common.HashLength {
+		return fmt.Errorf("无效交易哈希: %s", *hashHex)
+	}
+	abis := []abi.ABI{erc20}
+	if *abiPath != "" {
+		parsed, err := abiutil.LoadABI(*abiPath)
+		if err != nil {
+			return err
+		}
+		// 自定义 ABI 优先匹配
+		abis = append([]abi.ABI{parsed}, abis...)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	client, err := dialRPC(ctx, *rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("获取链 ID 失败: %w", err)
+	}
+	view, err := queryTx(ctx, client, chainID, common.BytesToHash(hashBytes), abis)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(view)
+	}
+	printTx(view)
+	return nil
+}
+
+// txBackend query-tx 需要的链上读取能力
+type txBackend interface {
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+func queryTx(ctx context.Context, backend txBackend, chainID *big.Int, hash common.Hash, abis []abi.ABI) (*txView, error) {
+	tx, isPending, err := backend.TransactionByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("交易不存在: %s", hash.Hex())
+		}
+		return nil, fmt.Errorf("获取交易失败: %w", err)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return nil, fmt.Errorf("恢复发送方失败: %w", err)
+	}
+	view := &txView{
+		Hash:   tx.Hash().Hex(),
+		Status: "pending",
+		From:   from.Hex(),
+		Nonce:  tx.Nonce(),
+		Type:   tx.Type(),
+		Value:  tx.Value().String(),
+		Gas:    tx.Gas(),
+		Input:  hexutil.Encode(tx.Data()),
+	}
+	if tx.To() != nil {
+		view.To = tx.To().Hex()
+	}
+	if tx.Type() == types.LegacyTxType || tx.Type() == types.AccessListTxType {
+		view.GasPrice = tx.GasPrice().String()
+	} else {
+		view.MaxFeePerGas = tx.GasFeeCap().String()
+		view.MaxPriorityFee = tx.GasTipCap().String()
+	}
+	if isPending {
+		return view, nil
+	}
+
+	receipt, err := backend.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易回执失败: %w", err)
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		view.Status = "success"
+	} else {
+		view.Status = "failed"
+	}
+	number := receipt.BlockNumber.Uint64()
+	index := receipt.TransactionIndex
+	view.BlockNumber = &number
+	view.BlockHash = receipt.BlockHash.Hex()
+	view.Index = &index
+	if receipt.ContractAddress != (common.Address{}) {
+		view.ContractAddress = receipt.ContractAddress.Hex()
+	}
+	view.GasUsed = receipt.GasUsed
+	if receipt.EffectiveGasPrice != nil {
+		view.EffectiveGasPrice = receipt.EffectiveGasPrice.String()
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+		if receipt.BlobGasPrice != nil {
+			fee.Add(fee, new(big.Int).Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), receipt.BlobGasPrice))
+		}
+		view.Fee = fee.String()
+	}
+	for _, l := range receipt.Logs {
+		lv := logView{
+			Index:   l.Index,
+			Address: l.Address.Hex(),
+			Data:    hexutil.Encode(l.Data),
+		}
+		for _, topic := range l.Topics {
+			lv.Topics = append(lv.Topics, topic.Hex())
+		}
+		if decoded, err := abiutil.DecodeLog(abis, *l); err == nil {
+			lv.Event = decoded
+		}
+		view.Logs = append(view.Logs, lv)
+	}
+	return view, nil
+}
+
+func printTx(view *txView) {
+	fmt.Println("=== 交易信息 ===")
+	fmt.Printf("交易哈希: %s\n", view.Hash)
+	fmt.Printf("状态: %s\n", view.Status)
+	if view.BlockNumber != nil {
+		fmt.Printf("区块: %d (%s) 序号 %d\n", *view.BlockNumber, view.BlockHash, *view.Index)
+	}
+	fmt.Printf("发送方: %s\n", view.From)
+	if view.To != "" {
+		fmt.Printf("接收方: %s\n", view.To)
+	}
+	if view.ContractAddress != "" {
+		fmt.Printf("创建合约: %s\n", view.ContractAddress)
+	}
+	fmt.Printf("Nonce: %d  类型: %d\n", view.Nonce, view.Type)
+	fmt.Printf("金额: %s ETH\n", formatWei(view.Value))
+	if view.GasPrice != "" {
+		fmt.Printf("GasPrice: %s wei\n", view.GasPrice)
+	} else {
+		fmt.Printf("MaxFee/MaxPriorityFee: %s / %s wei\n", view.MaxFeePerGas, view.MaxPriorityFee)
+	}
+	if view.BlockNumber != nil {
+		fmt.Printf("GasUsed/GasLimit: %d / %d\n", view.GasUsed, view.Gas)
+		if view.EffectiveGasPrice != "" {
+			fmt.Printf("实际 GasPrice: %s wei\n", view.EffectiveGasPrice)
+			fmt.Printf("手续费: %s ETH\n", formatWei(view.Fee))
+		}
+	} else {
+		fmt.Printf("GasLimit: %d\n", view.Gas)
+	}
+	if len(view.Input) > 2 {
+		fmt.Printf("Input: %s\n", view.Input)
+	}
+	if len(view.Logs) == 0 {
+		return
+	}
+	fmt.Printf("=== 日志 (%d) ===\n", len(view.Logs))
+	for _, l := range view.Logs {
+		if l.Event != nil {
+			fmt.Printf("[%d] %s %s\n", l.Index, l.Address, l.Event.Signature)
+			for _, f := range l.Event.Fields {
+				fmt.Printf("      %s (%s) = %s\n", f.Name, f.Type, f.Value)
+			}
+			continue
+		}
+		fmt.Printf("[%d] %s 未解码\n", l.Index, l.Address)
+		for i, topic := range l.Topics {
+			fmt.Printf("      topic%d: %s\n", i, topic)
+		}
+		fmt.Printf("      data: %s\n", l.Data)
+	}
+}
+
+func runQueryAddress(args []string) error {
+	fs := flag.NewFlagSet("query-address", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "Sepolia RPC URL，例如 Infura endpoint")
+	addrHex := fs.String("address", "", "要查询的地址")
+	blockArg := fs.String("block", "latest", "区块号或 latest")
+	asJSON := fs.Bool("json", false, "以 JSON 输出")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !common.IsHexAddress(*addrHex) {
+		return fmt.Errorf("缺少或无效的 -address: %q", *addrHex)
+	}
+	blockNum, err := parseBlockArg(*blockArg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	client, err := dialRPC(ctx, *rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	view, err := queryAddress(ctx, client, common.HexToAddress(*addrHex), blockNum)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(view)
+	}
+	fmt.Println("=== 地址信息 ===")
+	fmt.Printf("地址: %s\n", view.Address)
+	fmt.Printf("区块: %s\n", view.Block)
+	fmt.Printf("余额: %s ETH (%s wei)\n", formatWei(view.Balance), view.Balance)
+	fmt.Printf("Nonce: %d\n", view.Nonce)
+	if view.PendingNonce != nil {
+		fmt.Printf("Pending Nonce: %d\n", *view.PendingNonce)
+	}
+	if view.IsContract {
+		fmt.Printf("合约账户: 是（代码 %d 字节）\n", view.CodeSize)
+	} else {
+		fmt.Println("合约账户: 否")
+	}
+	return nil
+}
+
+// addressBackend query-address 需要的链上读取能力
+type addressBackend interface {
+	ethereum.ChainStateReader
+	ethereum.PendingStateReader
+}
+
+func queryAddress(ctx context.Context, backend addressBackend, addr common.Address, blockNum *big.Int) (*addressView, error) {
+	balance, err := backend.BalanceAt(ctx, addr, blockNum)
+	if err != nil {
+		return nil, fmt.Errorf("获取余额失败: %w", err)
+	}
+	nonce, err := backend.NonceAt(ctx, addr, blockNum)
+	if err != nil {
+		return nil, fmt.Errorf("获取 nonce 失败: %w", err)
+	}
+	code, err := backend.CodeAt(ctx, addr, blockNum)
+	if err != nil {
+		return nil, fmt.Errorf("获取合约代码失败: %w", err)
+	}
+	view := &addressView{
+		Address:    addr.Hex(),
+		Block:      "latest",
+		Balance:    balance.String(),
+		Nonce:      nonce,
+		IsContract: len(code) > 0,
+		CodeSize:   len(code),
+	}
+	if blockNum != nil {
+		view.Block = blockNum.String()
+		return view, nil
+	}
+	// 只有查询最新状态时 pending nonce 才有意义
+	pending, err := backend.PendingNonceAt(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("获取 pending nonce 失败: %w", err)
+	}
+	view.PendingNonce = &pending
+	return view, nil
+}
+
+func runQueryRange(args []string) error {
+	fs := flag.NewFlagSet("query-range", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "Sepolia RPC URL，例如 Infura endpoint")
+	fromArg := fs.String("from", "", "起始区块号（包含）")
+	toArg := fs.String("to", "latest", "结束区块号（包含）或 latest")
+	asJSON := fs.Bool("json", false, "以 JSON 输出")
+	timeout := fs.Duration("timeout", 2*time.Minute, "整体超时时间")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *fromArg == "" {
+		return fmt.Errorf("缺少必要参数：-from")
+	}
+	fromNum, err := parseBlockArg(*fromArg)
+	if err != nil {
+		return err
+	}
+	toNum, err := parseBlockArg(*toArg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := dialRPC(ctx, *rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if toNum == nil {
+		latest, err := client.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("获取最新区块号失败: %w", err)
+		}
+		toNum = new(big.Int).SetUint64(latest)
+	}
+	if fromNum == nil {
+		fromNum = toNum
+	}
+	view, err := queryRange(ctx, client, fromNum.Uint64(), toNum.Uint64())
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(view)
+	}
+	fmt.Printf("=== 区块 %d - %d 统计 ===\n", view.From, view.To)
+	fmt.Printf("区块数: %d（空块 %d）\n", view.Blocks, view.EmptyBlocks)
+	fmt.Printf("交易总数: %d（平均每块 %.2f）\n", view.Transactions, view.AvgTransactions)
+	fmt.Printf("GasUsed/GasLimit: %d / %d（利用率 %.2f%%）\n", view.GasUsed, view.GasLimit, view.GasUtilization*100)
+	if view.AvgBaseFee != "" {
+		fmt.Printf("BaseFee 最小/平均/最大: %s / %s / %s wei\n", view.MinBaseFee, view.AvgBaseFee, view.MaxBaseFee)
+	}
+	if view.Blocks > 1 {
+		fmt.Printf("平均出块间隔: %.2f 秒\n", view.AvgBlockTimeSecs)
+	}
+	return nil
+}
+
+// queryRange 只读取区块头与交易数量，不下载完整交易
+func queryRange(ctx context.Context, backend rangeBackend, from, to uint64) (*rangeView, error) {
+	if from > to {
+		return nil, fmt.Errorf("起始区块 %d 大于结束区块 %d", from, to)
+	}
+	// 用 to-from 比较，from=0、to=MaxUint64 时 to-from+1 会回绕成 0
+	if to-from >= maxRangeBlocks {
+		return nil, fmt.Errorf("区块范围过大：%d - %d，最多 %d 个", from, to, maxRangeBlocks)
+	}
+	view := &rangeView{From: from, To: to}
+	var (
+		minFee, maxFee *big.Int
+		sumFee         = new(big.Int)
+		feeBlocks      int64
+		firstTime      uint64
+		lastTime       uint64
+	)
+	for n := from; n <= to; n++ {
+		header, err := backend.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, fmt.Errorf("获取区块 %d 失败: %w", n, err)
+		}
+		count, err := backend.TransactionCount(ctx, header.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("获取区块 %d 交易数失败: %w", n, err)
+		}
+		view.Blocks++
+		view.Transactions += uint64(count)
+		if count == 0 {
+			view.EmptyBlocks++
+		}
+		view.GasUsed += header.GasUsed
+		view.GasLimit += header.GasLimit
+		if header.BaseFee != nil {
+			if minFee == nil || header.BaseFee.Cmp(minFee) < 0 {
+				minFee = header.BaseFee
+			}
+			if maxFee == nil || header.BaseFee.Cmp(maxFee) > 0 {
+				maxFee = header.BaseFee
+			}
+			sumFee.Add(sumFee, header.BaseFee)
+			feeBlocks++
+		}
+		if n == from {
+			firstTime = header.Time
+		}
+		lastTime = header.Time
+	}
+	view.AvgTransactions = float64(view.Transactions) / float64(view.Blocks)
+	if view.GasLimit > 0 {
+		view.GasUtilization = float64(view.GasUsed) / float64(view.GasLimit)
+	}
+	if feeBlocks > 0 {
+		view.MinBaseFee = minFee.String()
+		view.MaxBaseFee = maxFee.String()
+		view.AvgBaseFee = new(big.Int).Quo(sumFee, big.NewInt(feeBlocks)).String()
+	}
+	if view.Blocks > 1 {
+		view.AvgBlockTimeSecs = float64(lastTime-firstTime) / float64(view.Blocks-1)
+	}
+	return view, nil
+}
+
+// formatWei 把 wei 十进制字符串转换成 ETH
+func formatWei(wei string) string {
+	n, ok := new(big.Int).SetString(wei, 10)
+	if !ok {
+		return wei
+	}
+	return units.Format(n, 18)
+}
+
+// blockNumberLabel 用于错误信息中描述区块参数
+func blockNumberLabel(n *big.Int) string {
+	if n == nil {
+		return "latest"
+	}
+	return strconv.FormatUint(n.Uint64(), 10)
+}
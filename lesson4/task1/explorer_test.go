@@ -0,0 +1,401 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const explorerChainID = 11155111
+
+const transferABI = `[{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+	{"name":"from","type":"address","indexed":true},
+	{"name":"to","type":"address","indexed":true},
+	{"name":"value","type":"uint256","indexed":false}]}]`
+
+// fakeTxBackend 返回固定的交易与回执，记录是否查询过回执
+type fakeTxBackend struct {
+	tx         *types.Transaction
+	pending    bool
+	txErr      error
+	receipt    *types.Receipt
+	receiptErr error
+
+	receiptCalls int
+}
+
+func (b *fakeTxBackend) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	if b.txErr != nil {
+		return nil, false, b.txErr
+	}
+	return b.tx, b.pending, nil
+}
+
+func (b *fakeTxBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	b.receiptCalls++
+	if b.receiptErr != nil {
+		return nil, b.receiptErr
+	}
+	return b.receipt, nil
+}
+
+func signedTestTx(t *testing.T, inner types.TxData) (*types.Transaction, common.Address) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(big.NewInt(explorerChainID)), inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestQueryTxPending(t *testing.T) {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	tx, from := signedTestTx(t, &types.DynamicFeeTx{
+		ChainID:   big.NewInt(explorerChainID),
+		Nonce:     7,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+		Gas:       21000,
+		GasTipCap: big.NewInt(2e9),
+		GasFeeCap: big.NewInt(30e9),
+	})
+	backend := &fakeTxBackend{tx: tx, pending: true}
+	view, err := queryTx(context.Background(), backend, big.NewInt(explorerChainID), tx.Hash(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Status != "pending" || view.From != from.Hex() || view.To != to.Hex() || view.Nonce != 7 {
+		t.Fatalf("view = %+v", view)
+	}
+	if view.MaxFeePerGas != "30000000000" || view.MaxPriorityFee != "2000000000" || view.GasPrice != "" {
+		t.Fatalf("1559 费用字段 = %+v", view)
+	}
+	if view.BlockNumber != nil || view.Index != nil || view.GasUsed != 0 {
+		t.Fatalf("pending 交易不应有区块信息: %+v", view)
+	}
+	if backend.receiptCalls != 0 {
+		t.Fatalf("pending 交易查询了 %d 次回执", backend.receiptCalls)
+	}
+}
+
+func TestQueryTxMined(t *testing.T) {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	tx, _ := signedTestTx(t, &types.LegacyTx{
+		Nonce:    1,
+		To:       &to,
+		Gas:      60000,
+		GasPrice: big.NewInt(5e9),
+	})
+	parsed, err := abi.JSON(strings.NewReader(transferABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer := parsed.Events["Transfer"]
+	sender := common.HexToAddress("0x00000000000000000000000000000000000000c1")
+	recipient := common.HexToAddress("0x00000000000000000000000000000000000000c2")
+	data, err := transfer.Inputs.NonIndexed().Pack(big.NewInt(500))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt := &types.Receipt{
+		Status:            types.ReceiptStatusFailed,
+		BlockNumber:       big.NewInt(123),
+		BlockHash:         common.HexToHash("0x01"),
+		TransactionIndex:  4,
+		GasUsed:           50000,
+		EffectiveGasPrice: big.NewInt(5e9),
+		Logs: []*types.Log{
+			{Index: 9, Address: to, Topics: []common.Hash{transfer.ID, common.BytesToHash(sender.Bytes()), common.BytesToHash(recipient.Bytes())}, Data: data},
+			{Index: 10, Address: to, Topics: []common.Hash{common.HexToHash("0xdead")}},
+		},
+	}
+	backend := &fakeTxBackend{tx: tx, receipt: receipt}
+	view, err := queryTx(context.Background(), backend, big.NewInt(explorerChainID), tx.Hash(), []abi.ABI{parsed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Status != "failed" || *view.BlockNumber != 123 || *view.Index != 4 {
+		t.Fatalf("view = %+v", view)
+	}
+	if view.GasPrice != "5000000000" || view.MaxFeePerGas != "" {
+		t.Fatalf("legacy 费用字段 = %+v", view)
+	}
+	// fee = gasUsed * effectiveGasPrice
+	if view.Fee != "250000000000000" || view.ContractAddress != "" {
+		t.Fatalf("fee = %s, contract = %q", view.Fee, view.ContractAddress)
+	}
+	if len(view.Logs) != 2 || view.Logs[0].Event == nil || view.Logs[0].Event.Name != "Transfer" || view.Logs[0].Index != 9 {
+		t.Fatalf("logs[0] = %+v", view.Logs)
+	}
+	if view.Logs[1].Event != nil || len(view.Logs[1].Topics) != 1 {
+		t.Fatalf("未知事件不应解码: %+v", view.Logs[1])
+	}
+}
+
+func TestQueryTxContractCreation(t *testing.T) {
+	tx, from := signedTestTx(t, &types.DynamicFeeTx{
+		ChainID:   big.NewInt(explorerChainID),
+		Gas:       200000,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Data:      common.FromHex("0x6080604052"),
+	})
+	created := crypto.CreateAddress(from, 0)
+	backend := &fakeTxBackend{tx: tx, receipt: &types.Receipt{
+		Status:          types.ReceiptStatusSuccessful,
+		BlockNumber:     big.NewInt(5),
+		ContractAddress: created,
+		GasUsed:         150000,
+	}}
+	view, err := queryTx(context.Background(), backend, big.NewInt(explorerChainID), tx.Hash(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Status != "success" || view.To != "" || view.ContractAddress != created.Hex() || view.Input != "0x6080604052" {
+		t.Fatalf("view = %+v", view)
+	}
+	// 回执缺少 effectiveGasPrice 时不计算手续费
+	if view.Fee != "" || view.EffectiveGasPrice != "" {
+		t.Fatalf("fee = %q", view.Fee)
+	}
+}
+
+func TestQueryTxErrors(t *testing.T) {
+	tx, _ := signedTestTx(t, &types.LegacyTx{Gas: 21000, GasPrice: big.NewInt(1)})
+	cases := []struct {
+		name    string
+		backend *fakeTxBackend
+		want    string
+	}{
+		{"not found", &fakeTxBackend{txErr: ethereum.NotFound}, "交易不存在"},
+		{"rpc error", &fakeTxBackend{txErr: errors.New("connection refused")}, "获取交易失败"},
+		// 节点已返回交易但回执尚未索引
+		{"missing receipt", &fakeTxBackend{tx: tx, receiptErr: ethereum.NotFound}, "获取交易回执失败"},
+	}
+	for _, c := range cases {
+		_, err := queryTx(context.Background(), c.backend, big.NewInt(explorerChainID), tx.Hash(), nil)
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: err = %v, want %q", c.name, err, c.want)
+		}
+	}
+	// 链 ID 不匹配时无法恢复发送方
+	if _, err := queryTx(context.Background(), &fakeTxBackend{tx: tx}, big.NewInt(1), tx.Hash(), nil); err == nil || !strings.Contains(err.Error(), "恢复发送方失败") {
+		t.Errorf("wrong chain id: err = %v", err)
+	}
+}
+
+// fakeAddressBackend 只实现 query-address 用到的方法，其余调用会 panic
+type fakeAddressBackend struct {
+	addressBackend
+	balance      *big.Int
+	nonce        uint64
+	pendingNonce uint64
+	code         []byte
+	blocks       []*big.Int
+	pendingCalls int
+}
+
+func (b *fakeAddressBackend) BalanceAt(ctx context.Context, addr common.Address, block *big.Int) (*big.Int, error) {
+	b.blocks = append(b.blocks, block)
+	return b.balance, nil
+}
+
+func (b *fakeAddressBackend) NonceAt(ctx context.Context, addr common.Address, block *big.Int) (uint64, error) {
+	return b.nonce, nil
+}
+
+func (b *fakeAddressBackend) CodeAt(ctx context.Context, addr common.Address, block *big.Int) ([]byte, error) {
+	return b.code, nil
+}
+
+func (b *fakeAddressBackend) PendingNonceAt(ctx context.Context, addr common.Address) (uint64, error) {
+	b.pendingCalls++
+	return b.pendingNonce, nil
+}
+
+func TestQueryAddress(t *testing.T) {
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	eoa := &fakeAddressBackend{balance: big.NewInt(42), nonce: 3, pendingNonce: 5}
+	view, err := queryAddress(context.Background(), eoa, addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Block != "latest" || view.Balance != "42" || view.Nonce != 3 || view.IsContract || view.PendingNonce == nil || *view.PendingNonce != 5 {
+		t.Fatalf("latest view = %+v", view)
+	}
+
+	// 指定历史区块时不查询 pending nonce
+	contract := &fakeAddressBackend{balance: big.NewInt(0), code: []byte{0x60, 0x80, 0x60, 0x40}}
+	view, err = queryAddress(context.Background(), contract, addr, big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Block != "100" || !view.IsContract || view.CodeSize != 4 || view.PendingNonce != nil {
+		t.Fatalf("historical view = %+v", view)
+	}
+	if contract.pendingCalls != 0 || contract.blocks[0].Int64() != 100 {
+		t.Fatalf("pending calls = %d, block = %v", contract.pendingCalls, contract.blocks)
+	}
+}
+
+// fakeRangeBackend 按区块号返回预先构造的区块头；txCounts 以区块哈希为键
+type fakeRangeBackend struct {
+	headers  map[uint64]*types.Header
+	txCounts map[common.Hash]uint
+	calls    int
+}
+
+func (b *fakeRangeBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	b.calls++
+	header, ok := b.headers[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func (b *fakeRangeBackend) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return b.txCounts[blockHash], nil
+}
+
+// fakeBlock 构造区块头所需的字段，baseFee 为 0 表示合并前没有 baseFee 的区块
+type fakeBlock struct {
+	txs     uint
+	gasUsed uint64
+	baseFee int64
+	time    uint64
+}
+
+func newFakeRange(start uint64, blocks ...fakeBlock) *fakeRangeBackend {
+	b := &fakeRangeBackend{headers: map[uint64]*types.Header{}, txCounts: map[common.Hash]uint{}}
+	for i, blk := range blocks {
+		header := &types.Header{
+			Number:   new(big.Int).SetUint64(start + uint64(i)),
+			GasUsed:  blk.gasUsed,
+			GasLimit: 30_000_000,
+			Time:     blk.time,
+		}
+		if blk.baseFee > 0 {
+			header.BaseFee = big.NewInt(blk.baseFee)
+		}
+		b.headers[start+uint64(i)] = header
+		b.txCounts[header.Hash()] = blk.txs
+	}
+	return b
+}
+
+func TestQueryRange(t *testing.T) {
+	backend := newFakeRange(50,
+		fakeBlock{txs: 10, gasUsed: 15_000_000, baseFee: 100, time: 1000},
+		fakeBlock{txs: 0, gasUsed: 0, baseFee: 300, time: 1012},
+		fakeBlock{txs: 5, gasUsed: 3_000_000, baseFee: 200, time: 1024},
+	)
+	view, err := queryRange(context.Background(), backend, 50, 52)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Blocks != 3 || view.Transactions != 15 || view.EmptyBlocks != 1 || view.AvgTransactions != 5 {
+		t.Fatalf("counts = %+v", view)
+	}
+	if view.GasUsed != 18_000_000 || view.GasLimit != 90_000_000 || view.GasUtilization != 0.2 {
+		t.Fatalf("gas = %+v", view)
+	}
+	if view.MinBaseFee != "100" || view.MaxBaseFee != "300" || view.AvgBaseFee != "200" || view.AvgBlockTimeSecs != 12 {
+		t.Fatalf("fees/time = %+v", view)
+	}
+}
+
+func TestQueryRangeSingleBlockWithoutBaseFee(t *testing.T) {
+	backend := newFakeRange(7, fakeBlock{txs: 2, gasUsed: 42000, time: 1000})
+	view, err := queryRange(context.Background(), backend, 7, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 合并前的区块没有 baseFee；只有一个块时不计算出块间隔
+	if view.Blocks != 1 || view.AvgBaseFee != "" || view.AvgBlockTimeSecs != 0 {
+		t.Fatalf("view = %+v", view)
+	}
+}
+
+func TestQueryRangeErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		from, to uint64
+		want     string
+	}{
+		{"reversed", 10, 9, "大于结束区块"},
+		{"too many blocks", 0, maxRangeBlocks, "区块范围过大"},
+		{"whole chain", 0, math.MaxUint64, "区块范围过大"},
+		{"top of range", math.MaxUint64 - maxRangeBlocks, math.MaxUint64, "区块范围过大"},
+		{"missing block", 1, 1, "获取区块 1 失败"},
+	}
+	for _, c := range cases {
+		backend := &fakeRangeBackend{headers: map[uint64]*types.Header{}}
+		_, err := queryRange(context.Background(), backend, c.from, c.to)
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: err = %v, want %q", c.name, err, c.want)
+		}
+	}
+
+	// 恰好 maxRangeBlocks 个区块仍然允许，范围检查在发出任何请求之前完成
+	backend := &fakeRangeBackend{headers: map[uint64]*types.Header{}}
+	if _, err := queryRange(context.Background(), backend, 1, maxRangeBlocks); err == nil || !strings.Contains(err.Error(), "获取区块 1 失败") {
+		t.Fatalf("上限边界: err = %v", err)
+	}
+	if _, err := queryRange(context.Background(), backend, 1, maxRangeBlocks+1); err == nil || backend.calls != 1 {
+		t.Fatalf("超过上限时不应请求节点: calls = %d, err = %v", backend.calls, err)
+	}
+}
+
+func TestParseBlockArg(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string // 空字符串表示 latest（nil）
+		err  string
+	}{
+		{"", "", ""},
+		{"latest", "", ""},
+		{" LATEST ", "", ""},
+		{"0", "0", ""},
+		{"0x10", "16", ""},
+		{"18446744073709551615", "18446744073709551615", ""},
+		{"18446744073709551616", "", "区块号超出范围"},
+		{"0x10000000000000000", "", "区块号超出范围"},
+		{"-1", "", "无效区块号"},
+		{"pending", "", "无效区块号"},
+	}
+	for _, c := range cases {
+		n, err := parseBlockArg(c.in)
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("parseBlockArg(%q) err = %v, want %q", c.in, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseBlockArg(%q): %v", c.in, err)
+			continue
+		}
+		got := ""
+		if n != nil {
+			got = n.String()
+		}
+		if got != c.want {
+			t.Errorf("parseBlockArg(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"lesson4/signer"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// runImportKey 把已有的 16 进制私钥（或新生成的私钥）加密写入 keystore，之后即可改用 -keystore
+func runImportKey(args []string) error {
+	fs := flag.NewFlagSet("import-key", flag.ContinueOnError)
+	dir := fs.String("dir", "keystore", "keystore 输出目录")
+	generate := fs.Bool("new", false, "生成新私钥而不是导入已有私钥")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return fmt.Errorf("生成私钥失败: %w", err)
+	}
+	if !*generate {
+		keyHex, err := signer.PromptSecret("待导入的私钥（16进制，输入不回显）: ")
+		if err != nil {
+			return err
+		}
+		key, err = crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(keyHex), "0x"))
+		if err != nil {
+			return fmt.Errorf("私钥格式不正确: %w", err)
+		}
+	}
+
+	passphrase, err := signer.PromptSecret("设置 keystore 口令: ")
+	if err != nil {
+		return err
+	}
+	confirm, err := signer.PromptSecret("再次输入口令: ")
+	if err != nil {
+		return err
+	}
+	if passphrase != confirm {
+		return fmt.Errorf("两次输入的口令不一致")
+	}
+
+	account, err := signer.ImportToKeystore(*dir, key, passphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Println("=== keystore 已生成 ===")
+	fmt.Printf("地址: %s\n", account.Address.Hex())
+	fmt.Printf("文件: %s\n", account.URL.Path)
+	return nil
+}
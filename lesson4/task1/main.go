@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"flag"
 	"fmt"
 	"log"
@@ -11,9 +10,16 @@ import (
 	"strings"
 	"time"
 
+	"lesson4/abiutil"
+	"lesson4/signer"
+	"lesson4/txmgr"
+	"lesson4/units"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -25,18 +31,41 @@ func usage() {
 	fmt.Println("Sepolia 区块链交互工具")
 	fmt.Println("")
 	fmt.Println("用法:")
-	fmt.Println("  go run . query-block [-rpc <RPC_URL>] -block <区块号>")
-	fmt.Println("  go run . send-tx [-rpc <RPC_URL>] [-pk <私钥>] -to <接收地址> -amount <ETH数量>")
+	fmt.Println("  go run . query-block [-rpc <RPC_URL>] [-block <区块号|latest> | -hash <区块哈希>] [-json]")
+	fmt.Println("  go run . query-tx [-rpc <RPC_URL>] -hash <交易哈希> [-abi <ABI文件>] [-json]")
+	fmt.Println("  go run . query-address [-rpc <RPC_URL>] -address <地址> [-block <区块号|latest>] [-json]")
+	fmt.Println("  go run . query-range [-rpc <RPC_URL>] -from <起始区块> [-to <结束区块|latest>] [-json]")
+	fmt.Println("  go run . send-tx [-rpc <RPC_URL>] <签名参数> -to <接收地址> -amount <ETH数量> [-wait] [-confirmations 1]")
+	fmt.Println("  go run . send-tx [-rpc <RPC_URL>] <签名参数> -token <代币地址> -to <接收地址> -amount <代币数量>")
+	fmt.Println("  go run . send-tx [-rpc <RPC_URL>] <签名参数> -to <合约地址> -abi <ABI文件> -method '<方法签名>' [参数...]")
+	fmt.Println("  go run . tx-status [-rpc <RPC_URL>] <签名参数> [-watch] [-stuck 3m]")
+	fmt.Println("  go run . import-key [-dir ./keystore]")
+	fmt.Println("")
+	fmt.Println("冷钱包流程（签名步骤无需联网）:")
+	fmt.Println("  go run . build-tx [-rpc <RPC_URL>] -from <发送方地址> -to <接收地址> -amount <ETH数量> [-out unsigned-tx.json]")
+	fmt.Println("  go run . sign-tx <签名参数> [-in unsigned-tx.json] [-out signed-tx.txt]")
+	fmt.Println("  go run . broadcast [-rpc <RPC_URL>] (-in signed-tx.txt | -raw 0x...) [-wait]")
+	fmt.Println("")
+	fmt.Println("签名参数（任选其一）:")
+	fmt.Println("  -keystore <文件> [-password-file <口令文件>]  加密 JSON keystore，未提供口令文件时终端提示输入")
+	fmt.Println("  -mnemonic-file <文件> [-hd-path m/44'/60'/0'/0/0]  BIP-39 助记词")
+	fmt.Println("    [-mnemonic-passphrase-file <文件> | -mnemonic-passphrase]  钱包设置了 BIP-39 口令时使用")
+	fmt.Println("  -pk <私钥>  16进制私钥（不推荐，仅为兼容保留）")
 	fmt.Println("")
 	fmt.Println("环境变量（推荐，避免敏感参数出现在命令历史）:")
-	fmt.Println("  RPC_URL      Sepolia RPC URL")
-	fmt.Println("  PRIVATE_KEY  发送方私钥（16进制，支持带/不带0x）")
+	fmt.Println("  RPC_URL        Sepolia RPC URL")
+	fmt.Println("  KEYSTORE       keystore 文件路径")
+	fmt.Println("  PASSWORD_FILE  keystore 口令文件路径")
+	fmt.Println("  MNEMONIC_FILE  助记词文件路径")
+	fmt.Println("  MNEMONIC_PASSPHRASE_FILE  BIP-39 口令文件路径")
 	fmt.Println("")
 	fmt.Println("示例:")
 	fmt.Println("  $env:RPC_URL='https://sepolia.infura.io/v3/<INFURA_KEY>'")
-	fmt.Println("  $env:PRIVATE_KEY='<PRIVATE_KEY_HEX>'")
+	fmt.Println("  $env:KEYSTORE='.\\keystore\\UTC--...'")
 	fmt.Println("  go run . query-block -block 6000000")
-	fmt.Println("  go run . send-tx -to 0xabc... -amount 0.0001")
+	fmt.Println("  go run . query-tx -hash 0x... -json")
+	fmt.Println("  go run . send-tx -to 0xabc... -amount 0.0001 -wait")
+	fmt.Println("  go run . tx-status -watch")
 }
 
 func main() {
@@ -51,10 +80,42 @@ func main() {
 		if err := runQueryBlock(os.Args[2:]); err != nil {
 			log.Fatalf("查询区块失败: %v", err)
 		}
+	case "query-tx":
+		if err := runQueryTx(os.Args[2:]); err != nil {
+			log.Fatalf("查询交易失败: %v", err)
+		}
+	case "query-address":
+		if err := runQueryAddress(os.Args[2:]); err != nil {
+			log.Fatalf("查询地址失败: %v", err)
+		}
+	case "query-range":
+		if err := runQueryRange(os.Args[2:]); err != nil {
+			log.Fatalf("查询区块范围失败: %v", err)
+		}
 	case "send-tx":
 		if err := runSendTx(os.Args[2:]); err != nil {
 			log.Fatalf("发送交易失败: %v", err)
 		}
+	case "tx-status":
+		if err := runTxStatus(os.Args[2:]); err != nil {
+			log.Fatalf("查询交易状态失败: %v", err)
+		}
+	case "import-key":
+		if err := runImportKey(os.Args[2:]); err != nil {
+			log.Fatalf("导入私钥失败: %v", err)
+		}
+	case "build-tx":
+		if err := runBuildTx(os.Args[2:]); err != nil {
+			log.Fatalf("构建交易失败: %v", err)
+		}
+	case "sign-tx":
+		if err := runSignTx(os.Args[2:]); err != nil {
+			log.Fatalf("签名交易失败: %v", err)
+		}
+	case "broadcast":
+		if err := runBroadcast(os.Args[2:]); err != nil {
+			log.Fatalf("广播交易失败: %v", err)
+		}
 	default:
 		usage()
 		os.Exit(1)
@@ -64,72 +125,91 @@ func main() {
 func runQueryBlock(args []string) error {
 	fs := flag.NewFlagSet("query-block", flag.ContinueOnError)
 	rpcURL := fs.String("rpc", "", "Sepolia RPC URL，例如 Infura endpoint")
-	blockNum := fs.Uint64("block", 0, "区块号")
+	blockArg := fs.String("block", "latest", "区块号或 latest")
+	hashHex := fs.String("hash", "", "按区块哈希查询（优先于 -block）")
+	asJSON := fs.Bool("json", false, "以 JSON 输出")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
-	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
-	if resolvedRPC == "" {
-		return fmt.Errorf("-rpc 为空且未设置环境变量 RPC_URL")
+	blockNum, err := parseBlockArg(*blockArg)
+	if err != nil {
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
 
-	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	client, err := dialRPC(ctx, *rpcURL)
 	if err != nil {
-		return fmt.Errorf("连接 RPC 失败: %w", err)
+		return err
 	}
 	defer client.Close()
 
-	block, err := client.BlockByNumber(ctx, new(big.Int).SetUint64(*blockNum))
-	if err != nil {
-		return fmt.Errorf("获取区块失败: %w", err)
+	var block *types.Block
+	if *hashHex != "" {
+		hashBytes, err := hexutil.Decode(*hashHex)
+		if err != nil || len(hashBytes) != common.HashLength {
+			return fmt.Errorf("无效区块哈希: %s", *hashHex)
+		}
+		block, err = client.BlockByHash(ctx, common.BytesToHash(hashBytes))
+		if err != nil {
+			return fmt.Errorf("获取区块 %s 失败: %w", *hashHex, err)
+		}
+	} else {
+		block, err = client.BlockByNumber(ctx, blockNum)
+		if err != nil {
+			return fmt.Errorf("获取区块 %s 失败: %w", blockNumberLabel(blockNum), err)
+		}
 	}
 
-	fmt.Println("=== 区块信息 ===")
-	fmt.Printf("区块号: %d\n", block.NumberU64())
-	fmt.Printf("区块哈希: %s\n", block.Hash().Hex())
-	fmt.Printf("父区块哈希: %s\n", block.ParentHash().Hex())
-	fmt.Printf("时间戳: %d (%s)\n", block.Time(), time.Unix(int64(block.Time()), 0).UTC().Format(time.RFC3339))
-	fmt.Printf("交易数量: %d\n", len(block.Transactions()))
-	fmt.Printf("矿工地址(coinbase): %s\n", block.Coinbase().Hex())
-	fmt.Printf("GasUsed/GasLimit: %d / %d\n", block.GasUsed(), block.GasLimit())
-
+	view := newBlockView(block)
+	if *asJSON {
+		return printJSON(view)
+	}
+	printBlock(view)
 	return nil
 }
 
 func runSendTx(args []string) error {
 	fs := flag.NewFlagSet("send-tx", flag.ContinueOnError)
 	rpcURL := fs.String("rpc", "", "Sepolia RPC URL，例如 Infura endpoint")
-	privateKeyHex := fs.String("pk", "", "发送方私钥（16进制，不带0x）")
-	toAddrHex := fs.String("to", "", "接收方地址")
-	amountEth := fs.String("amount", "0", "转账金额（单位 ETH，例如 0.0001）")
-	chainID := fs.Int64("chainid", defaultChainID, "链 ID，Sepolia 默认 11155111")
+	signerFlags := signer.RegisterFlags(fs)
+	toAddrHex := fs.String("to", "", "接收方地址；配合 -abi 时为合约地址")
+	amount := fs.String("amount", "0", "转账金额（ETH；配合 -token 时为代币数量；配合 -abi 时为附带的 ETH）")
+	tokenHex := fs.String("token", "", "ERC-20 代币合约地址，指定后转账该代币")
+	abiPath := fs.String("abi", "", "合约 ABI 文件，配合 -method 调用任意合约方法")
+	methodSig := fs.String("method", "", "方法签名，例如 'transfer(address,uint256)'，方法参数按顺序放在所有选项之后")
+	gasLimit := fs.Uint64("gas", 0, "gas 上限，0 表示通过 EstimateGas 估算")
+	chainID := fs.Int64("chainid", 0, "链 ID，0 表示使用 RPC 返回的链 ID；指定时必须与 RPC 一致")
+	storePath := fs.String("store", defaultStorePath, "待确认交易记录文件")
+	wait := fs.Bool("wait", false, "等待交易达到确认深度（卡住时自动加价替换）")
+	confirmations := fs.Uint64("confirmations", 1, "确认深度，配合 -wait 使用")
+	timeout := fs.Duration("timeout", 5*time.Minute, "整体超时时间")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
-	resolvedPrivateKey := firstNonEmpty(*privateKeyHex, os.Getenv("PRIVATE_KEY"))
-	if resolvedRPC == "" || resolvedPrivateKey == "" || *toAddrHex == "" {
-		return fmt.Errorf("缺少必要参数：需要 -to，且需提供 -rpc 或 RPC_URL、-pk 或 PRIVATE_KEY")
+	if resolvedRPC == "" || *toAddrHex == "" {
+		return fmt.Errorf("缺少必要参数：需要 -to，且需提供 -rpc 或 RPC_URL")
 	}
 	if !common.IsHexAddress(*toAddrHex) {
 		return fmt.Errorf("接收地址格式不正确: %s", *toAddrHex)
 	}
+	if *tokenHex != "" && *abiPath != "" {
+		return fmt.Errorf("-token 与 -abi 不能同时使用")
+	}
+	if (*abiPath == "") != (*methodSig == "") {
+		return fmt.Errorf("-abi 与 -method 需要同时提供")
+	}
 
-	privateKey, fromAddr, err := parsePrivateKey(resolvedPrivateKey)
+	txSigner, err := signerFlags.Load()
 	if err != nil {
 		return err
 	}
+	fromAddr := txSigner.Address()
 	toAddr := common.HexToAddress(*toAddrHex)
 
-	valueWei, err := ethToWei(*amountEth)
-	if err != nil {
-		return fmt.Errorf("解析 -amount 失败: %w", err)
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
 	defer cancel()
 
 	client, err := ethclient.DialContext(ctx, resolvedRPC)
@@ -138,90 +218,142 @@ func runSendTx(args []string) error {
 	}
 	defer client.Close()
 
-	nonce, err := client.PendingNonceAt(ctx, fromAddr)
-	if err != nil {
-		return fmt.Errorf("获取 nonce 失败: %w", err)
+	var req txmgr.Request
+	switch {
+	case *tokenHex != "":
+		req, err = tokenTransferRequest(ctx, client, *tokenHex, toAddr, *amount)
+	case *abiPath != "":
+		var view bool
+		req, view, err = contractCallRequest(ctx, client, fromAddr, toAddr, *abiPath, *methodSig, *amount, fs.Args())
+		if err != nil || view {
+			// 只读方法已在 contractCallRequest 中调用并输出，不需要发交易
+			return err
+		}
+	default:
+		var valueWei *big.Int
+		if valueWei, err = units.ParseEther(*amount); err != nil {
+			return fmt.Errorf("解析 -amount 失败: %w", err)
+		}
+		fmt.Printf("金额(wei): %s\n", valueWei.String())
+		req = txmgr.Request{To: &toAddr, Value: valueWei}
 	}
-
-	gasTipCap, err := client.SuggestGasTipCap(ctx)
 	if err != nil {
-		return fmt.Errorf("获取 gasTipCap 失败: %w", err)
+		return err
 	}
+	req.Gas = *gasLimit
 
-	header, err := client.HeaderByNumber(ctx, nil)
+	mgr, err := newTxManager(ctx, client, txSigner, *chainID, *storePath, txmgr.Config{Confirmations: *confirmations})
 	if err != nil {
-		return fmt.Errorf("获取最新区块头失败: %w", err)
+		return err
 	}
-
-	// EIP-1559: maxFee 设为 2*baseFee + tip，适合作为测试网络的保守估计
-	gasFeeCap := new(big.Int).Mul(header.BaseFee, big.NewInt(2))
-	gasFeeCap = gasFeeCap.Add(gasFeeCap, gasTipCap)
-
-	const gasLimit = uint64(21000)
-
-	tx := types.NewTx(&types.DynamicFeeTx{
-		ChainID:   big.NewInt(*chainID),
-		Nonce:     nonce,
-		GasTipCap: gasTipCap,
-		GasFeeCap: gasFeeCap,
-		Gas:       gasLimit,
-		To:        &toAddr,
-		Value:     valueWei,
-		Data:      nil,
-	})
-
-	signedTx, err := types.SignTx(tx, types.NewLondonSigner(big.NewInt(*chainID)), privateKey)
+	rec, err := mgr.Send(ctx, req)
 	if err != nil {
-		return fmt.Errorf("签名交易失败: %w", err)
+		return err
 	}
-
-	if err := client.SendTransaction(ctx, signedTx); err != nil {
-		return fmt.Errorf("广播交易失败: %w", err)
+	tx, err := rec.Tx()
+	if err != nil {
+		return err
 	}
 
 	fmt.Println("=== 交易已发送 ===")
 	fmt.Printf("发送方: %s\n", fromAddr.Hex())
 	fmt.Printf("接收方: %s\n", toAddr.Hex())
-	fmt.Printf("金额(wei): %s\n", valueWei.String())
-	fmt.Printf("nonce: %d\n", nonce)
-	fmt.Printf("交易哈希: %s\n", signedTx.Hash().Hex())
-	fmt.Printf("浏览器链接: https://sepolia.etherscan.io/tx/%s\n", signedTx.Hash().Hex())
+	fmt.Printf("nonce: %d\n", rec.Nonce)
+	fmt.Printf("gas 上限: %d\n", tx.Gas())
+	fmt.Printf("交易哈希: %s\n", rec.Hash.Hex())
+	fmt.Printf("浏览器链接: https://sepolia.etherscan.io/tx/%s\n", rec.Hash.Hex())
 
+	if !*wait {
+		return nil
+	}
+	fmt.Printf("等待 %d 个确认...\n", *confirmations)
+	rec, err = mgr.Wait(ctx, rec.Hash)
+	if err != nil {
+		return fmt.Errorf("等待交易确认失败: %w", err)
+	}
+	printRecord(rec)
+	if rec.Status != txmgr.StatusConfirmed {
+		return fmt.Errorf("交易未成功确认，状态: %s", rec.Status)
+	}
 	return nil
 }
 
-func parsePrivateKey(privateKeyHex string) (*ecdsa.PrivateKey, common.Address, error) {
-	privateKeyHex = strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x")
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
+// tokenTransferRequest 读取代币 decimals，把 -amount 换算成最小单位后编码 transfer(to, value)
+func tokenTransferRequest(ctx context.Context, caller ethereum.ContractCaller, tokenHex string, to common.Address, amount string) (txmgr.Request, error) {
+	if !common.IsHexAddress(tokenHex) {
+		return txmgr.Request{}, fmt.Errorf("代币地址格式不正确: %s", tokenHex)
+	}
+	token := common.HexToAddress(tokenHex)
+	decimals, symbol, err := tokenInfo(ctx, caller, token)
 	if err != nil {
-		return nil, common.Address{}, fmt.Errorf("私钥格式不正确: %w", err)
+		return txmgr.Request{}, err
 	}
-	pubKey := privateKey.Public()
-	pubKeyECDSA, ok := pubKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, common.Address{}, fmt.Errorf("无法解析公钥")
+	value, err := units.Parse(amount, decimals)
+	if err != nil {
+		return txmgr.Request{}, fmt.Errorf("解析 -amount 失败: %w", err)
 	}
-	fromAddr := crypto.PubkeyToAddress(*pubKeyECDSA)
-	return privateKey, fromAddr, nil
+	data, err := erc20.Pack("transfer", to, value)
+	if err != nil {
+		return txmgr.Request{}, err
+	}
+	fmt.Printf("代币: %s %s (decimals=%d)\n", token.Hex(), symbol, decimals)
+	fmt.Printf("数量(最小单位): %s\n", value.String())
+	return txmgr.Request{To: &token, Data: data}, nil
 }
 
-func ethToWei(eth string) (*big.Int, error) {
-	if strings.TrimSpace(eth) == "" {
-		return nil, fmt.Errorf("金额为空")
+// contractCallRequest 按 ABI 编码方法调用；view/pure 方法直接 eth_call 并输出结果，返回 view=true
+func contractCallRequest(ctx context.Context, caller bind.ContractCaller, from, contract common.Address, abiPath, methodSig, amount string, rawArgs []string) (txmgr.Request, bool, error) {
+	// 向普通账户发送带 data 的交易同样会成功，提前拦截，避免以为调用了合约
+	code, err := caller.CodeAt(ctx, contract, nil)
+	if err != nil {
+		return txmgr.Request{}, false, fmt.Errorf("读取合约代码失败: %w", err)
+	}
+	if len(code) == 0 {
+		return txmgr.Request{}, false, fmt.Errorf("地址 %s 上没有合约代码", contract.Hex())
+	}
+	parsed, err := abiutil.LoadABI(abiPath)
+	if err != nil {
+		return txmgr.Request{}, false, err
+	}
+	method, err := abiutil.FindMethod(parsed, methodSig)
+	if err != nil {
+		return txmgr.Request{}, false, err
+	}
+	args, err := abiutil.ParseArgs(method.Inputs, rawArgs)
+	if err != nil {
+		return txmgr.Request{}, false, err
 	}
-	r, ok := new(big.Rat).SetString(eth)
-	if !ok {
-		return nil, fmt.Errorf("无效金额: %s", eth)
+	packed, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return txmgr.Request{}, false, fmt.Errorf("编码参数失败: %w", err)
 	}
+	data := append(append([]byte{}, method.ID...), packed...)
 
-	weiBase := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
-	r = new(big.Rat).Mul(r, weiBase)
+	if method.IsConstant() {
+		out, err := caller.CallContract(ctx, ethereum.CallMsg{From: from, To: &contract, Data: data}, nil)
+		if err != nil {
+			return txmgr.Request{}, true, fmt.Errorf("调用 %s 失败: %w", method.Sig, err)
+		}
+		vals, err := method.Outputs.Unpack(out)
+		if err != nil {
+			return txmgr.Request{}, true, fmt.Errorf("解码返回值失败: %w", err)
+		}
+		fmt.Printf("=== %s 返回值 ===\n", method.Sig)
+		for i, v := range vals {
+			fmt.Printf("[%d] %s %s = %s\n", i, method.Outputs[i].Type.String(), method.Outputs[i].Name, abiutil.FormatTyped(method.Outputs[i].Type, v))
+		}
+		return txmgr.Request{}, true, nil
+	}
 
-	if !r.IsInt() {
-		// 允许最多 18 位小数，超出则报错，避免精度隐患
-		return nil, fmt.Errorf("金额精度超过 18 位小数: %s", eth)
+	value, err := units.ParseEther(amount)
+	if err != nil {
+		return txmgr.Request{}, false, fmt.Errorf("解析 -amount 失败: %w", err)
+	}
+	if value.Sign() > 0 && !method.IsPayable() {
+		return txmgr.Request{}, false, fmt.Errorf("方法 %s 不是 payable，不能附带 ETH", method.Sig)
 	}
-	return r.Num(), nil
+	fmt.Printf("调用方法: %s\n", method.Sig)
+	return txmgr.Request{To: &contract, Value: value, Data: data}, false, nil
 }
 
 func firstNonEmpty(values ...string) string {
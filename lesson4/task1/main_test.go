@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lesson4/abiutil"
+	"lesson4/signer"
+	"lesson4/simtest"
+	"lesson4/txmgr"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const testTokenABI = "testdata/TestToken.abi"
+
+var recipient = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+// deployTestToken 部署 testdata/TestToken（精度 decimals，初始供应全部给部署账户）
+func deployTestToken(t *testing.T, chain *simtest.Chain, decimals uint8) (common.Address, abi.ABI) {
+	t.Helper()
+	parsed, err := abiutil.LoadABI(testTokenABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := abiutil.LoadBytecode("testdata/TestToken.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	supply := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)+6), nil)
+	auth := signer.TransactOpts(chain.Signer(), big.NewInt(simtest.ChainID))
+	addr, _, _, err := bind.DeployContract(auth, parsed, code, chain.Client, decimals, supply)
+	if err != nil {
+		t.Fatalf("部署 TestToken: %v", err)
+	}
+	chain.Sim.Commit()
+	return addr, parsed
+}
+
+// sendRequest 不指定 gas 发送请求并出块，返回实际打包的交易
+func sendRequest(t *testing.T, chain *simtest.Chain, req txmgr.Request) *types.Transaction {
+	t.Helper()
+	ctx := context.Background()
+	mgr, err := newTxManager(ctx, chain.Client, chain.Signer(), 0, filepath.Join(t.TempDir(), "txstore.json"), txmgr.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := mgr.Send(ctx, req)
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	chain.Sim.Commit()
+	tx, err := rec.Tx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := chain.Client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("receipt = %+v, %v", receipt, err)
+	}
+	return tx
+}
+
+func balanceOf(t *testing.T, chain *simtest.Chain, parsed abi.ABI, token, owner common.Address) *big.Int {
+	t.Helper()
+	vals, err := callView(context.Background(), chain.Client, token, parsed, "balanceOf", owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vals[0].(*big.Int)
+}
+
+func TestTokenTransferRequestScalesByDecimals(t *testing.T) {
+	chain := simtest.New(t, 1, 10)
+	ctx := context.Background()
+	for _, decimals := range []uint8{6, 18} {
+		token, parsed := deployTestToken(t, chain, decimals)
+		req, err := tokenTransferRequest(ctx, chain.Client, token.Hex(), recipient, "1.5")
+		if err != nil {
+			t.Fatalf("decimals=%d: %v", decimals, err)
+		}
+		if *req.To != token || req.Value != nil || req.Gas != 0 {
+			t.Fatalf("decimals=%d: request = %+v", decimals, req)
+		}
+		sendRequest(t, chain, req)
+
+		want := new(big.Int).Mul(big.NewInt(15), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)-1), nil))
+		if got := balanceOf(t, chain, parsed, token, recipient); got.Cmp(want) != 0 {
+			t.Fatalf("decimals=%d: 接收方余额 = %s, 期望 %s", decimals, got, want)
+		}
+	}
+}
+
+func TestTokenTransferRequestErrors(t *testing.T) {
+	chain := simtest.New(t, 1, 10)
+	token, _ := deployTestToken(t, chain, 6)
+	cases := []struct {
+		token  string
+		amount string
+		want   string
+	}{
+		// 6 位精度的代币不接受 7 位小数
+		{token.Hex(), "0.0000001", "解析 -amount 失败"},
+		{token.Hex(), "-1", "解析 -amount 失败"},
+		{"0x1234", "1", "代币地址格式不正确"},
+		{recipient.Hex(), "1", "可能不是合约"},
+	}
+	for _, c := range cases {
+		_, err := tokenTransferRequest(context.Background(), chain.Client, c.token, recipient, c.amount)
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("token=%s amount=%s: err = %v, want %q", c.token, c.amount, err, c.want)
+		}
+	}
+}
+
+func TestContractCallRequestEstimatesGas(t *testing.T) {
+	chain := simtest.New(t, 1, 10)
+	ctx := context.Background()
+	token, parsed := deployTestToken(t, chain, 6)
+	from := chain.Signer().Address()
+
+	req, view, err := contractCallRequest(ctx, chain.Client, from, token, testTokenABI, "transfer(address,uint256)", "0", []string{recipient.Hex(), "2500000"})
+	if err != nil || view {
+		t.Fatalf("transfer: view=%v err=%v", view, err)
+	}
+	estimated, err := chain.Client.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &token, Data: req.Data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 合约调用的 gas 来自 EstimateGas，而不是固定的 21000
+	if tx := sendRequest(t, chain, req); tx.Gas() != estimated || tx.Gas() <= 21000 {
+		t.Fatalf("gas = %d, 估算值 %d", tx.Gas(), estimated)
+	}
+	if got := balanceOf(t, chain, parsed, token, recipient); got.Int64() != 2500000 {
+		t.Fatalf("接收方余额 = %s", got)
+	}
+
+	// payable 方法附带 ETH
+	req, _, err = contractCallRequest(ctx, chain.Client, from, token, testTokenABI, "deposit", "0.5", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sendRequest(t, chain, req)
+	vals, err := callView(ctx, chain.Client, token, parsed, "deposits")
+	if err != nil || vals[0].(*big.Int).Cmp(big.NewInt(5e17)) != 0 {
+		t.Fatalf("deposits = %v, %v", vals, err)
+	}
+
+	// view 方法直接 eth_call，不生成交易
+	req, view, err = contractCallRequest(ctx, chain.Client, from, token, testTokenABI, "balanceOf", "0", []string{recipient.Hex()})
+	if err != nil || !view || req.To != nil {
+		t.Fatalf("balanceOf: req=%+v view=%v err=%v", req, view, err)
+	}
+}
+
+func TestContractCallRequestErrors(t *testing.T) {
+	chain := simtest.New(t, 1, 10)
+	token, _ := deployTestToken(t, chain, 6)
+	from := chain.Signer().Address()
+	cases := []struct {
+		name     string
+		contract common.Address
+		abiPath  string
+		method   string
+		amount   string
+		args     []string
+		want     string
+	}{
+		{"unknown signature", token, testTokenABI, "transfer(address)", "0", []string{recipient.Hex()}, "ABI 中没有签名为 transfer(address) 的方法"},
+		{"unknown name", token, testTokenABI, "mint", "0", nil, "ABI 中没有方法 mint"},
+		{"too few args", token, testTokenABI, "transfer", "0", []string{recipient.Hex()}, "参数个数不匹配：需要 2 个"},
+		{"too many args", token, testTokenABI, "deposit()", "0", []string{"1"}, "参数个数不匹配：需要 0 个"},
+		{"bad arg", token, testTokenABI, "transfer", "0", []string{"0x1", "1"}, "地址格式不正确"},
+		{"value on non-payable", token, testTokenABI, "transfer", "1", []string{recipient.Hex(), "1"}, "不是 payable"},
+		{"missing abi file", token, "testdata/missing.abi", "transfer", "0", nil, "读取 ABI 文件失败"},
+		{"not a contract", recipient, testTokenABI, "transfer", "0", []string{recipient.Hex(), "1"}, "上没有合约代码"},
+	}
+	for _, c := range cases {
+		_, _, err := contractCallRequest(context.Background(), chain.Client, from, c.contract, c.abiPath, c.method, c.amount, c.args)
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: err = %v, want %q", c.name, err, c.want)
+		}
+	}
+}
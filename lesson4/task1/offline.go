@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"lesson4/signer"
+	"lesson4/txmgr"
+	"lesson4/units"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// unsignedTx build-tx 输出、sign-tx 输入的未签名交易，字段编码与 JSON-RPC 保持一致
+type unsignedTx struct {
+	ChainID   *hexutil.Big    `json:"chainId"`
+	From      common.Address  `json:"from"`
+	Nonce     hexutil.Uint64  `json:"nonce"`
+	To        *common.Address `json:"to"`
+	Value     *hexutil.Big    `json:"value"`
+	Gas       hexutil.Uint64  `json:"gas"`
+	GasTipCap *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	GasFeeCap *hexutil.Big    `json:"maxFeePerGas"`
+	Data      hexutil.Bytes   `json:"data"`
+}
+
+func (u *unsignedTx) validate() error {
+	if u.ChainID == nil || u.Value == nil || u.GasTipCap == nil || u.GasFeeCap == nil {
+		return fmt.Errorf("未签名交易缺少 chainId/value/maxPriorityFeePerGas/maxFeePerGas 字段")
+	}
+	if u.Gas == 0 {
+		return fmt.Errorf("未签名交易的 gas 为 0")
+	}
+	if u.GasFeeCap.ToInt().Cmp(u.GasTipCap.ToInt()) < 0 {
+		return fmt.Errorf("maxFeePerGas 小于 maxPriorityFeePerGas")
+	}
+	return nil
+}
+
+func (u *unsignedTx) toTransaction() *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   u.ChainID.ToInt(),
+		Nonce:     uint64(u.Nonce),
+		GasTipCap: u.GasTipCap.ToInt(),
+		GasFeeCap: u.GasFeeCap.ToInt(),
+		Gas:       uint64(u.Gas),
+		To:        u.To,
+		Value:     u.Value.ToInt(),
+		Data:      u.Data,
+	})
+}
+
+// buildBackend build-tx 只需要读链上状态，不需要签名账户
+type buildBackend interface {
+	txmgr.FeeBackend
+	ethereum.GasEstimator
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+type buildRequest struct {
+	ChainID int64
+	From    common.Address
+	To      *common.Address
+	Value   *big.Int
+	Data    []byte
+	Gas     uint64 // 0 表示通过 EstimateGas 估算
+	Nonce   int64  // 负数表示读取链上 pending nonce
+}
+
+func buildUnsignedTx(ctx context.Context, backend buildBackend, req buildRequest) (*unsignedTx, error) {
+	value := req.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	nonce := uint64(req.Nonce)
+	if req.Nonce < 0 {
+		pending, err := backend.PendingNonceAt(ctx, req.From)
+		if err != nil {
+			return nil, fmt.Errorf("获取 nonce 失败: %w", err)
+		}
+		nonce = pending
+	}
+	gas := req.Gas
+	if gas == 0 {
+		estimated, err := backend.EstimateGas(ctx, ethereum.CallMsg{From: req.From, To: req.To, Value: value, Data: req.Data})
+		if err != nil {
+			return nil, fmt.Errorf("估算 gas 失败: %w", err)
+		}
+		gas = estimated
+	}
+	tipCap, feeCap, err := txmgr.SuggestFees(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &unsignedTx{
+		ChainID:   (*hexutil.Big)(big.NewInt(req.ChainID)),
+		From:      req.From,
+		Nonce:     hexutil.Uint64(nonce),
+		To:        req.To,
+		Value:     (*hexutil.Big)(value),
+		Gas:       hexutil.Uint64(gas),
+		GasTipCap: (*hexutil.Big)(tipCap),
+		GasFeeCap: (*hexutil.Big)(feeCap),
+		Data:      req.Data,
+	}, nil
+}
+
+// signUnsignedTx 完全离线；From 与签名账户不一致时拒绝签名，避免签错账户
+func signUnsignedTx(u *unsignedTx, txSigner signer.Signer) (*types.Transaction, error) {
+	if err := u.validate(); err != nil {
+		return nil, err
+	}
+	if u.From != (common.Address{}) && u.From != txSigner.Address() {
+		return nil, fmt.Errorf("交易 from=%s 与签名账户 %s 不一致", u.From.Hex(), txSigner.Address().Hex())
+	}
+	signed, err := txSigner.SignTx(u.toTransaction(), u.ChainID.ToInt())
+	if err != nil {
+		return nil, fmt.Errorf("签名交易失败: %w", err)
+	}
+	return signed, nil
+}
+
+func decodeRawTx(rawHex string) (*types.Transaction, error) {
+	raw, err := hexutil.Decode(strings.TrimSpace(rawHex))
+	if err != nil {
+		return nil, fmt.Errorf("原始交易不是合法的 0x 十六进制: %w", err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("解码原始交易失败: %w", err)
+	}
+	return tx, nil
+}
+
+// broadcastRawTx 校验签名后广播，返回解码出的交易与发送方
+func broadcastRawTx(ctx context.Context, backend ethereum.TransactionSender, rawHex string) (*types.Transaction, common.Address, error) {
+	tx, err := decodeRawTx(rawHex)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, common.Address{}, fmt.Errorf("交易签名无效: %w", err)
+	}
+	if err := backend.SendTransaction(ctx, tx); err != nil {
+		return nil, common.Address{}, fmt.Errorf("广播交易失败: %w", err)
+	}
+	return tx, from, nil
+}
+
+func runBuildTx(args []string) error {
+	fs := flag.NewFlagSet("build-tx", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "Sepolia RPC URL，例如 Infura endpoint")
+	fromHex := fs.String("from", "", "发送方地址（冷钱包地址，无需私钥）")
+	toAddrHex := fs.String("to", "", "接收方地址")
+	amountEth := fs.String("amount", "0", "转账金额（单位 ETH，例如 0.0001）")
+	dataHex := fs.String("data", "", "调用数据（0x 十六进制，可选）")
+	gasLimit := fs.Uint64("gas", 0, "gas 上限，0 表示自动估算")
+	nonce := fs.Int64("nonce", -1, "nonce，负数表示读取链上 pending nonce")
+	chainID := fs.Int64("chainid", defaultChainID, "链 ID，Sepolia 默认 11155111")
+	outPath := fs.String("out", "unsigned-tx.json", "未签名交易输出文件")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
+	if resolvedRPC == "" || *fromHex == "" || *toAddrHex == "" {
+		return fmt.Errorf("缺少必要参数：需要 -from、-to，且需提供 -rpc 或 RPC_URL")
+	}
+	if !common.IsHexAddress(*fromHex) || !common.IsHexAddress(*toAddrHex) {
+		return fmt.Errorf("地址格式不正确: from=%s to=%s", *fromHex, *toAddrHex)
+	}
+	toAddr := common.HexToAddress(*toAddrHex)
+	valueWei, err := units.ParseEther(*amountEth)
+	if err != nil {
+		return fmt.Errorf("解析 -amount 失败: %w", err)
+	}
+	var data []byte
+	if *dataHex != "" {
+		if data, err = hexutil.Decode(*dataHex); err != nil {
+			return fmt.Errorf("解析 -data 失败: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	if err != nil {
+		return fmt.Errorf("连接 RPC 失败: %w", err)
+	}
+	defer client.Close()
+
+	utx, err := buildUnsignedTx(ctx, client, buildRequest{
+		ChainID: *chainID,
+		From:    common.HexToAddress(*fromHex),
+		To:      &toAddr,
+		Value:   valueWei,
+		Data:    data,
+		Gas:     *gasLimit,
+		Nonce:   *nonce,
+	})
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(utx, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*outPath, out, 0o644); err != nil {
+		return fmt.Errorf("写入未签名交易失败: %w", err)
+	}
+
+	fmt.Println("=== 未签名交易已生成 ===")
+	fmt.Printf("文件: %s\n", *outPath)
+	fmt.Printf("nonce: %d, gas: %d\n", utx.Nonce, utx.Gas)
+	fmt.Printf("待签名哈希: %s\n", types.LatestSignerForChainID(big.NewInt(*chainID)).Hash(utx.toTransaction()).Hex())
+	return nil
+}
+
+func runSignTx(args []string) error {
+	fs := flag.NewFlagSet("sign-tx", flag.ContinueOnError)
+	signerFlags := signer.RegisterFlags(fs)
+	inPath := fs.String("in", "unsigned-tx.json", "未签名交易文件（build-tx 生成）")
+	outPath := fs.String("out", "signed-tx.txt", "已签名原始交易输出文件")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	in, err := os.ReadFile(*inPath)
+	if err != nil {
+		return fmt.Errorf("读取未签名交易失败: %w", err)
+	}
+	var utx unsignedTx
+	if err := json.Unmarshal(in, &utx); err != nil {
+		return fmt.Errorf("解析未签名交易失败: %w", err)
+	}
+	txSigner, err := signerFlags.Load()
+	if err != nil {
+		return err
+	}
+	signed, err := signUnsignedTx(&utx, txSigner)
+	if err != nil {
+		return err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*outPath, []byte(hexutil.Encode(raw)+"\n"), 0o644); err != nil {
+		return fmt.Errorf("写入已签名交易失败: %w", err)
+	}
+
+	fmt.Println("=== 交易已离线签名 ===")
+	fmt.Printf("签名账户: %s\n", txSigner.Address().Hex())
+	fmt.Printf("文件: %s\n", *outPath)
+	fmt.Printf("交易哈希: %s\n", signed.Hash().Hex())
+	return nil
+}
+
+func runBroadcast(args []string) error {
+	fs := flag.NewFlagSet("broadcast", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "Sepolia RPC URL，例如 Infura endpoint")
+	inPath := fs.String("in", "", "已签名原始交易文件（sign-tx 生成）")
+	rawHex := fs.String("raw", "", "已签名原始交易（0x 十六进制），与 -in 二选一")
+	wait := fs.Bool("wait", false, "等待交易上链")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
+	if resolvedRPC == "" {
+		return fmt.Errorf("-rpc 为空且未设置环境变量 RPC_URL")
+	}
+	raw := *rawHex
+	if *inPath != "" {
+		content, err := os.ReadFile(*inPath)
+		if err != nil {
+			return fmt.Errorf("读取已签名交易失败: %w", err)
+		}
+		raw = string(content)
+	}
+	if strings.TrimSpace(raw) == "" {
+		return fmt.Errorf("缺少必要参数：需提供 -in 或 -raw")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	if err != nil {
+		return fmt.Errorf("连接 RPC 失败: %w", err)
+	}
+	defer client.Close()
+
+	tx, from, err := broadcastRawTx(ctx, client, raw)
+	if err != nil {
+		return err
+	}
+	fmt.Println("=== 交易已广播 ===")
+	fmt.Printf("发送方: %s\n", from.Hex())
+	fmt.Printf("nonce: %d\n", tx.Nonce())
+	fmt.Printf("交易哈希: %s\n", tx.Hash().Hex())
+	fmt.Printf("浏览器链接: https://sepolia.etherscan.io/tx/%s\n", tx.Hash().Hex())
+	if !*wait {
+		return nil
+	}
+
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return fmt.Errorf("等待交易上链失败: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("交易执行失败，txHash=%s", tx.Hash().Hex())
+	}
+	fmt.Printf("已上链，区块高度: %d\n", receipt.BlockNumber.Uint64())
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"lesson4/signer"
+	"lesson4/simtest"
+	"lesson4/units"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+func newSimulatedChain(t *testing.T) (*simulated.Backend, signer.Signer) {
+	t.Helper()
+	chain := simtest.New(t, 1, 10)
+	return chain.Sim, chain.Signer()
+}
+
+func TestBuildSignBroadcast(t *testing.T) {
+	sim, txSigner := newSimulatedChain(t)
+	client := sim.Client()
+	ctx := context.Background()
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	value, err := units.ParseEther("0.25")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1. 在线构建：只需要地址
+	utx, err := buildUnsignedTx(ctx, client, buildRequest{
+		ChainID: simtest.ChainID,
+		From:    txSigner.Address(),
+		To:      &to,
+		Value:   value,
+		Nonce:   -1,
+	})
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if utx.Gas != 21000 {
+		t.Fatalf("估算 gas = %d, 期望 21000", utx.Gas)
+	}
+	encoded, err := json.Marshal(utx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2. 离线签名：只依赖文件内容与签名账户
+	var decoded unsignedTx
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	signed, err := signUnsignedTx(&decoded, txSigner)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 3. 广播原始交易
+	tx, from, err := broadcastRawTx(ctx, client, hexutil.Encode(raw)+"\n")
+	if err != nil {
+		t.Fatalf("broadcast: %v", err)
+	}
+	if from != txSigner.Address() || tx.Hash() != signed.Hash() {
+		t.Fatalf("广播结果不一致: from=%s hash=%s", from.Hex(), tx.Hash().Hex())
+	}
+	sim.Commit()
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatalf("receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("交易执行失败: status=%d", receipt.Status)
+	}
+	balance, err := client.BalanceAt(ctx, to, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(value) != 0 {
+		t.Fatalf("接收方余额 = %s, 期望 %s", balance, value)
+	}
+}
+
+func TestSignTxRejectsWrongAccount(t *testing.T) {
+	sim, txSigner := newSimulatedChain(t)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	utx, err := buildUnsignedTx(context.Background(), sim.Client(), buildRequest{
+		ChainID: simtest.ChainID,
+		From:    txSigner.Address(),
+		To:      &to,
+		Value:   big.NewInt(1),
+		Nonce:   -1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, other := newSimulatedChain(t)
+	if _, err := signUnsignedTx(utx, other); err == nil || !strings.Contains(err.Error(), "不一致") {
+		t.Fatalf("期望拒绝签名, got %v", err)
+	}
+}
+
+func TestBroadcastRejectsInvalidRaw(t *testing.T) {
+	sim, _ := newSimulatedChain(t)
+	for _, raw := range []string{"", "0xzz", "0x01020304"} {
+		if _, _, err := broadcastRawTx(context.Background(), sim.Client(), raw); err == nil {
+			t.Fatalf("raw=%q 期望返回错误", raw)
+		}
+	}
+}
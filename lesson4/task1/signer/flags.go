@@ -0,0 +1,164 @@
+package signer
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// ErrNoSigner 未配置任何签名后端
+var ErrNoSigner = errors.New("未配置签名账户：需提供 -keystore、-mnemonic-file 或 -pk（也可使用环境变量 KEYSTORE、MNEMONIC_FILE、PRIVATE_KEY）")
+
+// Flags 各子命令共用的签名参数；口令与助记词只从文件或终端读取，不经过命令行和环境变量
+type Flags struct {
+	Keystore     string
+	PasswordFile string
+	MnemonicFile string
+	HDPath       string
+	PrivateKey   string
+
+	// MnemonicPassphraseFile 与 AskMnemonicPassphrase 用于设置了 BIP-39 口令（"第 25 个词"）的钱包
+	MnemonicPassphraseFile string
+	AskMnemonicPassphrase  bool
+}
+
+// RegisterFlags 在子命令的 FlagSet 上注册签名参数
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{}
+	fs.StringVar(&f.Keystore, "keystore", "", "加密 JSON keystore 文件（可用环境变量 KEYSTORE 代替）")
+	fs.StringVar(&f.PasswordFile, "password-file", "", "keystore 口令文件，未提供时在终端提示输入（可用环境变量 PASSWORD_FILE 代替）")
+	fs.StringVar(&f.MnemonicFile, "mnemonic-file", "", "BIP-39 助记词文件（可用环境变量 MNEMONIC_FILE 代替）")
+	fs.StringVar(&f.HDPath, "hd-path", DefaultHDPath, "助记词派生路径")
+	fs.StringVar(&f.MnemonicPassphraseFile, "mnemonic-passphrase-file", "", "BIP-39 口令文件，钱包设置了助记词口令时使用（可用环境变量 MNEMONIC_PASSPHRASE_FILE 代替）")
+	fs.BoolVar(&f.AskMnemonicPassphrase, "mnemonic-passphrase", false, "在终端提示输入 BIP-39 口令")
+	fs.StringVar(&f.PrivateKey, "pk", "", "私钥（16进制，不推荐，仅为兼容保留）")
+	return f
+}
+
+// Load 按 keystore > 助记词 > 16 进制私钥 的优先级创建签名后端
+func (f *Flags) Load() (Signer, error) {
+	if path := firstNonEmpty(f.Keystore, os.Getenv("KEYSTORE")); path != "" {
+		passphrase, err := f.readPassphrase("keystore 口令: ")
+		if err != nil {
+			return nil, err
+		}
+		return FromKeystore(path, passphrase)
+	}
+	if path := firstNonEmpty(f.MnemonicFile, os.Getenv("MNEMONIC_FILE")); path != "" {
+		mnemonic, err := readSecretFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取助记词文件失败: %w", err)
+		}
+		passphrase, err := f.readMnemonicPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		s, err := FromMnemonic(mnemonic, passphrase, f.HDPath)
+		if err != nil {
+			return nil, err
+		}
+		// 口令不同会静默派生出另一个账户，打印地址便于核对
+		fmt.Fprintf(os.Stderr, "助记词派生账户: %s（路径 %s，BIP-39 口令: %s）\n", s.Address().Hex(), f.hdPath(), describePassphrase(passphrase))
+		return s, nil
+	}
+	if pk := firstNonEmpty(f.PrivateKey, os.Getenv("PRIVATE_KEY")); pk != "" {
+		fmt.Fprintln(os.Stderr, "警告: 正在使用明文私钥（-pk / PRIVATE_KEY），建议改用 -keystore 或 -mnemonic-file")
+		return FromPrivateKeyHex(pk)
+	}
+	return nil, ErrNoSigner
+}
+
+func (f *Flags) readPassphrase(prompt string) (string, error) {
+	if path := firstNonEmpty(f.PasswordFile, os.Getenv("PASSWORD_FILE")); path != "" {
+		passphrase, err := readSecretFile(path)
+		if err != nil {
+			return "", fmt.Errorf("读取口令文件失败: %w", err)
+		}
+		return passphrase, nil
+	}
+	return PromptSecret(prompt)
+}
+
+// readMnemonicPassphrase 未配置时返回空口令，与大多数钱包的默认设置一致
+func (f *Flags) readMnemonicPassphrase() (string, error) {
+	if path := firstNonEmpty(f.MnemonicPassphraseFile, os.Getenv("MNEMONIC_PASSPHRASE_FILE")); path != "" {
+		passphrase, err := readSecretFile(path)
+		if err != nil {
+			return "", fmt.Errorf("读取 BIP-39 口令文件失败: %w", err)
+		}
+		return passphrase, nil
+	}
+	if f.AskMnemonicPassphrase {
+		return PromptSecret("BIP-39 口令: ")
+	}
+	return "", nil
+}
+
+func (f *Flags) hdPath() string {
+	if f.HDPath == "" {
+		return DefaultHDPath
+	}
+	return f.HDPath
+}
+
+func describePassphrase(passphrase string) string {
+	if passphrase == "" {
+		return "无"
+	}
+	return "已设置"
+}
+
+// PromptSecret 在终端提示输入且不回显；标准输入不是终端时（例如管道）读取一行
+func PromptSecret(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		secret, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("读取输入失败: %w", err)
+		}
+		return string(secret), nil
+	}
+	line, err := stdinReader().ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("读取输入失败: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// stdin 非终端输入共用一个带缓冲的 reader：每次新建 reader 会把缓冲中已读走的后续行丢掉，
+// 导入私钥时连续提示的第二次输入就读不到了
+var (
+	stdinOnce sync.Once
+	stdin     *bufio.Reader
+)
+
+func stdinReader() *bufio.Reader {
+	stdinOnce.Do(func() { stdin = bufio.NewReader(os.Stdin) })
+	return stdin
+}
+
+// readSecretFile 只去掉末尾换行，口令中的其他空白保持原样
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// FromKeystore 用口令解密 go-ethereum 的加密 JSON keystore 文件（geth account new 生成的 UTC--* 文件）
+func FromKeystore(path, passphrase string) (Signer, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取 keystore 失败: %w", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("解密 keystore 失败: %w", err)
+	}
+	return newKeySigner(key.PrivateKey), nil
+}
+
+// ImportToKeystore 把私钥加密保存到 dir 下，文件名与 geth 保持一致，返回生成的文件路径
+func ImportToKeystore(dir string, key *ecdsa.PrivateKey, passphrase string) (accounts.Account, error) {
+	ks := keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP)
+	account, err := ks.ImportECDSA(key, passphrase)
+	if err != nil {
+		return accounts.Account{}, fmt.Errorf("写入 keystore 失败: %w", err)
+	}
+	return account, nil
+}
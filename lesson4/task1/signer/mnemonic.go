@@ -0,0 +1,82 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultHDPath 与 MetaMask、Ledger 等钱包默认的第一个账户一致
+const DefaultHDPath = "m/44'/60'/0'/0/0"
+
+var errInvalidChildKey = errors.New("派生出无效子私钥，请换用下一个索引")
+
+// FromMnemonic 按 BIP-39 从助记词生成种子，再按 BIP-32/BIP-44 派生路径得到私钥
+func FromMnemonic(mnemonic, password, hdPath string) (Signer, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("助记词无效（单词或校验和错误）")
+	}
+	if hdPath == "" {
+		hdPath = DefaultHDPath
+	}
+	path, err := accounts.ParseDerivationPath(hdPath)
+	if err != nil {
+		return nil, fmt.Errorf("派生路径格式错误: %w", err)
+	}
+	seed := bip39.NewSeed(mnemonic, password)
+	key, err := deriveKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return newKeySigner(key), nil
+}
+
+// deriveKey BIP-32 私钥派生：硬化索引使用父私钥，普通索引使用压缩公钥
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	n := crypto.S256().Params().N
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, key...)
+		} else {
+			parent, err := crypto.ToECDSA(key)
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&parent.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		il := new(big.Int).SetBytes(sum[:32])
+		if il.Cmp(n) >= 0 {
+			return nil, errInvalidChildKey
+		}
+		child := il.Add(il, new(big.Int).SetBytes(key))
+		child.Mod(child, n)
+		if child.Sign() == 0 {
+			return nil, errInvalidChildKey
+		}
+		key, chainCode = child.FillBytes(make([]byte, 32)), sum[32:]
+	}
+	return crypto.ToECDSA(key)
+}
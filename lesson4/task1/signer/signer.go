@@ -0,0 +1,67 @@
+// Package signer 提供交易签名后端：加密 JSON keystore、BIP-39 助记词以及（兼容用的）16 进制私钥。
+// 调用方只依赖 Signer 接口，不需要接触私钥本身。
+package signer
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer 交易签名后端
+type Signer interface {
+	Address() common.Address
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// keySigner 持有解密后的私钥，只存在于进程内存中
+type keySigner struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func newKeySigner(key *ecdsa.PrivateKey) *keySigner {
+	return &keySigner{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+func (s *keySigner) Address() common.Address {
+	return s.addr
+}
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// FromPrivateKeyHex 解析 16 进制私钥（支持带/不带 0x），仅为兼容旧的 -pk / PRIVATE_KEY 用法
+func FromPrivateKeyHex(privateKeyHex string) (Signer, error) {
+	privateKeyHex = strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x")
+	key, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("私钥格式不正确: %w", err)
+	}
+	return newKeySigner(key), nil
+}
+
+// SignerFn 适配 bind.SignerFn，供 abigen 绑定与 txmgr 使用
+func SignerFn(s Signer, chainID *big.Int) bind.SignerFn {
+	return func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if addr != s.Address() {
+			return nil, bind.ErrNotAuthorized
+		}
+		return s.SignTx(tx, chainID)
+	}
+}
+
+// TransactOpts 与 bind.NewKeyedTransactorWithChainID 等价，但签名由 Signer 完成
+func TransactOpts(s Signer, chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:   s.Address(),
+		Signer: SignerFn(s, chainID),
+	}
+}
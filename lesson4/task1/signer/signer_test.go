@@ -0,0 +1,165 @@
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Hardhat / Anvil 默认助记词，对应的账户与私钥是公开的测试向量
+const hardhatMnemonic = "test test test test test test test test test test test junk"
+
+func TestFromMnemonic(t *testing.T) {
+	cases := []struct {
+		mnemonic, password, path string
+		want                     string
+	}{
+		{hardhatMnemonic, "", "", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{hardhatMnemonic, "", "m/44'/60'/0'/0/1", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{hardhatMnemonic, "", "m/44'/60'/0'/0/2", "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"},
+		// 多余空白与换行不影响结果
+		{"  test test test test test test\ntest test test test test junk\n", "", DefaultHDPath, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+	}
+	for _, c := range cases {
+		s, err := FromMnemonic(c.mnemonic, c.password, c.path)
+		if err != nil {
+			t.Fatalf("%s: %v", c.path, err)
+		}
+		if s.Address() != common.HexToAddress(c.want) {
+			t.Errorf("%s: address = %s, want %s", c.path, s.Address().Hex(), c.want)
+		}
+	}
+
+	// 私钥与 Hardhat 第一个账户一致
+	s, _ := FromMnemonic(hardhatMnemonic, "", DefaultHDPath)
+	if got := common.Bytes2Hex(crypto.FromECDSA(s.(*keySigner).key)); got != "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80" {
+		t.Fatalf("private key = %s", got)
+	}
+	// BIP-39 口令参与种子计算，得到不同的账户
+	if other, err := FromMnemonic(hardhatMnemonic, "extra", DefaultHDPath); err != nil || other.Address() == s.Address() {
+		t.Fatalf("with password = %v, %v", other, err)
+	}
+}
+
+func TestFromMnemonicErrors(t *testing.T) {
+	cases := []struct{ name, mnemonic, path, want string }{
+		{"bad checksum", "test test test test test test test test test test test test", DefaultHDPath, "助记词无效"},
+		{"unknown word", "test test test test test test test test test test test notaword", DefaultHDPath, "助记词无效"},
+		{"bad path", hardhatMnemonic, "m/44'/x", "派生路径格式错误"},
+	}
+	for _, c := range cases {
+		if _, err := FromMnemonic(c.mnemonic, "", c.path); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%s: err = %v, want %q", c.name, err, c.want)
+		}
+	}
+}
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	account, err := ImportToKeystore(dir, key, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := crypto.PubkeyToAddress(key.PublicKey)
+	if account.Address != want || filepath.Dir(account.URL.Path) != dir {
+		t.Fatalf("account = %+v", account)
+	}
+
+	s, err := FromKeystore(account.URL.Path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Address() != want {
+		t.Fatalf("unlocked address = %s, want %s", s.Address().Hex(), want.Hex())
+	}
+	if _, err := FromKeystore(account.URL.Path, "wrong"); err == nil || !strings.Contains(err.Error(), "解密 keystore 失败") {
+		t.Fatalf("wrong passphrase err = %v", err)
+	}
+	if _, err := FromKeystore(filepath.Join(dir, "missing"), "correct horse"); err == nil || !strings.Contains(err.Error(), "读取 keystore 失败") {
+		t.Fatalf("missing file err = %v", err)
+	}
+
+	// 同一私钥不能重复导入
+	if _, err := ImportToKeystore(dir, key, "correct horse"); err == nil {
+		t.Fatal("期望重复导入失败")
+	}
+
+	// 通过参数加载：口令文件末尾的换行被去掉
+	passwordFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(passwordFile, []byte("correct horse\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := (&Flags{Keystore: account.URL.Path, PasswordFile: passwordFile}).Load()
+	if err != nil || loaded.Address() != want {
+		t.Fatalf("Flags.Load = %v, %v", loaded, err)
+	}
+}
+
+// 管道输入时连续两次提示应依次读到两行，导入私钥的流程依赖这一点
+func TestPromptSecretPipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteString("first secret\r\nsecond\n"); err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Close()
+	prevStdin, prevStderr := os.Stdin, os.Stderr
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdin, os.Stderr = r, devNull
+	defer func() { os.Stdin, os.Stderr = prevStdin, prevStderr }()
+
+	for _, want := range []string{"first secret", "second"} {
+		got, err := PromptSecret("> ")
+		if err != nil || got != want {
+			t.Fatalf("PromptSecret = %q, %v, want %q", got, err, want)
+		}
+	}
+	if _, err := PromptSecret("> "); err == nil {
+		t.Fatal("输入结束后期望返回错误")
+	}
+}
+
+// 设置了 BIP-39 口令的助记词必须从参数读到口令，否则会静默派生出另一个账户
+func TestFlagsMnemonicPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	mnemonicFile := filepath.Join(dir, "mnemonic")
+	passphraseFile := filepath.Join(dir, "passphrase")
+	if err := os.WriteFile(mnemonicFile, []byte(hardhatMnemonic+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(passphraseFile, []byte("extra\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	want, err := FromMnemonic(hardhatMnemonic, "extra", DefaultHDPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MNEMONIC_PASSPHRASE_FILE", "")
+
+	plain, err := (&Flags{MnemonicFile: mnemonicFile}).Load()
+	if err != nil || plain.Address() != common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266") {
+		t.Fatalf("无口令 Load = %v, %v", plain, err)
+	}
+	withFlag, err := (&Flags{MnemonicFile: mnemonicFile, MnemonicPassphraseFile: passphraseFile}).Load()
+	if err != nil || withFlag.Address() != want.Address() {
+		t.Fatalf("-mnemonic-passphrase-file Load = %v, %v", withFlag, err)
+	}
+	t.Setenv("MNEMONIC_PASSPHRASE_FILE", passphraseFile)
+	withEnv, err := (&Flags{MnemonicFile: mnemonicFile}).Load()
+	if err != nil || withEnv.Address() != want.Address() {
+		t.Fatalf("MNEMONIC_PASSPHRASE_FILE Load = %v, %v", withEnv, err)
+	}
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+// send-tx 测试用的最小 ERC-20：精度由构造参数指定，另带一个 payable 方法用于测试附带 ETH 的合约调用。
+// 修改后用 solc（evmVersion cancun）重新编译，ABI 与字节码分别保存为同目录的 TestToken.abi、TestToken.bin
+pragma solidity ^0.8.20;
+
+contract TestToken {
+    string public constant name = "Test USD";
+    string public constant symbol = "TUSD";
+    uint8 public immutable decimals;
+    uint256 public totalSupply;
+    uint256 public deposits;
+    mapping(address => uint256) public balanceOf;
+
+    event Transfer(address indexed from, address indexed to, uint256 value);
+
+    constructor(uint8 decimals_, uint256 supply) {
+        decimals = decimals_;
+        totalSupply = supply;
+        balanceOf[msg.sender] = supply;
+        emit Transfer(address(0), msg.sender, supply);
+    }
+
+    function transfer(address to, uint256 value) external returns (bool) {
+        require(balanceOf[msg.sender] >= value, "insufficient balance");
+        balanceOf[msg.sender] -= value;
+        balanceOf[to] += value;
+        emit Transfer(msg.sender, to, value);
+        return true;
+    }
+
+    function deposit() external payable {
+        deposits += msg.value;
+    }
+}
@@ -0,0 +1,74 @@
+package txmgr
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// minBumpPercent 节点交易池替换同 nonce 交易时要求 tip 与 feeCap 都至少上涨 10%
+const minBumpPercent = 10
+
+// FeeBackend 估算 EIP-1559 费用所需的最小接口
+type FeeBackend interface {
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// SuggestFees 与 send-tx 原有逻辑一致：maxFee = 2*baseFee + tip，适合作为测试网络的保守估计
+func SuggestFees(ctx context.Context, backend FeeBackend) (tipCap, feeCap *big.Int, err error) {
+	tipCap, err = backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取 gasTipCap 失败: %w", err)
+	}
+	header, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取最新区块头失败: %w", err)
+	}
+	feeCap = new(big.Int).Mul(header.BaseFee, big.NewInt(2))
+	feeCap.Add(feeCap, tipCap)
+	return tipCap, feeCap, nil
+}
+
+func (m *Manager) suggestFees(ctx context.Context) (tipCap, feeCap *big.Int, err error) {
+	return SuggestFees(ctx, m.backend)
+}
+
+// bumpedFees 计算替换交易的费用：取「旧值上浮 BumpPercent」与「当前建议值」中的较大者
+func (m *Manager) bumpedFees(ctx context.Context, old *types.Transaction) (tipCap, feeCap *big.Int, err error) {
+	suggestTip, suggestCap, err := m.suggestFees(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	tipCap = maxBig(bumpBig(old.GasTipCap(), m.cfg.BumpPercent), suggestTip)
+	feeCap = maxBig(bumpBig(old.GasFeeCap(), m.cfg.BumpPercent), suggestCap)
+	if feeCap.Cmp(tipCap) < 0 {
+		feeCap = new(big.Int).Set(tipCap)
+	}
+	if m.cfg.MaxFeeCap != nil && feeCap.Cmp(m.cfg.MaxFeeCap) > 0 {
+		return nil, nil, fmt.Errorf("%w: 需要 %s wei，上限 %s wei", ErrFeeCapExceeded, feeCap, m.cfg.MaxFeeCap)
+	}
+	return tipCap, feeCap, nil
+}
+
+// bumpBig 返回 v*(100+percent)/100，向上取整，保证严格满足交易池的涨幅要求
+func bumpBig(v *big.Int, percent int64) *big.Int {
+	if v == nil || v.Sign() == 0 {
+		return big.NewInt(1)
+	}
+	num := new(big.Int).Mul(v, big.NewInt(100+percent))
+	q, r := new(big.Int).QuoRem(num, big.NewInt(100), new(big.Int))
+	if r.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
+
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
@@ -0,0 +1,546 @@
+// Package txmgr 管理单个签名账户的交易生命周期：分配 nonce、持久化待确认交易、
+// 按确认深度轮询回执，并对低价或长时间未打包的交易加价替换。
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrUnknownTx      = errors.New("交易未被跟踪")
+	ErrFeeCapExceeded = errors.New("加价后 maxFee 超过上限")
+	ErrMaxBumps       = errors.New("加价替换次数已达上限")
+	ErrNonceResync    = errors.New("重新同步 nonce 后仍然过低")
+)
+
+// maxNonceResyncs Send 遇到 nonce too low 时最多重新同步的次数，与加价次数分开计算
+const maxNonceResyncs = 3
+
+// 节点返回的错误经过 RPC 后只剩字符串，按消息匹配
+const (
+	errMsgUnderpriced        = "transaction underpriced"
+	errMsgReplaceUnderpriced = "replacement transaction underpriced"
+	errMsgNonceTooLow        = "nonce too low"
+	errMsgAlreadyKnown       = "already known"
+)
+
+// Backend ethclient.Client 与 simulated.Client 都满足该接口
+type Backend interface {
+	ethereum.ChainIDReader
+	ethereum.BlockNumberReader
+	ethereum.GasEstimator
+	ethereum.TransactionReader
+	ethereum.TransactionSender
+	FeeBackend
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// Config 零值字段会在 New 中填充默认值
+type Config struct {
+	Confirmations uint64        // 确认深度，默认 1
+	PollInterval  time.Duration // 轮询间隔，默认 4s
+	StuckAfter    time.Duration // 广播后超过该时长仍未打包视为卡住，默认 3 分钟
+	BumpPercent   int64         // 每次替换的加价百分比，最小 10
+	MaxBumps      int           // 单个 nonce 最多替换次数，默认 5
+	MaxFeeCap     *big.Int      // 可选，加价后的 maxFee 上限（wei）
+}
+
+func (c *Config) setDefaults() {
+	if c.Confirmations == 0 {
+		c.Confirmations = 1
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = 4 * time.Second
+	}
+	if c.StuckAfter <= 0 {
+		c.StuckAfter = 3 * time.Minute
+	}
+	if c.BumpPercent < minBumpPercent {
+		c.BumpPercent = minBumpPercent
+	}
+	if c.MaxBumps <= 0 {
+		c.MaxBumps = 5
+	}
+}
+
+// Request 待发送交易的内容，Gas 为 0 时通过 EstimateGas 估算
+type Request struct {
+	To    *common.Address
+	Value *big.Int
+	Data  []byte
+	Gas   uint64
+}
+
+// Manager 独占一个账户的 nonce 序列；同一账户不要同时使用多个 Manager
+type Manager struct {
+	backend Backend
+	from    common.Address
+	signer  bind.SignerFn
+	store   Store
+	cfg     Config
+
+	mu        sync.Mutex
+	chainID   *big.Int
+	nextNonce uint64
+	synced    bool
+	records   map[uint64]*Record
+}
+
+// New 创建 Manager 并从 store 恢复该账户之前未完成的交易
+func New(backend Backend, from common.Address, signer bind.SignerFn, store Store, cfg Config) (*Manager, error) {
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	cfg.setDefaults()
+	records, err := store.Load(from)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manager{
+		backend: backend,
+		from:    from,
+		signer:  signer,
+		store:   store,
+		cfg:     cfg,
+		records: make(map[uint64]*Record, len(records)),
+	}
+	for _, r := range records {
+		m.records[r.Nonce] = r
+	}
+	return m, nil
+}
+
+func (m *Manager) From() common.Address {
+	return m.from
+}
+
+func (m *Manager) Config() Config {
+	return m.cfg
+}
+
+// ReserveNonce 分配下一个 nonce，供 abigen 绑定等自行构造交易的调用方使用，
+// 发送成功后需调用 Adopt 纳入跟踪，发送失败需调用 ReleaseNonce 归还
+func (m *Manager) ReserveNonce(ctx context.Context) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.reserveNonceLocked(ctx)
+}
+
+// ReleaseNonce 归还最近一次分配但未使用的 nonce；不是最后分配的 nonce 时不做处理，
+// 留下的空洞会在下次同步链上 nonce 时被修正
+func (m *Manager) ReleaseNonce(nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.synced && nonce+1 == m.nextNonce {
+		m.nextNonce = nonce
+	}
+}
+
+func (m *Manager) reserveNonceLocked(ctx context.Context) (uint64, error) {
+	if !m.synced {
+		if err := m.syncNonceLocked(ctx); err != nil {
+			return 0, err
+		}
+	}
+	nonce := m.nextNonce
+	m.nextNonce++
+	return nonce, nil
+}
+
+// syncNonceLocked 以链上 pending nonce 与本地未完成记录中较大者为准
+func (m *Manager) syncNonceLocked(ctx context.Context) error {
+	pending, err := m.backend.PendingNonceAt(ctx, m.from)
+	if err != nil {
+		return fmt.Errorf("获取 nonce 失败: %w", err)
+	}
+	next := pending
+	for nonce, r := range m.records {
+		if !r.Final() && nonce+1 > next {
+			next = nonce + 1
+		}
+	}
+	m.nextNonce = next
+	m.synced = true
+	return nil
+}
+
+func (m *Manager) chainIDLocked(ctx context.Context) (*big.Int, error) {
+	if m.chainID == nil {
+		id, err := m.backend.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("获取链 ID 失败: %w", err)
+		}
+		m.chainID = id
+	}
+	return m.chainID, nil
+}
+
+// Send 分配 nonce、填充 EIP-1559 费用、签名并广播；遇到 underpriced 时自动加价重试
+func (m *Manager) Send(ctx context.Context, req Request) (*Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	chainID, err := m.chainIDLocked(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value := req.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	gas := req.Gas
+	if gas == 0 {
+		gas, err = m.backend.EstimateGas(ctx, ethereum.CallMsg{From: m.from, To: req.To, Value: value, Data: req.Data})
+		if err != nil {
+			return nil, fmt.Errorf("估算 gas 失败: %w", err)
+		}
+	}
+	tipCap, feeCap, err := m.suggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := m.reserveNonceLocked(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        req.To,
+		Value:     value,
+		Data:      req.Data,
+	})
+	bumps, resyncs := 0, 0
+	for {
+		signed, err := m.signer(m.from, tx)
+		if err != nil {
+			m.releaseLocked(nonce)
+			return nil, fmt.Errorf("签名交易失败: %w", err)
+		}
+		err = m.backend.SendTransaction(ctx, signed)
+		if err == nil || errContains(err, errMsgAlreadyKnown) {
+			rec := m.newRecord(signed)
+			rec.Bumps = bumps
+			return rec.clone(), m.saveLocked(rec)
+		}
+		switch {
+		case errContains(err, errMsgNonceTooLow):
+			// 本地 nonce 落后于链上（其他客户端也在用该账户），重新同步后换 nonce 再发；
+			// 节点一直返回该错误时不能无限重试
+			if resyncs >= maxNonceResyncs {
+				m.releaseLocked(nonce)
+				return nil, fmt.Errorf("%w: %v", ErrNonceResync, err)
+			}
+			resyncs++
+			m.synced = false
+			if nonce, err = m.reserveNonceLocked(ctx); err != nil {
+				return nil, err
+			}
+			tx = withNonce(tx, nonce)
+		case errContains(err, errMsgUnderpriced) || errContains(err, errMsgReplaceUnderpriced):
+			if bumps >= m.cfg.MaxBumps {
+				m.releaseLocked(nonce)
+				return nil, fmt.Errorf("%w: %v", ErrMaxBumps, err)
+			}
+			tipCap, feeCap, err := m.bumpedFees(ctx, tx)
+			if err != nil {
+				m.releaseLocked(nonce)
+				return nil, err
+			}
+			tx = withFees(tx, tipCap, feeCap)
+			bumps++
+		default:
+			m.releaseLocked(nonce)
+			return nil, fmt.Errorf("广播交易失败: %w", err)
+		}
+	}
+}
+
+// Adopt 将调用方自行签名并已广播的交易纳入跟踪（例如 abigen 绑定发出的交易）
+func (m *Manager) Adopt(tx *types.Transaction) (*Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.synced && tx.Nonce() >= m.nextNonce {
+		m.nextNonce = tx.Nonce() + 1
+	}
+	rec := m.newRecord(tx)
+	return rec.clone(), m.saveLocked(rec)
+}
+
+func (m *Manager) newRecord(tx *types.Transaction) *Record {
+	raw, _ := tx.MarshalBinary()
+	now := time.Now().UTC()
+	rec := &Record{
+		From:      m.from,
+		Nonce:     tx.Nonce(),
+		Hash:      tx.Hash(),
+		RawTx:     raw,
+		Status:    StatusPending,
+		SentAt:    now,
+		UpdatedAt: now,
+	}
+	if old, ok := m.records[tx.Nonce()]; ok && !old.Final() && old.Hash != tx.Hash() {
+		rec.History = old.Hashes()
+		rec.Bumps = old.Bumps
+	}
+	m.records[rec.Nonce] = rec
+	return rec
+}
+
+func (m *Manager) releaseLocked(nonce uint64) {
+	if nonce+1 == m.nextNonce {
+		m.nextNonce = nonce
+	}
+}
+
+func (m *Manager) saveLocked(rec *Record) error {
+	rec.UpdatedAt = time.Now().UTC()
+	if err := m.store.Save(rec); err != nil {
+		return fmt.Errorf("保存交易记录失败: %w", err)
+	}
+	return nil
+}
+
+// Replace 以更高费用重新广播指定 nonce 的交易（加速卡住的交易）
+func (m *Manager) Replace(ctx context.Context, nonce uint64) (*Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec, ok := m.records[nonce]
+	if !ok || rec.Final() {
+		return nil, fmt.Errorf("%w: nonce=%d", ErrUnknownTx, nonce)
+	}
+	if err := m.replaceLocked(ctx, rec); err != nil {
+		return nil, err
+	}
+	return rec.clone(), nil
+}
+
+func (m *Manager) replaceLocked(ctx context.Context, rec *Record) error {
+	if rec.Bumps >= m.cfg.MaxBumps {
+		return fmt.Errorf("%w: nonce=%d", ErrMaxBumps, rec.Nonce)
+	}
+	old, err := rec.Tx()
+	if err != nil {
+		return err
+	}
+	tipCap, feeCap, err := m.bumpedFees(ctx, old)
+	if err != nil {
+		return err
+	}
+	signed, err := m.signer(m.from, withFees(old, tipCap, feeCap))
+	if err != nil {
+		return fmt.Errorf("签名替换交易失败: %w", err)
+	}
+	if err := m.backend.SendTransaction(ctx, signed); err != nil && !errContains(err, errMsgAlreadyKnown) {
+		// nonce too low 说明旧交易已被打包，交给下一轮 Poll 处理
+		if errContains(err, errMsgNonceTooLow) {
+			return nil
+		}
+		return fmt.Errorf("广播替换交易失败: %w", err)
+	}
+	raw, _ := signed.MarshalBinary()
+	rec.History = append(rec.History, rec.Hash)
+	rec.Hash = signed.Hash()
+	rec.RawTx = raw
+	rec.Bumps++
+	rec.SentAt = time.Now().UTC()
+	return m.saveLocked(rec)
+}
+
+// Poll 检查所有未完成交易一次：更新打包与确认状态，并替换卡住的交易
+func (m *Manager) Poll(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending := m.pendingLocked()
+	if len(pending) == 0 {
+		return nil
+	}
+	head, err := m.backend.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("获取最新区块高度失败: %w", err)
+	}
+	// 先取 nonce 再查回执：nonce 已被消耗但查不到任何回执，才能确定是被其他交易占用
+	latestNonce, err := m.backend.NonceAt(ctx, m.from, nil)
+	if err != nil {
+		return fmt.Errorf("获取 nonce 失败: %w", err)
+	}
+
+	for _, rec := range pending {
+		receipt, err := m.findReceipt(ctx, rec)
+		if err != nil {
+			return err
+		}
+		if receipt != nil {
+			m.applyReceipt(rec, receipt, head)
+			if err := m.saveLocked(rec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		changed := rec.Status != StatusPending
+		rec.Status, rec.MinedHash, rec.BlockNumber, rec.Confirmations = StatusPending, common.Hash{}, 0, 0
+		switch {
+		case latestNonce > rec.Nonce:
+			rec.Status = StatusDropped
+			changed = true
+		case rec.Nonce == latestNonce && time.Since(rec.SentAt) > m.cfg.StuckAfter && rec.Bumps < m.cfg.MaxBumps:
+			// 只有下一个待打包的 nonce 才会阻塞后续交易，更高的 nonce 等它打包后再判断
+			if err := m.replaceLocked(ctx, rec); err != nil {
+				return err
+			}
+			continue
+		}
+		if changed {
+			if err := m.saveLocked(rec); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findReceipt 依次查询该 nonce 下广播过的每个哈希，任何一个被打包都算数
+func (m *Manager) findReceipt(ctx context.Context, rec *Record) (*types.Receipt, error) {
+	hashes := rec.Hashes()
+	for i := len(hashes) - 1; i >= 0; i-- {
+		receipt, err := m.backend.TransactionReceipt(ctx, hashes[i])
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("获取交易回执失败(%s): %w", hashes[i].Hex(), err)
+		}
+		return receipt, nil
+	}
+	return nil, nil
+}
+
+func (m *Manager) applyReceipt(rec *Record, receipt *types.Receipt, head uint64) {
+	block := receipt.BlockNumber.Uint64()
+	rec.MinedHash = receipt.TxHash
+	rec.BlockNumber = block
+	rec.Confirmations = 0
+	if head >= block {
+		rec.Confirmations = head - block + 1
+	}
+	switch {
+	case rec.Confirmations < m.cfg.Confirmations:
+		rec.Status = StatusMined
+	case receipt.Status == types.ReceiptStatusSuccessful:
+		rec.Status = StatusConfirmed
+	default:
+		rec.Status = StatusFailed
+	}
+}
+
+// Wait 持续轮询直到指定交易进入终态；hash 可以是该 nonce 下任意一次广播的哈希
+func (m *Manager) Wait(ctx context.Context, hash common.Hash) (*Record, error) {
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.Poll(ctx); err != nil {
+			return nil, err
+		}
+		rec, ok := m.Status(hash)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTx, hash.Hex())
+		}
+		if rec.Final() {
+			return rec, nil
+		}
+		select {
+		case <-ctx.Done():
+			return rec, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status 按任意历史哈希查找交易记录
+func (m *Manager) Status(hash common.Hash) (*Record, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, rec := range m.records {
+		for _, h := range rec.Hashes() {
+			if h == hash {
+				return rec.clone(), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Records 返回该账户全部交易记录，按 nonce 升序
+func (m *Manager) Records() []*Record {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]*Record, 0, len(m.records))
+	for _, rec := range m.records {
+		out = append(out, rec.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Nonce < out[j].Nonce })
+	return out
+}
+
+func (m *Manager) pendingLocked() []*Record {
+	var out []*Record
+	for _, rec := range m.records {
+		if !rec.Final() {
+			out = append(out, rec)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Nonce < out[j].Nonce })
+	return out
+}
+
+func withNonce(tx *types.Transaction, nonce uint64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   tx.ChainId(),
+		Nonce:     nonce,
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	})
+}
+
+// withFees 替换交易统一使用 DynamicFeeTx；legacy 交易的 GasTipCap/GasFeeCap 都等于 gasPrice，
+// 按同样规则加价即可满足交易池的替换条件
+func withFees(tx *types.Transaction, tipCap, feeCap *big.Int) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:    tx.ChainId(),
+		Nonce:      tx.Nonce(),
+		GasTipCap:  tipCap,
+		GasFeeCap:  feeCap,
+		Gas:        tx.Gas(),
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	})
+}
+
+func errContains(err error, msg string) bool {
+	return err != nil && strings.Contains(err.Error(), msg)
+}
@@ -0,0 +1,307 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"lesson4/signer"
+	"lesson4/simtest"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+var testTo = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+type testChain struct {
+	sim    *simulated.Backend
+	client simulated.Client
+	from   common.Address
+	signer bind.SignerFn
+}
+
+func newTestChain(t *testing.T) *testChain {
+	t.Helper()
+	chain := simtest.New(t, 1, 10)
+	s := chain.Signer()
+	return &testChain{sim: chain.Sim, client: chain.Client, from: s.Address(), signer: signer.SignerFn(s, big.NewInt(simtest.ChainID))}
+}
+
+func (c *testChain) manager(t *testing.T, backend Backend, store Store, cfg Config) *Manager {
+	t.Helper()
+	if backend == nil {
+		backend = c.client
+	}
+	m, err := New(backend, c.from, c.signer, store, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// scriptedBackend 前几次 SendTransaction 依次返回 errs 中的错误，之后交给真实后端
+type scriptedBackend struct {
+	Backend
+	errs  []error
+	sends int
+}
+
+func (b *scriptedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.sends++
+	if len(b.errs) > 0 {
+		err := b.errs[0]
+		b.errs = b.errs[1:]
+		return err
+	}
+	return b.Backend.SendTransaction(ctx, tx)
+}
+
+// feeBackend 固定的费用建议，只用于 bumpedFees
+type feeBackend struct {
+	Backend
+	tip, baseFee int64
+}
+
+func (b *feeBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(b.tip), nil
+}
+
+func (b *feeBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(b.baseFee)}, nil
+}
+
+func TestBumpBig(t *testing.T) {
+	cases := []struct {
+		v       *big.Int
+		percent int64
+		want    int64
+	}{
+		{big.NewInt(100), 10, 110},
+		{big.NewInt(101), 10, 112}, // 111.1 向上取整
+		{big.NewInt(7), 12, 8},     // 7.84
+		{big.NewInt(1), 10, 2},     // 1.1，不能停在 1
+		{big.NewInt(0), 10, 1},
+		{nil, 10, 1},
+	}
+	for _, c := range cases {
+		if got := bumpBig(c.v, c.percent); got.Int64() != c.want {
+			t.Errorf("bumpBig(%v, %d) = %s, want %d", c.v, c.percent, got, c.want)
+		}
+	}
+}
+
+func TestBumpedFees(t *testing.T) {
+	old := types.NewTx(&types.DynamicFeeTx{GasTipCap: big.NewInt(100), GasFeeCap: big.NewInt(1000)})
+	cases := []struct {
+		name         string
+		tip, baseFee int64
+		maxFeeCap    *big.Int
+		wantTip      int64
+		wantCap      int64
+		wantErr      error
+	}{
+		// 建议值更低：旧值上浮 10%
+		{name: "bump old", tip: 1, baseFee: 1, wantTip: 110, wantCap: 1100},
+		// 建议值更高：使用建议值，maxFee = 2*baseFee + tip
+		{name: "suggestion", tip: 500, baseFee: 1000, wantTip: 500, wantCap: 2500},
+		{name: "cap ok", tip: 1, baseFee: 1, maxFeeCap: big.NewInt(1100), wantTip: 110, wantCap: 1100},
+		{name: "cap exceeded", tip: 1, baseFee: 1, maxFeeCap: big.NewInt(1099), wantErr: ErrFeeCapExceeded},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := Config{MaxFeeCap: c.maxFeeCap}
+			cfg.setDefaults()
+			m := &Manager{backend: &feeBackend{tip: c.tip, baseFee: c.baseFee}, cfg: cfg}
+			tip, feeCap, err := m.bumpedFees(context.Background(), old)
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Fatalf("err = %v, want %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tip.Int64() != c.wantTip || feeCap.Int64() != c.wantCap {
+				t.Fatalf("fees = %s/%s, want %d/%d", tip, feeCap, c.wantTip, c.wantCap)
+			}
+		})
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	underpriced := errors.New(errMsgUnderpriced)
+	nonceTooLow := errors.New(errMsgNonceTooLow)
+	cases := []struct {
+		name      string
+		errs      []error
+		maxBumps  int
+		wantBumps int
+		wantSends int
+		wantErr   error
+	}{
+		{name: "underpriced then ok", errs: []error{underpriced}, wantBumps: 1, wantSends: 2},
+		// 重新同步 nonce 不计入加价次数
+		{name: "nonce too low then ok", errs: []error{nonceTooLow, nonceTooLow}, wantBumps: 0, wantSends: 3},
+		{name: "mixed", errs: []error{nonceTooLow, underpriced}, wantBumps: 1, wantSends: 3},
+		{name: "nonce too low forever", errs: []error{nonceTooLow, nonceTooLow, nonceTooLow, nonceTooLow, nonceTooLow}, wantSends: maxNonceResyncs + 1, wantErr: ErrNonceResync},
+		// 加价预算不会被重新同步消耗
+		{name: "bumps exhausted", errs: []error{nonceTooLow, underpriced, underpriced}, maxBumps: 1, wantSends: 3, wantErr: ErrMaxBumps},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			chain := newTestChain(t)
+			backend := &scriptedBackend{Backend: chain.client, errs: c.errs}
+			m := chain.manager(t, backend, nil, Config{MaxBumps: c.maxBumps})
+			rec, err := m.Send(context.Background(), Request{To: &testTo, Value: big.NewInt(1)})
+			if backend.sends != c.wantSends {
+				t.Fatalf("sends = %d, want %d", backend.sends, c.wantSends)
+			}
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Fatalf("err = %v, want %v", err, c.wantErr)
+				}
+				// 失败后 nonce 归还，下一笔仍从 0 开始
+				if nonce, err := m.ReserveNonce(context.Background()); err != nil || nonce != 0 {
+					t.Fatalf("next nonce = %d, %v", nonce, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rec.Bumps != c.wantBumps || rec.Nonce != 0 {
+				t.Fatalf("record = %+v, want bumps %d", rec, c.wantBumps)
+			}
+		})
+	}
+}
+
+func TestPollReplacesStuckTx(t *testing.T) {
+	chain := newTestChain(t)
+	ctx := context.Background()
+	m := chain.manager(t, nil, nil, Config{Confirmations: 2, StuckAfter: time.Nanosecond})
+
+	sent, err := m.Send(ctx, Request{To: &testTo, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 不出块，交易卡在交易池中，Poll 以更高费用替换
+	if err := m.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	replaced, ok := m.Status(sent.Hash)
+	if !ok || replaced.Bumps != 1 || replaced.Hash == sent.Hash || len(replaced.History) != 1 || replaced.History[0] != sent.Hash {
+		t.Fatalf("replaced = %+v", replaced)
+	}
+	oldTx, _ := sent.Tx()
+	newTx, err := replaced.Tx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newTx.GasTipCap().Cmp(bumpBig(oldTx.GasTipCap(), minBumpPercent)) < 0 || newTx.GasFeeCap().Cmp(bumpBig(oldTx.GasFeeCap(), minBumpPercent)) < 0 {
+		t.Fatalf("fees %s/%s -> %s/%s", oldTx.GasTipCap(), oldTx.GasFeeCap(), newTx.GasTipCap(), newTx.GasFeeCap())
+	}
+
+	chain.sim.Commit()
+	if err := m.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if rec, _ := m.Status(sent.Hash); rec.Status != StatusMined || rec.MinedHash != replaced.Hash || rec.Confirmations != 1 {
+		t.Fatalf("after one block = %+v", rec)
+	}
+	chain.sim.Commit()
+	if err := m.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if rec, _ := m.Status(replaced.Hash); rec.Status != StatusConfirmed || rec.Confirmations != 2 {
+		t.Fatalf("after two blocks = %+v", rec)
+	}
+}
+
+func TestPollMaxBumps(t *testing.T) {
+	chain := newTestChain(t)
+	ctx := context.Background()
+	m := chain.manager(t, nil, nil, Config{StuckAfter: time.Nanosecond, MaxBumps: 1})
+	sent, err := m.Send(ctx, Request{To: &testTo, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := m.Poll(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rec, _ := m.Status(sent.Hash); rec.Bumps != 1 || rec.Status != StatusPending {
+		t.Fatalf("record = %+v", rec)
+	}
+	if _, err := m.Replace(ctx, sent.Nonce); !errors.Is(err, ErrMaxBumps) {
+		t.Fatalf("Replace err = %v", err)
+	}
+}
+
+func TestPollDetectsDropped(t *testing.T) {
+	chain := newTestChain(t)
+	ctx := context.Background()
+	m := chain.manager(t, nil, nil, Config{})
+	sent, err := m.Send(ctx, Request{To: &testTo, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 另一个客户端用同一 nonce 发出更高费用的交易并被打包，被跟踪的交易再也不会上链
+	oldTx, _ := sent.Tx()
+	other, err := chain.signer(chain.from, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(simtest.ChainID),
+		Nonce:     sent.Nonce,
+		GasTipCap: new(big.Int).Mul(oldTx.GasTipCap(), big.NewInt(2)),
+		GasFeeCap: new(big.Int).Mul(oldTx.GasFeeCap(), big.NewInt(2)),
+		Gas:       oldTx.Gas(),
+		To:        &testTo,
+		Value:     big.NewInt(2),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.client.SendTransaction(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	chain.sim.Commit()
+
+	if err := m.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	rec, _ := m.Status(sent.Hash)
+	if rec.Status != StatusDropped || !rec.Final() {
+		t.Fatalf("record = %+v, want dropped", rec)
+	}
+}
+
+func TestManagerResumesFromStore(t *testing.T) {
+	chain := newTestChain(t)
+	ctx := context.Background()
+	store := NewMemoryStore()
+	first := chain.manager(t, nil, store, Config{})
+	sent, err := first.Send(ctx, Request{To: &testTo, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 重启后从 store 恢复未完成的交易，下一个 nonce 排在其后
+	second := chain.manager(t, nil, store, Config{})
+	if nonce, err := second.ReserveNonce(ctx); err != nil || nonce != sent.Nonce+1 {
+		t.Fatalf("next nonce = %d, %v", nonce, err)
+	}
+	chain.sim.Commit()
+	if err := second.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if rec, ok := second.Status(sent.Hash); !ok || rec.Status != StatusConfirmed {
+		t.Fatalf("record = %+v", rec)
+	}
+}
@@ -0,0 +1,188 @@
+package txmgr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Status 交易在本地跟踪中的生命周期状态
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // 已广播，尚未打包
+	StatusMined     Status = "mined"     // 已打包，确认数未达到要求
+	StatusConfirmed Status = "confirmed" // 已达到确认深度且执行成功
+	StatusFailed    Status = "failed"    // 已达到确认深度但执行失败（receipt.status=0）
+	StatusDropped   Status = "dropped"   // nonce 已被其他未跟踪的交易占用
+)
+
+// Record 同一 nonce 下的一笔交易及其所有替换历史
+type Record struct {
+	From          common.Address `json:"from"`
+	Nonce         uint64         `json:"nonce"`
+	Hash          common.Hash    `json:"hash"`
+	History       []common.Hash  `json:"history,omitempty"`
+	RawTx         hexutil.Bytes  `json:"raw_tx"`
+	Status        Status         `json:"status"`
+	MinedHash     common.Hash    `json:"mined_hash,omitempty"`
+	BlockNumber   uint64         `json:"block_number,omitempty"`
+	Confirmations uint64         `json:"confirmations,omitempty"`
+	Bumps         int            `json:"bumps"`
+	SentAt        time.Time      `json:"sent_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// Tx 解码当前生效的已签名交易
+func (r *Record) Tx() (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(r.RawTx); err != nil {
+		return nil, fmt.Errorf("解码交易失败: %w", err)
+	}
+	return tx, nil
+}
+
+// Final 是否已进入终态，终态记录不再轮询
+func (r *Record) Final() bool {
+	return r.Status == StatusConfirmed || r.Status == StatusFailed || r.Status == StatusDropped
+}
+
+// Hashes 返回该 nonce 下广播过的全部交易哈希，最新的在最后
+func (r *Record) Hashes() []common.Hash {
+	hashes := make([]common.Hash, 0, len(r.History)+1)
+	hashes = append(hashes, r.History...)
+	return append(hashes, r.Hash)
+}
+
+func (r *Record) clone() *Record {
+	cp := *r
+	cp.History = append([]common.Hash(nil), r.History...)
+	cp.RawTx = append(hexutil.Bytes(nil), r.RawTx...)
+	return &cp
+}
+
+// Store 交易记录的持久化接口
+type Store interface {
+	Load(from common.Address) ([]*Record, error)
+	Save(rec *Record) error
+}
+
+// MemoryStore 进程内存储，适合测试或不需要跨进程恢复的场景
+type MemoryStore struct {
+	mu      sync.Mutex
+	records []*Record
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Load(from common.Address) ([]*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return filterRecords(s.records, from), nil
+}
+
+func (s *MemoryStore) Save(rec *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = upsertRecord(s.records, rec)
+	return nil
+}
+
+// FileStore 以 JSON 文件保存交易记录，写入时先写临时文件再 rename，避免中途退出损坏文件
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Load(from common.Address) ([]*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return filterRecords(records, from), nil
+}
+
+func (s *FileStore) Save(rec *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, err := s.read()
+	if err != nil {
+		return err
+	}
+	records = upsertRecord(records, rec)
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("创建交易记录目录失败: %w", err)
+		}
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("写入交易记录失败: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func (s *FileStore) read() ([]*Record, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取交易记录失败: %w", err)
+	}
+	var records []*Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("解析交易记录失败(%s): %w", s.path, err)
+	}
+	return records, nil
+}
+
+func filterRecords(records []*Record, from common.Address) []*Record {
+	var out []*Record
+	for _, r := range records {
+		if r.From == from {
+			out = append(out, r.clone())
+		}
+	}
+	return out
+}
+
+func upsertRecord(records []*Record, rec *Record) []*Record {
+	cp := rec.clone()
+	for i, r := range records {
+		if r.From == rec.From && r.Nonce == rec.Nonce {
+			records[i] = cp
+			return records
+		}
+	}
+	records = append(records, cp)
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].From != records[j].From {
+			return records[i].From.Hex() < records[j].From.Hex()
+		}
+		return records[i].Nonce < records[j].Nonce
+	})
+	return records
+}
@@ -0,0 +1,107 @@
+package txmgr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "txs.json")
+	store := NewFileStore(path)
+	alice := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	bob := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+
+	// 文件不存在时为空
+	if records, err := store.Load(alice); err != nil || len(records) != 0 {
+		t.Fatalf("empty load = %v, %v", records, err)
+	}
+
+	sentAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rec := &Record{
+		From:    alice,
+		Nonce:   1,
+		Hash:    common.HexToHash("0x02"),
+		History: []common.Hash{common.HexToHash("0x01")},
+		RawTx:   []byte{0xde, 0xad},
+		Status:  StatusPending,
+		Bumps:   1,
+		SentAt:  sentAt,
+	}
+	for _, r := range []*Record{rec, {From: alice, Nonce: 0, Status: StatusConfirmed}, {From: bob, Nonce: 0, Status: StatusPending}} {
+		if err := store.Save(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rec.Status, rec.BlockNumber = StatusMined, 9
+	if err := store.Save(rec); err != nil {
+		t.Fatal(err)
+	}
+
+	// 新实例读取同一文件：按账户过滤、按 nonce 排序、同一 nonce 覆盖
+	records, err := NewFileStore(path).Load(alice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Nonce != 0 || records[1].Nonce != 1 {
+		t.Fatalf("records = %+v", records)
+	}
+	got := records[1]
+	if got.Status != StatusMined || got.BlockNumber != 9 || got.Bumps != 1 || !got.SentAt.Equal(sentAt) ||
+		len(got.History) != 1 || got.History[0] != rec.History[0] || got.Hash != rec.Hash || string(got.RawTx) != string(rec.RawTx) {
+		t.Fatalf("record = %+v", got)
+	}
+	if records, _ := store.Load(bob); len(records) != 1 {
+		t.Fatalf("bob records = %d", len(records))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("mode = %v", info.Mode().Perm())
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("临时文件未被 rename: %v", err)
+	}
+}
+
+func TestFileStoreWriteIsAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "txs.json")
+	store := NewFileStore(path)
+	alice := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	if err := store.Save(&Record{From: alice, Nonce: 0, Status: StatusPending}); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 临时文件无法写入时保存失败，原文件保持不变
+	if err := os.Mkdir(path+".tmp", 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(&Record{From: alice, Nonce: 1, Status: StatusPending}); err == nil {
+		t.Fatal("期望写入失败")
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Fatalf("原文件被修改:\n%s", after)
+	}
+
+	// 损坏的文件报错而不是当作空
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(alice); err == nil {
+		t.Fatal("期望解析失败")
+	}
+}
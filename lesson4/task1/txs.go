@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"lesson4/signer"
+	"lesson4/txmgr"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const defaultStorePath = "txstore.json"
+
+// newTxManager 用 RPC 返回的链 ID 签名；-chainid 仅作为校验，与 RPC 不一致时直接报错，避免签出的交易全部 invalid sender
+func newTxManager(ctx context.Context, backend txmgr.Backend, txSigner signer.Signer, chainID int64, storePath string, cfg txmgr.Config) (*txmgr.Manager, error) {
+	rpcChainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取链 ID 失败: %w", err)
+	}
+	if chainID != 0 && rpcChainID.Cmp(big.NewInt(chainID)) != 0 {
+		return nil, fmt.Errorf("-chainid %d 与 RPC 返回的链 ID %s 不一致", chainID, rpcChainID)
+	}
+	signerFn := signer.SignerFn(txSigner, rpcChainID)
+	mgr, err := txmgr.New(backend, txSigner.Address(), signerFn, txmgr.NewFileStore(storePath), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("加载交易记录失败: %w", err)
+	}
+	return mgr, nil
+}
+
+func runTxStatus(args []string) error {
+	fs := flag.NewFlagSet("tx-status", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "Sepolia RPC URL，例如 Infura endpoint")
+	signerFlags := signer.RegisterFlags(fs)
+	chainID := fs.Int64("chainid", 0, "链 ID，0 表示使用 RPC 返回的链 ID；指定时必须与 RPC 一致")
+	storePath := fs.String("store", defaultStorePath, "待确认交易记录文件")
+	watch := fs.Bool("watch", false, "持续轮询直到全部交易进入终态")
+	confirmations := fs.Uint64("confirmations", 1, "确认深度")
+	stuckAfter := fs.Duration("stuck", 3*time.Minute, "广播后超过该时长未打包则加价替换")
+	bumpPercent := fs.Int64("bump", 10, "每次替换的加价百分比（最小 10）")
+	timeout := fs.Duration("timeout", 30*time.Minute, "整体超时时间")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
+	if resolvedRPC == "" {
+		return fmt.Errorf("-rpc 为空且未设置环境变量 RPC_URL")
+	}
+	// 卡住的交易需要重新签名，因此也需要签名账户
+	txSigner, err := signerFlags.Load()
+	if err != nil {
+		return err
+	}
+	fromAddr := txSigner.Address()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	if err != nil {
+		return fmt.Errorf("连接 RPC 失败: %w", err)
+	}
+	defer client.Close()
+
+	mgr, err := newTxManager(ctx, client, txSigner, *chainID, *storePath, txmgr.Config{
+		Confirmations: *confirmations,
+		StuckAfter:    *stuckAfter,
+		BumpPercent:   *bumpPercent,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := mgr.Poll(ctx); err != nil {
+			return err
+		}
+		records := mgr.Records()
+		fmt.Printf("=== %s 交易状态 (%s) ===\n", fromAddr.Hex(), time.Now().Format(time.RFC3339))
+		pending := 0
+		for _, rec := range records {
+			printRecord(rec)
+			if !rec.Final() {
+				pending++
+			}
+		}
+		if len(records) == 0 {
+			fmt.Println("无交易记录")
+		}
+		if !*watch || pending == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(mgr.Config().PollInterval):
+		}
+	}
+}
+
+func printRecord(rec *txmgr.Record) {
+	fmt.Printf("nonce=%d 状态=%s 哈希=%s", rec.Nonce, rec.Status, rec.Hash.Hex())
+	if rec.Bumps > 0 {
+		fmt.Printf(" 已加价替换=%d次", rec.Bumps)
+	}
+	if rec.BlockNumber > 0 {
+		fmt.Printf(" 区块=%d 确认数=%d", rec.BlockNumber, rec.Confirmations)
+		if rec.MinedHash != rec.Hash {
+			fmt.Printf(" 实际打包=%s", rec.MinedHash.Hex())
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lesson4/simtest"
+	"lesson4/txmgr"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestNewTxManagerUsesRPCChainID(t *testing.T) {
+	chain := simtest.New(t, 1, 10)
+	ctx := context.Background()
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	// 未指定 -chainid 与指定一致的链 ID 时都按 RPC 的链 ID 签名，交易能被节点接受
+	for _, chainID := range []int64{0, simtest.ChainID} {
+		store := filepath.Join(t.TempDir(), "txstore.json")
+		mgr, err := newTxManager(ctx, chain.Client, chain.Signer(), chainID, store, txmgr.Config{})
+		if err != nil {
+			t.Fatalf("chainid=%d: %v", chainID, err)
+		}
+		rec, err := mgr.Send(ctx, txmgr.Request{To: &to, Value: big.NewInt(1)})
+		if err != nil {
+			t.Fatalf("chainid=%d send: %v", chainID, err)
+		}
+		chain.Sim.Commit()
+		receipt, err := chain.Client.TransactionReceipt(ctx, rec.Hash)
+		if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+			t.Fatalf("chainid=%d receipt: %v, %v", chainID, receipt, err)
+		}
+	}
+
+	store := filepath.Join(t.TempDir(), "txstore.json")
+	_, err := newTxManager(ctx, chain.Client, chain.Signer(), defaultChainID, store, txmgr.Config{})
+	if err == nil || !strings.Contains(err.Error(), "不一致") {
+		t.Fatalf("期望链 ID 不一致错误, got %v", err)
+	}
+}
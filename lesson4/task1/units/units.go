@@ -0,0 +1,59 @@
+// Package units 在人类可读的十进制金额与链上最小单位（wei 或代币最小精度）之间换算，
+// 供 lesson4 各工具与 nft-auction 共用，保证同一金额在各处得到相同的结果。
+package units
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Parse 把十进制金额按 decimals 换算成最小单位；允许 0，负数或超出精度时报错
+func Parse(amount string, decimals uint8) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return nil, fmt.Errorf("金额为空")
+	}
+	r, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return nil, fmt.Errorf("无效金额: %s", amount)
+	}
+	if r.Sign() < 0 {
+		return nil, fmt.Errorf("金额不能为负: %s", amount)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(pow10(decimals)))
+	if !r.IsInt() {
+		// 超出精度则报错，避免精度隐患
+		return nil, fmt.Errorf("金额精度超过 %d 位小数: %s", decimals, amount)
+	}
+	return r.Num(), nil
+}
+
+// ParseEther 按 18 位小数换算成 wei
+func ParseEther(amount string) (*big.Int, error) {
+	return Parse(amount, 18)
+}
+
+// Format 是 Parse 的逆运算，去掉小数部分末尾的 0
+func Format(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+	abs := new(big.Int).Abs(amount)
+	whole, frac := new(big.Int).QuoRem(abs, pow10(decimals), new(big.Int))
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+	if frac.Sign() == 0 {
+		return sign + whole.String()
+	}
+	fracStr := frac.String()
+	fracStr = strings.Repeat("0", int(decimals)-len(fracStr)) + fracStr
+	return sign + whole.String() + "." + strings.TrimRight(fracStr, "0")
+}
+
+func pow10(decimals uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
@@ -0,0 +1,77 @@
+package units
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in       string
+		decimals uint8
+		want     string
+	}{
+		{"0", 18, "0"},
+		{"0.0", 6, "0"},
+		{"1", 18, "1000000000000000000"},
+		{" 0.25 ", 18, "250000000000000000"},
+		{"1.5", 6, "1500000"},
+		{"123", 0, "123"},
+		{"0.000001", 6, "1"},
+		{"1e3", 2, "100000"},
+	}
+	for _, c := range cases {
+		got, err := Parse(c.in, c.decimals)
+		if err != nil || got.String() != c.want {
+			t.Errorf("Parse(%q, %d) = %v, %v, want %s", c.in, c.decimals, got, err, c.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		in       string
+		decimals uint8
+		want     string
+	}{
+		{"", 18, "金额为空"},
+		{"   ", 18, "金额为空"},
+		{"abc", 18, "无效金额"},
+		{"-1", 18, "金额不能为负"},
+		{"0.0000001", 6, "金额精度超过 6 位小数"},
+		{"1.5", 0, "金额精度超过 0 位小数"},
+	}
+	for _, c := range cases {
+		if got, err := Parse(c.in, c.decimals); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("Parse(%q, %d) = %v, %v, want error %q", c.in, c.decimals, got, err, c.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		in       *big.Int
+		decimals uint8
+		want     string
+	}{
+		{nil, 18, "0"},
+		{big.NewInt(0), 18, "0"},
+		{big.NewInt(1), 18, "0.000000000000000001"},
+		{big.NewInt(1500000), 6, "1.5"},
+		{big.NewInt(-2500), 3, "-2.5"},
+		{big.NewInt(42), 0, "42"},
+	}
+	for _, c := range cases {
+		if got := Format(c.in, c.decimals); got != c.want {
+			t.Errorf("Format(%v, %d) = %s, want %s", c.in, c.decimals, got, c.want)
+		}
+	}
+	// 往返换算不丢精度
+	for _, s := range []string{"0", "1", "0.1", "123.456789"} {
+		n, err := ParseEther(s)
+		if err != nil || Format(n, 18) != s {
+			t.Errorf("round trip %s = %v, %v", s, n, err)
+		}
+	}
+}
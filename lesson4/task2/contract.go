@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"lesson4/abiutil"
+	"lesson4/signer"
+	"lesson4/txmgr"
+	"lesson4/units"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// contractTxResult contract deploy/send 的结果：交易记录与回执中解码出的事件
+type contractTxResult struct {
+	Address common.Address
+	Record  *txmgr.Record
+	Events  []decodedLog
+}
+
+// decodedLog 回执或历史日志中的一条记录；ABI 中找不到匹配事件时 Event 为空
+type decodedLog struct {
+	Log   types.Log
+	Event *abiutil.DecodedEvent
+}
+
+func decodeLogs(parsed abi.ABI, logs []types.Log) []decodedLog {
+	out := make([]decodedLog, 0, len(logs))
+	for _, l := range logs {
+		d := decodedLog{Log: l}
+		if ev, err := abiutil.DecodeLog([]abi.ABI{parsed}, l); err == nil {
+			d.Event = ev
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// receiptLogs 读取实际打包交易（可能已被 txmgr 替换）的日志
+func receiptLogs(ctx context.Context, backend bind.DeployBackend, rec *txmgr.Record, parsed abi.ABI) ([]decodedLog, error) {
+	receipt, err := backend.TransactionReceipt(ctx, rec.MinedHash)
+	if err != nil {
+		return nil, fmt.Errorf("获取交易回执失败: %w", err)
+	}
+	logs := make([]types.Log, len(receipt.Logs))
+	for i, l := range receipt.Logs {
+		logs[i] = *l
+	}
+	return decodeLogs(parsed, logs), nil
+}
+
+// deployContract 按 ABI 打包构造参数部署任意合约，并等待达到确认深度
+func deployContract(ctx context.Context, backend chainBackend, txSigner signer.Signer, parsed abi.ABI, bytecode []byte, args []interface{}, opts txOptions) (*contractTxResult, error) {
+	if opts.Value != nil && opts.Value.Sign() > 0 && !parsed.Constructor.IsPayable() {
+		return nil, fmt.Errorf("构造函数不是 payable，不能附带 -value")
+	}
+	auth, mgr, nonce, err := newTransactor(ctx, backend, txSigner, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAddr, tx, _, err := bind.DeployContract(auth, parsed, bytecode, backend, args...)
+	if err != nil {
+		mgr.ReleaseNonce(nonce)
+		return nil, fmt.Errorf("发送部署交易失败: %w", err)
+	}
+	fmt.Printf("部署交易已发送: %s\n", tx.Hash().Hex())
+
+	result := &contractTxResult{Address: contractAddr}
+	result.Record, err = trackAndWait(ctx, mgr, tx)
+	if err != nil {
+		return result, fmt.Errorf("等待部署交易上链失败: %w", err)
+	}
+	if result.Record.Status != txmgr.StatusConfirmed {
+		return result, fmt.Errorf("部署%w，txHash=%s，状态=%s", errTxFailed, result.Record.Hash.Hex(), result.Record.Status)
+	}
+	result.Events, err = receiptLogs(ctx, backend, result.Record, parsed)
+	return result, err
+}
+
+// sendContract 发送任意非只读方法的交易，并等待达到确认深度
+func sendContract(ctx context.Context, backend chainBackend, txSigner signer.Signer, contractAddr common.Address, parsed abi.ABI, method abi.Method, args []interface{}, opts txOptions) (*contractTxResult, error) {
+	if method.IsConstant() {
+		return nil, fmt.Errorf("%s 是只读方法，请使用 contract call", method.Sig)
+	}
+	if opts.Value != nil && opts.Value.Sign() > 0 && !method.IsPayable() {
+		return nil, fmt.Errorf("%s 不是 payable，不能附带 -value", method.Sig)
+	}
+	auth, mgr, nonce, err := newTransactor(ctx, backend, txSigner, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	bound := bind.NewBoundContract(contractAddr, parsed, backend, backend, backend)
+	tx, err := bound.Transact(auth, method.Name, args...)
+	if err != nil {
+		mgr.ReleaseNonce(nonce)
+		return nil, fmt.Errorf("发送 %s 交易失败: %w", method.Sig, err)
+	}
+	fmt.Printf("交易已发送: %s\n", tx.Hash().Hex())
+
+	result := &contractTxResult{Address: contractAddr}
+	result.Record, err = trackAndWait(ctx, mgr, tx)
+	if err != nil {
+		return result, fmt.Errorf("等待交易上链失败: %w", err)
+	}
+	if result.Record.Status != txmgr.StatusConfirmed {
+		return result, fmt.Errorf("%w，txHash=%s，状态=%s", errTxFailed, result.Record.Hash.Hex(), result.Record.Status)
+	}
+	result.Events, err = receiptLogs(ctx, backend, result.Record, parsed)
+	return result, err
+}
+
+// callContract 通过 eth_call 执行任意方法（非只读方法相当于模拟执行），返回解包后的值
+func callContract(ctx context.Context, caller ethereum.ContractCaller, from, contractAddr common.Address, method abi.Method, args []interface{}, value *big.Int) ([]interface{}, error) {
+	input, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("打包参数失败: %w", err)
+	}
+	data := append(append([]byte{}, method.ID...), input...)
+	out, err := caller.CallContract(ctx, ethereum.CallMsg{From: from, To: &contractAddr, Value: value, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("调用 %s 失败: %w", method.Sig, err)
+	}
+	if len(out) == 0 && len(method.Outputs) > 0 {
+		return nil, fmt.Errorf("%s 返回为空，地址 %s 可能不是合约", method.Sig, contractAddr.Hex())
+	}
+	values, err := method.Outputs.Unpack(out)
+	if err != nil {
+		return nil, fmt.Errorf("解码返回值失败: %w", err)
+	}
+	return values, nil
+}
+
+// filterContractLogs 分段拉取合约在 [from, to] 内的日志；eventName 非空时只查询该事件
+func filterContractLogs(ctx context.Context, filterer ethereum.LogFilterer, contractAddr common.Address, parsed abi.ABI, eventName string, from, to, chunk uint64, handle func(decodedLog) error) error {
+	query := ethereum.FilterQuery{Addresses: []common.Address{contractAddr}}
+	if eventName != "" {
+		event, ok := parsed.Events[eventName]
+		if !ok {
+			return fmt.Errorf("ABI 中没有事件 %s", eventName)
+		}
+		query.Topics = [][]common.Hash{{event.ID}}
+	}
+	_, err := forEachChunk(ctx, from, to, chunk, func(start, end uint64) error {
+		q := query
+		q.FromBlock = new(big.Int).SetUint64(start)
+		q.ToBlock = new(big.Int).SetUint64(end)
+		logs, err := filterer.FilterLogs(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, d := range decodeLogs(parsed, logs) {
+			if err := handle(d); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// printOutputs 逐个打印返回值；Unpack 保证 values 与 args 一一对应
+func printOutputs(args abi.Arguments, values []interface{}) {
+	if len(values) == 0 {
+		fmt.Println("（无返回值）")
+		return
+	}
+	for i, v := range values {
+		name := args[i].Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		fmt.Printf("%s (%s) = %s\n", name, args[i].Type.String(), abiutil.FormatTyped(args[i].Type, v))
+	}
+}
+
+func printLog(d decodedLog) {
+	prefix := fmt.Sprintf("区块 %d  交易 %s  日志 %d", d.Log.BlockNumber, d.Log.TxHash.Hex(), d.Log.Index)
+	if d.Event == nil {
+		fmt.Printf("%s  %s 未解码（topic0=%s）\n", prefix, d.Log.Address.Hex(), topic0(d.Log))
+		return
+	}
+	fmt.Printf("%s  %s\n", prefix, d.Event.Signature)
+	for _, f := range d.Event.Fields {
+		fmt.Printf("      %s (%s) = %s\n", f.Name, f.Type, f.Value)
+	}
+}
+
+func topic0(l types.Log) string {
+	if len(l.Topics) == 0 {
+		return "无"
+	}
+	return l.Topics[0].Hex()
+}
+
+func runContract(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("缺少操作：deploy | call | send | events")
+	}
+	action := args[0]
+	switch action {
+	case "deploy", "call", "send", "events":
+	default:
+		return fmt.Errorf("未知操作 %q：可选 deploy | call | send | events", action)
+	}
+
+	fs := flag.NewFlagSet("contract "+action, flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "Sepolia RPC URL")
+	signerFlags := signer.RegisterFlags(fs)
+	abiPath := fs.String("abi", "", "ABI 文件（ABI 数组或带 abi 字段的构建产物）")
+	binPath := fs.String("bin", "", "deploy：字节码文件（十六进制或带 bytecode 字段的构建产物），默认与 -abi 相同")
+	contractAddrHex := fs.String("addr", "", "call/send/events：合约地址")
+	methodSig := fs.String("method", "", "call/send：方法名或完整签名，例如 'transfer(address,uint256)'")
+	value := fs.String("value", "0", "随交易发送的 ETH 数量（仅 payable）")
+	from := fs.String("from", "", "call：eth_call 使用的 from 地址（依赖 msg.sender 的只读方法需要），默认零地址")
+	chainID := fs.Int64("chainid", defaultChainID, "链 ID，Sepolia 默认为 11155111")
+	storePath := fs.String("store", defaultStorePath, "待确认交易记录文件")
+	confirmations := fs.Uint64("confirmations", 1, "确认深度")
+	gasLimit := fs.Uint64("gas", 0, "gas 上限，0 表示自动估算")
+	eventName := fs.String("event", "", "events：只查询该事件")
+	fromBlock := fs.Uint64("from-block", 0, "events：起始区块号（包含）")
+	toArg := fs.String("to-block", "latest", "events：结束区块号（包含）或 latest")
+	chunk := fs.Uint64("chunk", defaultChunkSize, "events：单次 eth_getLogs 查询的区块数")
+	timeout := fs.Duration("timeout", 5*time.Minute, "整体超时时间")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	// flag 在第一个非 flag 参数处停止解析，剩余的按顺序作为方法或构造函数参数
+	rawArgs := fs.Args()
+
+	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
+	if resolvedRPC == "" {
+		return fmt.Errorf("缺少必要参数：需提供 RPC_URL（命令行参数或环境变量）")
+	}
+	if *abiPath == "" {
+		return fmt.Errorf("缺少必要参数：-abi")
+	}
+	parsed, err := abiutil.LoadABI(*abiPath)
+	if err != nil {
+		return err
+	}
+	wei, err := units.ParseEther(*value)
+	if err != nil {
+		return err
+	}
+	var contractAddr common.Address
+	if action != "deploy" {
+		resolvedAddr := firstNonEmpty(*contractAddrHex, os.Getenv("CONTRACT_ADDRESS"))
+		if !common.IsHexAddress(resolvedAddr) {
+			return fmt.Errorf("缺少或无效的合约地址（-addr 或 CONTRACT_ADDRESS）: %q", resolvedAddr)
+		}
+		contractAddr = common.HexToAddress(resolvedAddr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	if err != nil {
+		return fmt.Errorf("连接 RPC 失败: %w", err)
+	}
+	defer client.Close()
+
+	opts := txOptions{
+		ChainID:  big.NewInt(*chainID),
+		Store:    txmgr.NewFileStore(*storePath),
+		Config:   txmgr.Config{Confirmations: *confirmations},
+		GasLimit: *gasLimit,
+		Value:    wei,
+	}
+
+	switch action {
+	case "deploy":
+		bytecode, err := abiutil.LoadBytecode(firstNonEmpty(*binPath, *abiPath))
+		if err != nil {
+			return err
+		}
+		ctorArgs, err := abiutil.ParseArgs(parsed.Constructor.Inputs, rawArgs)
+		if err != nil {
+			return fmt.Errorf("构造函数%w", err)
+		}
+		txSigner, err := signerFlags.Load()
+		if err != nil {
+			return err
+		}
+		result, err := deployContract(ctx, client, txSigner, parsed, bytecode, ctorArgs, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Println("=== 部署成功 ===")
+		fmt.Printf("合约地址: %s\n", result.Address.Hex())
+		fmt.Printf("区块高度: %d\n", result.Record.BlockNumber)
+		for _, d := range result.Events {
+			printLog(d)
+		}
+		return nil
+
+	case "call", "send":
+		if *methodSig == "" {
+			return fmt.Errorf("缺少必要参数：-method")
+		}
+		method, err := abiutil.FindMethod(parsed, *methodSig)
+		if err != nil {
+			return err
+		}
+		callArgs, err := abiutil.ParseArgs(method.Inputs, rawArgs)
+		if err != nil {
+			return err
+		}
+		if action == "call" {
+			var caller common.Address
+			if *from != "" {
+				if !common.IsHexAddress(*from) {
+					return fmt.Errorf("无效的 -from 地址: %s", *from)
+				}
+				caller = common.HexToAddress(*from)
+			}
+			values, err := callContract(ctx, client, caller, contractAddr, method, callArgs, wei)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("=== %s ===\n", method.Sig)
+			printOutputs(method.Outputs, values)
+			return nil
+		}
+		txSigner, err := signerFlags.Load()
+		if err != nil {
+			return err
+		}
+		result, err := sendContract(ctx, client, txSigner, contractAddr, parsed, method, callArgs, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("=== %s 已确认 ===\n", method.Sig)
+		fmt.Printf("区块高度: %d\n", result.Record.BlockNumber)
+		fmt.Printf("浏览器: https://sepolia.etherscan.io/tx/%s\n", result.Record.MinedHash.Hex())
+		for _, d := range result.Events {
+			printLog(d)
+		}
+		return nil
+
+	default: // events
+		toBlock, err := resolveToBlock(ctx, client, *toArg)
+		if err != nil {
+			return err
+		}
+		if *fromBlock > toBlock {
+			return fmt.Errorf("起始区块 %d 大于结束区块 %d", *fromBlock, toBlock)
+		}
+		total := 0
+		err = filterContractLogs(ctx, client, contractAddr, parsed, *eventName, *fromBlock, toBlock, *chunk, func(d decodedLog) error {
+			total++
+			printLog(d)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("=== 区块 %d - %d 共 %d 条日志 ===\n", *fromBlock, toBlock, total)
+		return nil
+	}
+}
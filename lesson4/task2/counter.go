@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"lesson4/signer"
+	"lesson4/task2/bindings/counter"
+	"lesson4/txmgr"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errTxFailed 交易已打包但执行失败（revert 或 gas 耗尽）
+var errTxFailed = errors.New("交易执行失败")
+
+// chainBackend deploy/call/contract 需要的全部链上能力；*ethclient.Client 与 simulated.Client 都满足
+type chainBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	txmgr.Backend
+}
+
+// txOptions deploy/call/contract 共用的发送参数
+type txOptions struct {
+	ChainID  *big.Int
+	Store    txmgr.Store
+	Config   txmgr.Config
+	GasLimit uint64   // 0 表示由绑定自动估算
+	Value    *big.Int // 随交易发送的 wei，仅 payable 方法可用
+}
+
+// incrementResult increment 调用前后的计数以及交易中的 Incremented 事件
+type incrementResult struct {
+	Before *big.Int
+	After  *big.Int
+	Record *txmgr.Record
+	Event  *counter.CounterIncremented
+}
+
+// newTransactor 构造带 EIP-1559 费用与本地分配 nonce 的 TransactOpts；发送失败时调用方需 ReleaseNonce
+func newTransactor(ctx context.Context, backend chainBackend, txSigner signer.Signer, opts txOptions) (*bind.TransactOpts, *txmgr.Manager, uint64, error) {
+	auth := signer.TransactOpts(txSigner, opts.ChainID)
+	auth.Context = ctx
+	auth.GasLimit = opts.GasLimit
+	auth.Value = opts.Value
+
+	// 让绑定方法自动估算 gas；为了兼容 EIP-1559 手动填充费用参数。
+	if err := fillDynamicFee(ctx, backend, auth); err != nil {
+		return nil, nil, 0, fmt.Errorf("获取动态费参数失败: %w", err)
+	}
+
+	mgr, err := txmgr.New(backend, txSigner.Address(), auth.Signer, opts.Store, opts.Config)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("加载交易记录失败: %w", err)
+	}
+	nonce, err := mgr.ReserveNonce(ctx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	auth.Nonce = new(big.Int).SetUint64(nonce)
+	return auth, mgr, nonce, nil
+}
+
+// deployCounter 部署 Counter 并等待达到确认深度
+func deployCounter(ctx context.Context, backend chainBackend, txSigner signer.Signer, opts txOptions) (common.Address, *txmgr.Record, error) {
+	auth, mgr, nonce, err := newTransactor(ctx, backend, txSigner, opts)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	contractAddr, tx, _, err := counter.DeployCounter(auth, backend)
+	if err != nil {
+		mgr.ReleaseNonce(nonce)
+		return common.Address{}, nil, fmt.Errorf("发送部署交易失败: %w", err)
+	}
+	fmt.Printf("部署交易已发送: %s\n", tx.Hash().Hex())
+
+	rec, err := trackAndWait(ctx, mgr, tx)
+	if err != nil {
+		return contractAddr, rec, fmt.Errorf("等待部署交易上链失败: %w", err)
+	}
+	if rec.Status != txmgr.StatusConfirmed {
+		return contractAddr, rec, fmt.Errorf("部署%w，txHash=%s，状态=%s", errTxFailed, rec.Hash.Hex(), rec.Status)
+	}
+	return contractAddr, rec, nil
+}
+
+// incrementCounter 发送 increment() 并等待确认，返回调用前后计数与事件
+func incrementCounter(ctx context.Context, backend chainBackend, txSigner signer.Signer, contractAddr common.Address, opts txOptions) (*incrementResult, error) {
+	ctr, err := counter.NewCounter(contractAddr, backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建合约实例失败: %w", err)
+	}
+
+	before, err := ctr.GetCount(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("读取调用前计数失败: %w", err)
+	}
+	fmt.Printf("调用前计数: %s\n", before.String())
+
+	auth, mgr, nonce, err := newTransactor(ctx, backend, txSigner, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := ctr.Increment(auth)
+	if err != nil {
+		mgr.ReleaseNonce(nonce)
+		return nil, fmt.Errorf("发送 increment 交易失败: %w", err)
+	}
+	fmt.Printf("交易已发送: %s\n", tx.Hash().Hex())
+
+	result := &incrementResult{Before: before}
+	result.Record, err = trackAndWait(ctx, mgr, tx)
+	if err != nil {
+		return result, fmt.Errorf("等待交易上链失败: %w", err)
+	}
+	if result.Record.Status != txmgr.StatusConfirmed {
+		return result, fmt.Errorf("%w，txHash=%s，状态=%s", errTxFailed, result.Record.Hash.Hex(), result.Record.Status)
+	}
+
+	// 被替换过的交易以实际打包的哈希为准
+	receipt, err := backend.TransactionReceipt(ctx, result.Record.MinedHash)
+	if err != nil {
+		return result, fmt.Errorf("获取交易回执失败: %w", err)
+	}
+	for _, l := range receipt.Logs {
+		if ev, err := ctr.ParseIncremented(*l); err == nil {
+			result.Event = ev
+			break
+		}
+	}
+
+	result.After, err = ctr.GetCount(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return result, fmt.Errorf("读取调用后计数失败: %w", err)
+	}
+	return result, nil
+}
+
+func fillDynamicFee(ctx context.Context, backend txmgr.FeeBackend, auth *bind.TransactOpts) error {
+	tipCap, err := backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return err
+	}
+	header, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	feeCap := new(big.Int).Mul(header.BaseFee, big.NewInt(2))
+	feeCap.Add(feeCap, tipCap)
+
+	auth.GasTipCap = tipCap
+	auth.GasFeeCap = feeCap
+	return nil
+}
+
+// trackAndWait 把绑定发出的交易交给 txmgr 跟踪，卡住时由 txmgr 加价替换，直到达到确认深度
+func trackAndWait(ctx context.Context, mgr *txmgr.Manager, tx *types.Transaction) (*txmgr.Record, error) {
+	if _, err := mgr.Adopt(tx); err != nil {
+		return nil, err
+	}
+	return mgr.Wait(ctx, tx.Hash())
+}
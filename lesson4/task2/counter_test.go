@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"lesson4/signer"
+	"lesson4/simtest"
+	"lesson4/task2/bindings/counter"
+	"lesson4/txmgr"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type testChain struct {
+	*simtest.Chain
+	signer signer.Signer
+	store  *txmgr.MemoryStore
+}
+
+func newTestChain(t *testing.T) *testChain {
+	t.Helper()
+	chain := simtest.New(t, 1, 10)
+	return &testChain{Chain: chain, signer: chain.Signer(), store: txmgr.NewMemoryStore()}
+}
+
+func (c *testChain) opts() txOptions {
+	return txOptions{
+		ChainID: big.NewInt(simtest.ChainID),
+		Store:   c.store,
+		Config:  txmgr.Config{Confirmations: 1, PollInterval: 10 * time.Millisecond},
+	}
+}
+
+func (c *testChain) deploy(t *testing.T) common.Address {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	addr, rec, err := deployCounter(ctx, c.Client, c.signer, c.opts())
+	if err != nil {
+		t.Fatalf("deploy: %v", err)
+	}
+	if rec.Status != txmgr.StatusConfirmed {
+		t.Fatalf("部署状态 = %s, 期望 confirmed", rec.Status)
+	}
+	return addr
+}
+
+func TestDeployAndIncrement(t *testing.T) {
+	chain := newTestChain(t)
+	chain.AutoMine(t)
+	addr := chain.deploy(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for i := int64(1); i <= 2; i++ {
+		result, err := incrementCounter(ctx, chain.Client, chain.signer, addr, chain.opts())
+		if err != nil {
+			t.Fatalf("increment #%d: %v", i, err)
+		}
+		if result.Before.Int64() != i-1 || result.After.Int64() != i {
+			t.Fatalf("increment #%d: 计数 %s -> %s", i, result.Before, result.After)
+		}
+		if result.Event == nil || result.Event.NewValue.Int64() != i {
+			t.Fatalf("increment #%d: 事件 = %+v", i, result.Event)
+		}
+	}
+
+	// 历史事件：chunk=1 覆盖逐块分段的路径
+	filterer, err := counter.NewCounterFilterer(addr, chain.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := chain.Client.BlockNumber(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []int64
+	_, err = filterIncremented(ctx, filterer, 0, head, 1, func(ev *counter.CounterIncremented) error {
+		values = append(values, ev.NewValue.Int64())
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Fatalf("Incremented 事件 = %v, 期望 [1 2]", values)
+	}
+
+	records, err := chain.store.Load(chain.signer.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("交易记录 %d 条, 期望 3 条", len(records))
+	}
+	for _, rec := range records {
+		if rec.Status != txmgr.StatusConfirmed {
+			t.Fatalf("nonce %d 状态 = %s", rec.Nonce, rec.Status)
+		}
+	}
+}
+
+func TestIncrementOutOfGasFails(t *testing.T) {
+	chain := newTestChain(t)
+	chain.AutoMine(t)
+	addr := chain.deploy(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	opts := chain.opts()
+	// 足够通过内在 gas 校验，但不够执行 SSTORE，交易会被打包并回滚
+	opts.GasLimit = 25000
+	result, err := incrementCounter(ctx, chain.Client, chain.signer, addr, opts)
+	if !errors.Is(err, errTxFailed) {
+		t.Fatalf("期望 errTxFailed, got %v", err)
+	}
+	if result.Record == nil || result.Record.Status != txmgr.StatusFailed {
+		t.Fatalf("交易记录 = %+v, 期望 failed", result.Record)
+	}
+
+	ctr, err := counter.NewCounter(addr, chain.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := ctr.GetCount(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count.Sign() != 0 {
+		t.Fatalf("回滚后计数 = %s, 期望 0", count)
+	}
+}
+
+func TestIncrementWithoutContract(t *testing.T) {
+	chain := newTestChain(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := incrementCounter(ctx, chain.Client, chain.signer, common.HexToAddress("0x00000000000000000000000000000000000000aa"), chain.opts())
+	if err == nil || !strings.Contains(err.Error(), "读取调用前计数失败") {
+		t.Fatalf("期望读取计数失败, got %v", err)
+	}
+	records, err := chain.store.Load(chain.signer.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("不应产生交易记录, got %d 条", len(records))
+	}
+}
+
+func TestDeployUnminedTimesOut(t *testing.T) {
+	chain := newTestChain(t)
+	// 不出块：交易停留在交易池中
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	_, rec, err := deployCounter(ctx, chain.Client, chain.signer, chain.opts())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("期望超时, got %v", err)
+	}
+	if rec == nil || rec.Status != txmgr.StatusPending {
+		t.Fatalf("交易记录 = %+v, 期望 pending", rec)
+	}
+
+	// 之后出块，同一个 nonce 的交易仍可由 txmgr 跟踪到确认
+	chain.Sim.Commit()
+	mgr, err := txmgr.New(chain.Client, chain.signer.Address(), signer.SignerFn(chain.signer, big.NewInt(simtest.ChainID)), chain.store, chain.opts().Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.Poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	tracked, ok := mgr.Status(rec.Hash)
+	if !ok || tracked.Status != txmgr.StatusConfirmed {
+		t.Fatalf("出块后状态 = %+v, 期望 confirmed", tracked)
+	}
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"lesson4/task2/bindings/counter"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// defaultChunkSize 多数公共 RPC 限制单次 eth_getLogs 的区块跨度（常见为 1000~10000）
+	defaultChunkSize = 2000
+	// defaultPollInterval HTTP RPC 下轮询新区块的间隔
+	defaultPollInterval = 4 * time.Second
+)
+
+// eventView 单条 Incremented 事件的输出结构
+type eventView struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	TxHash      string `json:"txHash"`
+	LogIndex    uint   `json:"logIndex"`
+	NewValue    string `json:"newValue"`
+	Removed     bool   `json:"removed,omitempty"`
+}
+
+// headReader 轮询时只需要读取最新区块号
+type headReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+func newEventView(ev *counter.CounterIncremented) eventView {
+	return eventView{
+		BlockNumber: ev.Raw.BlockNumber,
+		TxHash:      ev.Raw.TxHash.Hex(),
+		LogIndex:    ev.Raw.Index,
+		NewValue:    ev.NewValue.String(),
+		Removed:     ev.Raw.Removed,
+	}
+}
+
+func printEvent(ev *counter.CounterIncremented, asJSON bool) error {
+	view := newEventView(ev)
+	if asJSON {
+		// 每行一个 JSON 对象，方便管道处理
+		out, err := json.Marshal(view)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+	suffix := ""
+	if view.Removed {
+		suffix = "（链重组，已回滚）"
+	}
+	fmt.Printf("区块 %d  交易 %s  日志 %d  newValue=%s%s\n", view.BlockNumber, view.TxHash, view.LogIndex, view.NewValue, suffix)
+	return nil
+}
+
+// rangeLimitErrors 常见节点对 eth_getLogs 区块跨度 / 结果条数限制的报错片段（geth、Infura、Alchemy、QuickNode、BSC 等）；
+// 限流、gas limit、合约 revert 与超时不在其中，这些错误缩小区块段也无济于事
+var rangeLimitErrors = []string{
+	"query returned more than",
+	"block range",
+	"range is too",
+	"range too large",
+	"too many blocks",
+	"too many results",
+	"response size",
+	"is limited to",
+}
+
+// isRangeLimitErr 判断 eth_getLogs 是否因区块跨度或结果数量超出节点限制而失败
+func isRangeLimitErr(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range rangeLimitErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachChunk 把 [from, to] 按 chunk 个区块分段依次交给 fn；
+// 节点报区块跨度或结果数超限时将当前分段减半重试，直到单个区块。
+// 返回第一个尚未处理的区块：全部成功时为 to+1，出错时为失败分段的起点，之前的分段已经交给 fn
+func forEachChunk(ctx context.Context, from, to, chunk uint64, fn func(start, end uint64) error) (uint64, error) {
+	if chunk == 0 {
+		return from, fmt.Errorf("-chunk 必须大于 0")
+	}
+	for start := from; start <= to; {
+		end := start + chunk - 1
+		if end > to || end < start {
+			end = to
+		}
+		if err := fn(start, end); err != nil {
+			if ctx.Err() == nil && isRangeLimitErr(err) && end > start {
+				chunk = (end - start + 1) / 2
+				continue
+			}
+			return start, fmt.Errorf("查询区块 %d-%d 的事件失败: %w", start, end, err)
+		}
+		if end == to {
+			break
+		}
+		start = end + 1
+	}
+	return to + 1, nil
+}
+
+// filterIncremented 分段拉取 [from, to] 内的 Incremented 事件，返回值含义同 forEachChunk
+func filterIncremented(ctx context.Context, filterer *counter.CounterFilterer, from, to, chunk uint64, handle func(*counter.CounterIncremented) error) (uint64, error) {
+	return forEachChunk(ctx, from, to, chunk, func(start, end uint64) error {
+		it, err := filterer.FilterIncremented(&bind.FilterOpts{Start: start, End: &end, Context: ctx})
+		if err != nil {
+			return err
+		}
+		defer it.Close()
+		for it.Next() {
+			if err := handle(it.Event); err != nil {
+				return err
+			}
+		}
+		return it.Error()
+	})
+}
+
+// resolveToBlock 解析结束区块参数：latest 时读取最新区块号
+func resolveToBlock(ctx context.Context, heads headReader, arg string) (uint64, error) {
+	if strings.EqualFold(strings.TrimSpace(arg), "latest") {
+		head, err := heads.BlockNumber(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("获取最新区块号失败: %w", err)
+		}
+		return head, nil
+	}
+	n, ok := new(big.Int).SetString(strings.TrimSpace(arg), 0)
+	if !ok || !n.IsUint64() {
+		return 0, fmt.Errorf("无效的结束区块: %s", arg)
+	}
+	return n.Uint64(), nil
+}
+
+func runEvents(args []string) error {
+	fs := flag.NewFlagSet("events", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "Sepolia RPC URL")
+	contractAddrHex := fs.String("addr", "", "已部署 Counter 合约地址")
+	fromBlock := fs.Uint64("from", 0, "起始区块号（包含），建议填写合约部署区块")
+	toArg := fs.String("to", "latest", "结束区块号（包含）或 latest")
+	chunk := fs.Uint64("chunk", defaultChunkSize, "单次 eth_getLogs 查询的区块数")
+	asJSON := fs.Bool("json", false, "以 JSON Lines 输出")
+	timeout := fs.Duration("timeout", 5*time.Minute, "整体超时时间")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
+	resolvedAddr := firstNonEmpty(*contractAddrHex, os.Getenv("CONTRACT_ADDRESS"))
+	if resolvedRPC == "" || resolvedAddr == "" {
+		return fmt.Errorf("缺少必要参数：需提供 RPC_URL、CONTRACT_ADDRESS（命令行参数或环境变量）")
+	}
+	if !common.IsHexAddress(resolvedAddr) {
+		return fmt.Errorf("合约地址不合法: %s", resolvedAddr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	if err != nil {
+		return fmt.Errorf("连接 RPC 失败: %w", err)
+	}
+	defer client.Close()
+
+	toBlock, err := resolveToBlock(ctx, client, *toArg)
+	if err != nil {
+		return err
+	}
+	if *fromBlock > toBlock {
+		return fmt.Errorf("起始区块 %d 大于结束区块 %d", *fromBlock, toBlock)
+	}
+
+	filterer, err := counter.NewCounterFilterer(common.HexToAddress(resolvedAddr), client)
+	if err != nil {
+		return fmt.Errorf("创建合约实例失败: %w", err)
+	}
+	total := 0
+	_, err = filterIncremented(ctx, filterer, *fromBlock, toBlock, *chunk, func(ev *counter.CounterIncremented) error {
+		total++
+		return printEvent(ev, *asJSON)
+	})
+	if err != nil {
+		return err
+	}
+	if !*asJSON {
+		fmt.Printf("=== 区块 %d - %d 共 %d 条 Incremented 事件 ===\n", *fromBlock, toBlock, total)
+	}
+	return nil
+}
+
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "Sepolia RPC URL，ws:// 或 wss:// 时使用订阅，否则轮询")
+	contractAddrHex := fs.String("addr", "", "已部署 Counter 合约地址")
+	pollInterval := fs.Duration("poll", defaultPollInterval, "HTTP RPC 下的轮询间隔")
+	chunk := fs.Uint64("chunk", defaultChunkSize, "轮询时单次 eth_getLogs 查询的区块数")
+	asJSON := fs.Bool("json", false, "以 JSON Lines 输出")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
+	resolvedAddr := firstNonEmpty(*contractAddrHex, os.Getenv("CONTRACT_ADDRESS"))
+	if resolvedRPC == "" || resolvedAddr == "" {
+		return fmt.Errorf("缺少必要参数：需提供 RPC_URL、CONTRACT_ADDRESS（命令行参数或环境变量）")
+	}
+	if !common.IsHexAddress(resolvedAddr) {
+		return fmt.Errorf("合约地址不合法: %s", resolvedAddr)
+	}
+
+	// Ctrl+C 退出
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	if err != nil {
+		return fmt.Errorf("连接 RPC 失败: %w", err)
+	}
+	defer client.Close()
+
+	filterer, err := counter.NewCounterFilterer(common.HexToAddress(resolvedAddr), client)
+	if err != nil {
+		return fmt.Errorf("创建合约实例失败: %w", err)
+	}
+	handle := func(ev *counter.CounterIncremented) error { return printEvent(ev, *asJSON) }
+
+	err = watchIncremented(ctx, filterer, client, *pollInterval, *chunk, handle)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// watchIncremented 优先使用订阅；节点不支持订阅（HTTP）或订阅中断时，从最后处理的区块之后改为轮询
+func watchIncremented(ctx context.Context, filterer *counter.CounterFilterer, heads headReader, interval time.Duration, chunk uint64, handle func(*counter.CounterIncremented) error) error {
+	head, err := heads.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("获取最新区块号失败: %w", err)
+	}
+	next := head + 1
+
+	sink := make(chan *counter.CounterIncremented)
+	sub, err := filterer.WatchIncremented(&bind.WatchOpts{Context: ctx}, sink)
+	switch {
+	case errors.Is(err, rpc.ErrNotificationsUnsupported):
+		fmt.Fprintf(os.Stderr, "RPC 不支持订阅，改为每 %s 轮询一次\n", interval)
+		return pollIncremented(ctx, filterer, heads, next, interval, chunk, handle)
+	case err != nil:
+		return fmt.Errorf("订阅事件失败: %w", err)
+	}
+	defer sub.Unsubscribe()
+	fmt.Fprintln(os.Stderr, "已订阅 Incremented 事件，按 Ctrl+C 退出")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "订阅中断（%v），从区块 %d 起改为轮询\n", err, next)
+			return pollIncremented(ctx, filterer, heads, next, interval, chunk, handle)
+		case ev := <-sink:
+			if err := handle(ev); err != nil {
+				return err
+			}
+			if !ev.Raw.Removed && ev.Raw.BlockNumber+1 > next {
+				next = ev.Raw.BlockNumber + 1
+			}
+		}
+	}
+}
+
+// pollIncremented 从 next 开始按 interval 轮询新区块并拉取其中的事件
+func pollIncremented(ctx context.Context, filterer *counter.CounterFilterer, heads headReader, next uint64, interval time.Duration, chunk uint64, handle func(*counter.CounterIncremented) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		head, err := heads.BlockNumber(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// 临时网络错误不退出，下个周期重试
+			fmt.Fprintf(os.Stderr, "获取最新区块号失败: %v\n", err)
+		} else if head >= next {
+			// 按分段推进 next：后面的分段失败时，已输出的分段不会在下个周期重复输出
+			next, err = filterIncremented(ctx, filterer, next, head, chunk, handle)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"lesson4/task2/bindings/counter"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// limitedFilterer 在模拟链上模拟节点限制：跨度超过 maxSpan 时报结果数超限，
+// failBlock 非 0 时起点为该区块的第一次查询返回普通错误，noSubscribe 时像 HTTP 节点一样不支持订阅
+type limitedFilterer struct {
+	bind.ContractFilterer
+	maxSpan     uint64
+	noSubscribe bool
+	subscribed  chan struct{}
+
+	mu        sync.Mutex
+	failBlock uint64
+	calls     [][2]uint64
+}
+
+func (f *limitedFilterer) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	f.mu.Lock()
+	f.calls = append(f.calls, [2]uint64{from, to})
+	fail := f.failBlock != 0 && from == f.failBlock
+	if fail {
+		f.failBlock = 0
+	}
+	f.mu.Unlock()
+	if f.maxSpan > 0 && to-from+1 > f.maxSpan {
+		return nil, fmt.Errorf("query returned more than 10000 results")
+	}
+	if fail {
+		return nil, fmt.Errorf("connection reset by peer")
+	}
+	return f.ContractFilterer.FilterLogs(ctx, q)
+}
+
+func (f *limitedFilterer) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if f.subscribed != nil {
+		close(f.subscribed)
+	}
+	if f.noSubscribe {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	return f.ContractFilterer.SubscribeFilterLogs(ctx, q, ch)
+}
+
+func (f *limitedFilterer) setFailBlock(n uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failBlock = n
+}
+
+func (f *limitedFilterer) ranges() [][2]uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][2]uint64(nil), f.calls...)
+}
+
+// gatedHeads 第一次读取区块号时直接返回，之后等 release 关闭后才返回，便于让一次轮询覆盖多个区块
+type gatedHeads struct {
+	headReader
+	once    sync.Once
+	release chan struct{}
+}
+
+func (h *gatedHeads) BlockNumber(ctx context.Context) (uint64, error) {
+	first := false
+	h.once.Do(func() { first = true })
+	if !first {
+		select {
+		case <-h.release:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+	return h.headReader.BlockNumber(ctx)
+}
+
+// incrementN 调用 increment n 次，返回每次事件所在的区块号
+func incrementN(t *testing.T, chain *testChain, addr common.Address, n int) []uint64 {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	blocks := make([]uint64, 0, n)
+	for i := 0; i < n; i++ {
+		result, err := incrementCounter(ctx, chain.Client, chain.signer, addr, chain.opts())
+		if err != nil {
+			t.Fatalf("increment #%d: %v", i+1, err)
+		}
+		blocks = append(blocks, result.Event.Raw.BlockNumber)
+	}
+	return blocks
+}
+
+func TestForEachChunkHalvesOnRangeLimit(t *testing.T) {
+	chain := newTestChain(t)
+	chain.AutoMine(t)
+	addr := chain.deploy(t)
+	blocks := incrementN(t, chain, addr, 3)
+
+	limited := &limitedFilterer{ContractFilterer: chain.Client, maxSpan: 2}
+	filterer, err := counter.NewCounterFilterer(addr, limited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var got []int64
+	head := blocks[len(blocks)-1]
+	next, err := filterIncremented(ctx, filterer, 0, head, 100, func(ev *counter.CounterIncremented) error {
+		got = append(got, ev.NewValue.Int64())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("filterIncremented: %v", err)
+	}
+	if next != head+1 {
+		t.Errorf("next = %d, 期望 %d", next, head+1)
+	}
+	if fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("事件 = %v, 期望 [1 2 3]", got)
+	}
+	// 第一次按 100 个区块查询被拒，之后每段都不超过 2 个区块且首尾相接
+	calls := limited.ranges()
+	if calls[0] != [2]uint64{0, head} {
+		t.Errorf("第一次查询 = %v, 期望 [0 %d]", calls[0], head)
+	}
+	covered := uint64(0)
+	for _, c := range calls {
+		if c[1]-c[0]+1 > 2 {
+			continue
+		}
+		if c[0] != covered {
+			t.Fatalf("分段 %v 不连续，期望从 %d 开始（全部查询 %v）", c, covered, calls)
+		}
+		covered = c[1] + 1
+	}
+	if covered != head+1 {
+		t.Errorf("分段覆盖到 %d, 期望 %d（全部查询 %v）", covered, head+1, calls)
+	}
+}
+
+func TestFilterIncrementedStopsAtFailedChunk(t *testing.T) {
+	chain := newTestChain(t)
+	chain.AutoMine(t)
+	addr := chain.deploy(t)
+	blocks := incrementN(t, chain, addr, 3)
+
+	limited := &limitedFilterer{ContractFilterer: chain.Client, failBlock: blocks[1]}
+	filterer, err := counter.NewCounterFilterer(addr, limited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var got []int64
+	next, err := filterIncremented(ctx, filterer, 0, blocks[2], 1, func(ev *counter.CounterIncremented) error {
+		got = append(got, ev.NewValue.Int64())
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("err = %v, 期望透传节点错误", err)
+	}
+	// 普通错误不缩小分段，返回失败分段的起点，之前的分段已经处理
+	if next != blocks[1] {
+		t.Errorf("next = %d, 期望失败分段起点 %d", next, blocks[1])
+	}
+	if fmt.Sprint(got) != "[1]" {
+		t.Errorf("事件 = %v, 期望 [1]", got)
+	}
+	calls := limited.ranges()
+	if last := calls[len(calls)-1]; last != [2]uint64{blocks[1], blocks[1]} {
+		t.Errorf("最后一次查询 = %v, 期望只查询一次失败区块 %d", last, blocks[1])
+	}
+}
+
+func TestIsRangeLimitErr(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want bool
+	}{
+		{"query returned more than 10000 results", true},
+		{"eth_getLogs block range is too large", true},
+		{"exceed maximum block range: 5000", true},
+		{"Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range", true},
+		{"eth_getLogs is limited to a 10,000 range", true},
+		{"rate limit exceeded", false},
+		{"context deadline exceeded", false},
+		{"i/o timeout", false},
+		{"gas limit reached", false},
+	}
+	for _, tt := range tests {
+		if got := isRangeLimitErr(errors.New(tt.msg)); got != tt.want {
+			t.Errorf("isRangeLimitErr(%q) = %v, 期望 %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestWatchFallsBackToPolling(t *testing.T) {
+	chain := newTestChain(t)
+	chain.AutoMine(t)
+	addr := chain.deploy(t)
+
+	limited := &limitedFilterer{ContractFilterer: chain.Client, noSubscribe: true, subscribed: make(chan struct{})}
+	filterer, err := counter.NewCounterFilterer(addr, limited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	heads := &gatedHeads{headReader: chain.Client, release: make(chan struct{})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	events := make(chan int64, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- watchIncremented(ctx, filterer, heads, 10*time.Millisecond, 1, func(ev *counter.CounterIncremented) error {
+			events <- ev.NewValue.Int64()
+			return nil
+		})
+	}()
+	select {
+	case <-limited.subscribed:
+	case <-ctx.Done():
+		t.Fatal("watchIncremented 没有尝试订阅")
+	}
+
+	// 三笔交易都上链后才放开轮询，第一次轮询覆盖全部区块，其中第二笔所在区块失败一次
+	blocks := incrementN(t, chain, addr, 3)
+	limited.setFailBlock(blocks[1])
+	close(heads.release)
+
+	var got []int64
+	for len(got) < 3 {
+		select {
+		case v := <-events:
+			got = append(got, v)
+		case err := <-done:
+			t.Fatalf("watchIncremented 提前退出: %v（已收到 %v）", err, got)
+		case <-ctx.Done():
+			t.Fatalf("超时，已收到 %v", got)
+		}
+	}
+	// 再等几个轮询周期，确认失败区块之前的事件不会重复输出
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("watchIncremented 返回 %v, 期望 context.Canceled", err)
+	}
+	close(events)
+	for v := range events {
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("事件 = %v, 期望每个只输出一次 [1 2 3]", got)
+	}
+}
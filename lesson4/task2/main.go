@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"flag"
 	"fmt"
 	"log"
@@ -11,16 +10,17 @@ import (
 	"strings"
 	"time"
 
-	"lesson4/task2/bindings/counter"
+	"lesson4/signer"
+	"lesson4/txmgr"
 
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-const defaultChainID = 11155111
+const (
+	defaultChainID   = 11155111
+	defaultStorePath = "txstore.json"
+)
 
 func main() {
 	if len(os.Args) < 2 {
@@ -37,6 +37,18 @@ func main() {
 		if err := runCall(os.Args[2:]); err != nil {
 			log.Fatalf("调用合约失败: %v", err)
 		}
+	case "contract":
+		if err := runContract(os.Args[2:]); err != nil {
+			log.Fatalf("合约操作失败: %v", err)
+		}
+	case "events":
+		if err := runEvents(os.Args[2:]); err != nil {
+			log.Fatalf("查询事件失败: %v", err)
+		}
+	case "watch":
+		if err := runWatch(os.Args[2:]); err != nil {
+			log.Fatalf("监听事件失败: %v", err)
+		}
 	default:
 		usage()
 		os.Exit(1)
@@ -47,28 +59,47 @@ func usage() {
 	fmt.Println("Counter 合约工具")
 	fmt.Println("")
 	fmt.Println("用法:")
-	fmt.Println("  go run . deploy [-rpc <RPC_URL>] [-pk <PRIVATE_KEY>] [-chainid 11155111]")
-	fmt.Println("  go run . call [-rpc <RPC_URL>] [-pk <PRIVATE_KEY>] [-addr <CONTRACT_ADDRESS>] [-chainid 11155111]")
+	fmt.Println("  go run . deploy [-rpc <RPC_URL>] <签名参数> [-chainid 11155111] [-confirmations 1] [-gas 0]")
+	fmt.Println("  go run . call [-rpc <RPC_URL>] <签名参数> [-addr <CONTRACT_ADDRESS>] [-chainid 11155111] [-confirmations 1] [-gas 0]")
+	fmt.Println("  go run . events [-rpc <RPC_URL>] [-addr <CONTRACT_ADDRESS>] [-from <起始区块>] [-to latest] [-chunk 2000] [-json]")
+	fmt.Println("  go run . watch [-rpc <ws(s)://... | https://...>] [-addr <CONTRACT_ADDRESS>] [-poll 4s] [-json]")
+	fmt.Println("")
+	fmt.Println("任意合约（-abi 为 ABI 或构建产物；位置参数按 ABI 类型解析，数组/tuple 用 JSON，例如 '[1,2]' '[\"0x..\",5]'）:")
+	fmt.Println("  go run . contract deploy <签名参数> -abi <ABI文件> [-bin <字节码文件>] [-value 0] [构造参数...]")
+	fmt.Println("  go run . contract call -abi <ABI文件> -addr <合约地址> -method '<方法签名>' [-from <地址>] [参数...]")
+	fmt.Println("  go run . contract send <签名参数> -abi <ABI文件> -addr <合约地址> -method '<方法签名>' [-value 0] [参数...]")
+	fmt.Println("  go run . contract events -abi <ABI文件> -addr <合约地址> [-event <事件名>] [-from-block 0] [-to-block latest]")
+	fmt.Println("")
+	fmt.Println("签名参数（任选其一）:")
+	fmt.Println("  -keystore <文件> [-password-file <口令文件>]")
+	fmt.Println("  -mnemonic-file <文件> [-hd-path m/44'/60'/0'/0/0] [-mnemonic-passphrase-file <文件> | -mnemonic-passphrase]")
+	fmt.Println("  -pk <PRIVATE_KEY>（不推荐）")
 	fmt.Println("")
 	fmt.Println("环境变量:")
 	fmt.Println("  RPC_URL")
-	fmt.Println("  PRIVATE_KEY")
-	fmt.Println("  CONTRACT_ADDRESS (仅 call 时可用)")
+	fmt.Println("  KEYSTORE / PASSWORD_FILE / MNEMONIC_FILE（PRIVATE_KEY 仍兼容）")
+	fmt.Println("  CONTRACT_ADDRESS (call / events / watch / contract 时可用)")
 }
 
 func runDeploy(args []string) error {
 	fs := flag.NewFlagSet("deploy", flag.ContinueOnError)
 	rpcURL := fs.String("rpc", "", "Sepolia RPC URL")
-	privateKeyHex := fs.String("pk", "", "部署者私钥（16进制，支持带/不带 0x）")
+	signerFlags := signer.RegisterFlags(fs)
 	chainID := fs.Int64("chainid", defaultChainID, "链 ID，Sepolia 默认为 11155111")
+	storePath := fs.String("store", defaultStorePath, "待确认交易记录文件")
+	confirmations := fs.Uint64("confirmations", 1, "确认深度")
+	gasLimit := fs.Uint64("gas", 0, "gas 上限，0 表示自动估算")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
 	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
-	resolvedPK := firstNonEmpty(*privateKeyHex, os.Getenv("PRIVATE_KEY"))
-	if resolvedRPC == "" || resolvedPK == "" {
-		return fmt.Errorf("缺少必要参数：需提供 RPC_URL、PRIVATE_KEY（命令行参数或环境变量）")
+	if resolvedRPC == "" {
+		return fmt.Errorf("缺少必要参数：需提供 RPC_URL（命令行参数或环境变量）")
+	}
+	txSigner, err := signerFlags.Load()
+	if err != nil {
+		return err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
@@ -80,63 +111,50 @@ func runDeploy(args []string) error {
 	}
 	defer client.Close()
 
-	privKey, fromAddr, err := parsePrivateKey(resolvedPK)
-	if err != nil {
-		return fmt.Errorf("解析私钥失败: %w", err)
-	}
-
-	auth, err := bind.NewKeyedTransactorWithChainID(privKey, big.NewInt(*chainID))
+	contractAddr, rec, err := deployCounter(ctx, client, txSigner, txOptions{
+		ChainID:  big.NewInt(*chainID),
+		Store:    txmgr.NewFileStore(*storePath),
+		Config:   txmgr.Config{Confirmations: *confirmations},
+		GasLimit: *gasLimit,
+	})
 	if err != nil {
-		return fmt.Errorf("创建签名器失败: %w", err)
-	}
-	auth.Context = ctx
-
-	if err = fillDynamicFee(ctx, client, auth); err != nil {
-		return fmt.Errorf("获取动态费参数失败: %w", err)
-	}
-
-	contractAddr, tx, _, err := counter.DeployCounter(auth, client)
-	if err != nil {
-		return fmt.Errorf("发送部署交易失败: %w", err)
-	}
-	fmt.Printf("部署交易已发送: %s\n", tx.Hash().Hex())
-
-	receipt, err := bind.WaitMined(ctx, client, tx)
-	if err != nil {
-		return fmt.Errorf("等待部署交易上链失败: %w", err)
-	}
-	if receipt.Status != types.ReceiptStatusSuccessful {
-		return fmt.Errorf("部署交易执行失败，txHash=%s", tx.Hash().Hex())
+		return err
 	}
 
 	fmt.Println("=== 部署成功 ===")
-	fmt.Printf("部署者: %s\n", fromAddr.Hex())
+	fmt.Printf("部署者: %s\n", txSigner.Address().Hex())
 	fmt.Printf("合约地址: %s\n", contractAddr.Hex())
-	fmt.Printf("区块高度: %d\n", receipt.BlockNumber.Uint64())
-	fmt.Printf("浏览器: https://sepolia.etherscan.io/tx/%s\n", tx.Hash().Hex())
+	fmt.Printf("区块高度: %d\n", rec.BlockNumber)
+	fmt.Printf("浏览器: https://sepolia.etherscan.io/tx/%s\n", rec.MinedHash.Hex())
 	return nil
 }
 
 func runCall(args []string) error {
 	fs := flag.NewFlagSet("call", flag.ContinueOnError)
 	rpcURL := fs.String("rpc", "", "Sepolia RPC URL")
-	privateKeyHex := fs.String("pk", "", "调用者私钥（16进制，支持带/不带 0x）")
+	signerFlags := signer.RegisterFlags(fs)
 	contractAddrHex := fs.String("addr", "", "已部署 Counter 合约地址")
 	chainID := fs.Int64("chainid", defaultChainID, "链 ID，Sepolia 默认为 11155111")
+	storePath := fs.String("store", defaultStorePath, "待确认交易记录文件")
+	confirmations := fs.Uint64("confirmations", 1, "确认深度")
+	gasLimit := fs.Uint64("gas", 0, "gas 上限，0 表示自动估算")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
 	resolvedRPC := firstNonEmpty(*rpcURL, os.Getenv("RPC_URL"))
-	resolvedPK := firstNonEmpty(*privateKeyHex, os.Getenv("PRIVATE_KEY"))
 	resolvedAddr := firstNonEmpty(*contractAddrHex, os.Getenv("CONTRACT_ADDRESS"))
 
-	if resolvedRPC == "" || resolvedPK == "" || resolvedAddr == "" {
-		return fmt.Errorf("缺少必要参数：需提供 RPC_URL、PRIVATE_KEY、CONTRACT_ADDRESS（命令行参数或环境变量）")
+	if resolvedRPC == "" || resolvedAddr == "" {
+		return fmt.Errorf("缺少必要参数：需提供 RPC_URL、CONTRACT_ADDRESS（命令行参数或环境变量）")
 	}
 	if !common.IsHexAddress(resolvedAddr) {
 		return fmt.Errorf("合约地址不合法: %s", resolvedAddr)
 	}
+	txSigner, err := signerFlags.Load()
+	if err != nil {
+		return err
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
@@ -148,89 +166,25 @@ func runCall(args []string) error {
 	defer client.Close()
 
 	contractAddr := common.HexToAddress(resolvedAddr)
-	ctr, err := counter.NewCounter(contractAddr, client)
-	if err != nil {
-		return fmt.Errorf("创建合约实例失败: %w", err)
-	}
-
-	before, err := ctr.GetCount(&bind.CallOpts{Context: ctx})
-	if err != nil {
-		return fmt.Errorf("读取调用前计数失败: %w", err)
-	}
-	fmt.Printf("调用前计数: %s\n", before.String())
-
-	privKey, fromAddr, err := parsePrivateKey(resolvedPK)
+	result, err := incrementCounter(ctx, client, txSigner, contractAddr, txOptions{
+		ChainID:  big.NewInt(*chainID),
+		Store:    txmgr.NewFileStore(*storePath),
+		Config:   txmgr.Config{Confirmations: *confirmations},
+		GasLimit: *gasLimit,
+	})
 	if err != nil {
-		return fmt.Errorf("解析私钥失败: %w", err)
-	}
-
-	auth, err := bind.NewKeyedTransactorWithChainID(privKey, big.NewInt(*chainID))
-	if err != nil {
-		return fmt.Errorf("创建签名器失败: %w", err)
-	}
-	auth.Context = ctx
-
-	// 让绑定方法自动估算 gas；为了兼容 EIP-1559 手动填充费用参数。
-	if err = fillDynamicFee(ctx, client, auth); err != nil {
-		return fmt.Errorf("获取动态费参数失败: %w", err)
-	}
-
-	tx, err := ctr.Increment(auth)
-	if err != nil {
-		return fmt.Errorf("发送 increment 交易失败: %w", err)
-	}
-	fmt.Printf("交易已发送: %s\n", tx.Hash().Hex())
-
-	receipt, err := bind.WaitMined(ctx, client, tx)
-	if err != nil {
-		return fmt.Errorf("等待交易上链失败: %w", err)
-	}
-	if receipt.Status != types.ReceiptStatusSuccessful {
-		return fmt.Errorf("交易执行失败，txHash=%s", tx.Hash().Hex())
-	}
-
-	after, err := ctr.GetCount(&bind.CallOpts{Context: ctx})
-	if err != nil {
-		return fmt.Errorf("读取调用后计数失败: %w", err)
+		return err
 	}
 
 	fmt.Println("=== 调用结果 ===")
-	fmt.Printf("发送方: %s\n", fromAddr.Hex())
+	fmt.Printf("发送方: %s\n", txSigner.Address().Hex())
 	fmt.Printf("合约地址: %s\n", contractAddr.Hex())
-	fmt.Printf("区块高度: %d\n", receipt.BlockNumber.Uint64())
-	fmt.Printf("调用后计数: %s\n", after.String())
-	fmt.Printf("浏览器: https://sepolia.etherscan.io/tx/%s\n", tx.Hash().Hex())
-	return nil
-}
-
-func parsePrivateKey(privateKeyHex string) (*ecdsa.PrivateKey, common.Address, error) {
-	privateKeyHex = strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x")
-	privKey, err := crypto.HexToECDSA(privateKeyHex)
-	if err != nil {
-		return nil, common.Address{}, fmt.Errorf("私钥格式错误: %w", err)
+	fmt.Printf("区块高度: %d\n", result.Record.BlockNumber)
+	fmt.Printf("调用后计数: %s\n", result.After.String())
+	if result.Event != nil {
+		fmt.Printf("Incremented 事件: newValue=%s\n", result.Event.NewValue.String())
 	}
-	pubKey, ok := privKey.Public().(*ecdsa.PublicKey)
-	if !ok {
-		return nil, common.Address{}, fmt.Errorf("解析公钥失败")
-	}
-	return privKey, crypto.PubkeyToAddress(*pubKey), nil
-}
-
-func fillDynamicFee(ctx context.Context, client *ethclient.Client, auth *bind.TransactOpts) error {
-	tipCap, err := client.SuggestGasTipCap(ctx)
-	if err != nil {
-		return err
-	}
-	header, err := client.HeaderByNumber(ctx, nil)
-	if err != nil {
-		return err
-	}
-
-	feeCap := new(big.Int).Mul(header.BaseFee, big.NewInt(2))
-	feeCap.Add(feeCap, tipCap)
-
-	auth.GasTipCap = tipCap
-	auth.GasFeeCap = feeCap
+	fmt.Printf("浏览器: https://sepolia.etherscan.io/tx/%s\n", result.Record.MinedHash.Hex())
 	return nil
 }
 
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"nft-auction/bindings/auction"
+	"nft-auction/bindings/mockfeed"
+	"nft-auction/bindings/mynft"
+	"nft-auction/bindings/mytoken"
+
+	"lesson4/signer"
+	"lesson4/txmgr"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errTxFailed 交易已打包但执行失败（revert 或 gas 耗尽）
+var errTxFailed = errors.New("交易执行失败")
+
+// chainBackend 所有子命令需要的链上能力；*ethclient.Client 与 simulated.Client 都满足
+type chainBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	txmgr.Backend
+}
+
+// session 一个签名账户在一次命令中的发送上下文：所有交易共用同一个 txmgr，nonce 连续分配
+type session struct {
+	backend chainBackend
+	signer  signer.Signer
+	chainID *big.Int
+	mgr     *txmgr.Manager
+}
+
+func newSession(backend chainBackend, txSigner signer.Signer, chainID *big.Int, store txmgr.Store, cfg txmgr.Config) (*session, error) {
+	mgr, err := txmgr.New(backend, txSigner.Address(), signer.SignerFn(txSigner, chainID), store, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("加载交易记录失败: %w", err)
+	}
+	return &session{backend: backend, signer: txSigner, chainID: chainID, mgr: mgr}, nil
+}
+
+func (s *session) From() common.Address {
+	return s.signer.Address()
+}
+
+// transact 分配 nonce、填充 EIP-1559 费用后调用 send 发出交易，等待达到确认深度并返回回执
+func (s *session) transact(ctx context.Context, desc string, value *big.Int, send func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Receipt, error) {
+	auth := signer.TransactOpts(s.signer, s.chainID)
+	auth.Context = ctx
+	auth.Value = value
+
+	tipCap, feeCap, err := txmgr.SuggestFees(ctx, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("获取动态费参数失败: %w", err)
+	}
+	auth.GasTipCap, auth.GasFeeCap = tipCap, feeCap
+
+	nonce, err := s.mgr.ReserveNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+	auth.Nonce = new(big.Int).SetUint64(nonce)
+
+	tx, err := send(auth)
+	if err != nil {
+		s.mgr.ReleaseNonce(nonce)
+		return nil, fmt.Errorf("发送 %s 交易失败: %w", desc, err)
+	}
+	fmt.Printf("%s 交易已发送: %s\n", desc, tx.Hash().Hex())
+
+	if _, err := s.mgr.Adopt(tx); err != nil {
+		return nil, err
+	}
+	rec, err := s.mgr.Wait(ctx, tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("等待 %s 交易上链失败: %w", desc, err)
+	}
+	if rec.Status != txmgr.StatusConfirmed {
+		return nil, fmt.Errorf("%s %w，txHash=%s，状态=%s", desc, errTxFailed, rec.Hash.Hex(), rec.Status)
+	}
+	// 被替换过的交易以实际打包的哈希为准
+	receipt, err := s.backend.TransactionReceipt(ctx, rec.MinedHash)
+	if err != nil {
+		return nil, fmt.Errorf("获取 %s 交易回执失败: %w", desc, err)
+	}
+	return receipt, nil
+}
+
+// mintNFT 由 NFT 合约 owner 调用 mintNFT，从 Transfer 事件中取出新 tokenId
+func mintNFT(ctx context.Context, s *session, nftAddr, to common.Address) (*big.Int, error) {
+	nft, err := mynft.NewMyNFT(nftAddr, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建 NFT 合约实例失败: %w", err)
+	}
+	receipt, err := s.transact(ctx, "mintNFT", nil, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return nft.MintNFT(auth, to)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range receipt.Logs {
+		if ev, err := nft.ParseTransfer(*l); err == nil && ev.From == (common.Address{}) {
+			return ev.TokenId, nil
+		}
+	}
+	return nil, fmt.Errorf("回执中没有 Transfer 事件")
+}
+
+// createAuction 创建拍卖；拍卖合约尚未获得该 NFT 的授权时先发送 approve
+func createAuction(ctx context.Context, s *session, auctionAddr, nftAddr common.Address, tokenID, endTime *big.Int) (*auction.AuctionAuctionCreated, error) {
+	nft, err := mynft.NewMyNFT(nftAddr, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建 NFT 合约实例失败: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	owner, err := nft.OwnerOf(opts, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("读取 NFT #%s 持有人失败: %w", tokenID, err)
+	}
+	if owner != s.From() {
+		return nil, fmt.Errorf("NFT #%s 的持有人是 %s，不是当前账户 %s", tokenID, owner.Hex(), s.From().Hex())
+	}
+	approved, err := nft.GetApproved(opts, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("读取 NFT 授权失败: %w", err)
+	}
+	if approved != auctionAddr {
+		forAll, err := nft.IsApprovedForAll(opts, s.From(), auctionAddr)
+		if err != nil {
+			return nil, fmt.Errorf("读取 NFT 授权失败: %w", err)
+		}
+		if !forAll {
+			if _, err := s.transact(ctx, "approve NFT", nil, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+				return nft.Approve(auth, auctionAddr, tokenID)
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	market, err := auction.NewAuction(auctionAddr, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建拍卖合约实例失败: %w", err)
+	}
+	receipt, err := s.transact(ctx, "createAuction", nil, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return market.CreateAuction(auth, nftAddr, tokenID, endTime)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range receipt.Logs {
+		if ev, err := market.ParseAuctionCreated(*l); err == nil {
+			return ev, nil
+		}
+	}
+	return nil, fmt.Errorf("回执中没有 AuctionCreated 事件")
+}
+
+// bidWithETH 用 ETH 出价
+func bidWithETH(ctx context.Context, s *session, auctionAddr common.Address, auctionID, amount *big.Int) (*auction.AuctionBidPlaced, error) {
+	market, err := auction.NewAuction(auctionAddr, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建拍卖合约实例失败: %w", err)
+	}
+	receipt, err := s.transact(ctx, "bidWithETH", amount, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return market.BidWithETH(auth, auctionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findBidPlaced(market, receipt)
+}
+
+// bidWithERC20 用 ERC-20 出价；授权额度不足时先 approve 本次出价金额
+func bidWithERC20(ctx context.Context, s *session, auctionAddr common.Address, auctionID *big.Int, tokenAddr common.Address, amount *big.Int) (*auction.AuctionBidPlaced, error) {
+	token, err := mytoken.NewMyToken(tokenAddr, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建代币合约实例失败: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	balance, err := token.BalanceOf(opts, s.From())
+	if err != nil {
+		return nil, fmt.Errorf("读取代币余额失败: %w", err)
+	}
+	if balance.Cmp(amount) < 0 {
+		return nil, fmt.Errorf("代币余额不足：持有 %s，出价 %s", balance, amount)
+	}
+	allowance, err := token.Allowance(opts, s.From(), auctionAddr)
+	if err != nil {
+		return nil, fmt.Errorf("读取代币授权额度失败: %w", err)
+	}
+	if allowance.Cmp(amount) < 0 {
+		if _, err := s.transact(ctx, "approve ERC20", nil, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+			return token.Approve(auth, auctionAddr, amount)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	market, err := auction.NewAuction(auctionAddr, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建拍卖合约实例失败: %w", err)
+	}
+	receipt, err := s.transact(ctx, "bidWithERC20", nil, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return market.BidWithERC20(auth, auctionID, tokenAddr, amount)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findBidPlaced(market, receipt)
+}
+
+func findBidPlaced(market *auction.Auction, receipt *types.Receipt) (*auction.AuctionBidPlaced, error) {
+	for _, l := range receipt.Logs {
+		if ev, err := market.ParseBidPlaced(*l); err == nil {
+			return ev, nil
+		}
+	}
+	return nil, fmt.Errorf("回执中没有 BidPlaced 事件")
+}
+
+// endAuction 结束到期的拍卖：NFT 转给最高出价者，出价转给卖家
+func endAuction(ctx context.Context, s *session, auctionAddr common.Address, auctionID *big.Int) (*auction.AuctionAuctionEnded, error) {
+	market, err := auction.NewAuction(auctionAddr, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建拍卖合约实例失败: %w", err)
+	}
+	receipt, err := s.transact(ctx, "endAuction", nil, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return market.EndAuction(auth, auctionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range receipt.Logs {
+		if ev, err := market.ParseAuctionEnded(*l); err == nil {
+			return ev, nil
+		}
+	}
+	return nil, fmt.Errorf("回执中没有 AuctionEnded 事件")
+}
+
+// withdrawRefunds 提取被超价后排队的退款：ETH 以及 tokens 中每个有余额的代币；没有退款时返回空
+func withdrawRefunds(ctx context.Context, s *session, auctionAddr common.Address, tokens []common.Address) ([]*auction.AuctionRefundWithdrawn, error) {
+	market, err := auction.NewAuction(auctionAddr, s.backend)
+	if err != nil {
+		return nil, fmt.Errorf("创建拍卖合约实例失败: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	var withdrawn []*auction.AuctionRefundWithdrawn
+	collect := func(receipt *types.Receipt) {
+		for _, l := range receipt.Logs {
+			if ev, err := market.ParseRefundWithdrawn(*l); err == nil {
+				withdrawn = append(withdrawn, ev)
+			}
+		}
+	}
+
+	pendingEth, err := market.PendingEthReturns(opts, s.From())
+	if err != nil {
+		return nil, fmt.Errorf("读取 ETH 待退款失败: %w", err)
+	}
+	if pendingEth.Sign() > 0 {
+		receipt, err := s.transact(ctx, "withdrawEthRefund", nil, market.WithdrawEthRefund)
+		if err != nil {
+			return withdrawn, err
+		}
+		collect(receipt)
+	}
+	for _, token := range tokens {
+		pending, err := market.PendingTokenReturns(opts, s.From(), token)
+		if err != nil {
+			return withdrawn, fmt.Errorf("读取代币 %s 待退款失败: %w", token.Hex(), err)
+		}
+		if pending.Sign() == 0 {
+			continue
+		}
+		receipt, err := s.transact(ctx, "withdrawTokenRefund", nil, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+			return market.WithdrawTokenRefund(auth, token)
+		})
+		if err != nil {
+			return withdrawn, err
+		}
+		collect(receipt)
+	}
+	return withdrawn, nil
+}
+
+// latestPrice 读取拍卖合约使用的 ETH/USD 价格及喂价精度
+func latestPrice(ctx context.Context, backend bind.ContractCaller, auctionAddr common.Address) (*big.Int, uint8, error) {
+	market, err := auction.NewAuctionCaller(auctionAddr, backend)
+	if err != nil {
+		return nil, 0, fmt.Errorf("创建拍卖合约实例失败: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	price, err := market.GetLatestPrice(opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("读取 getLatestPrice 失败: %w", err)
+	}
+	feedAddr, err := market.EthUsdFeed(opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("读取 ethUsdFeed 失败: %w", err)
+	}
+	// Chainlink 聚合器与 MockV3Aggregator 都实现了 decimals()
+	feed, err := mockfeed.NewMockV3AggregatorCaller(feedAddr, backend)
+	if err != nil {
+		return nil, 0, fmt.Errorf("创建喂价合约实例失败: %w", err)
+	}
+	decimals, err := feed.Decimals(opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("读取喂价精度失败: %w", err)
+	}
+	return price, decimals, nil
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"nft-auction/bindings/auction"
+	"nft-auction/bindings/mockfeed"
+	"nft-auction/bindings/mynft"
+	"nft-auction/bindings/mytoken"
+
+	"lesson4/simtest"
+	"lesson4/txmgr"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// 与 Hardhat 测试一致：喂价 8 位精度，ETH = 2000 USD，MTK = 1 USD
+var (
+	ethPrice   = big.NewInt(2000e8)
+	tokenPrice = big.NewInt(1e8)
+)
+
+type artifact struct {
+	abi  *abi.ABI
+	code []byte
+}
+
+// loadArtifact 从 abigen 绑定中取 ABI 与部署字节码，绑定需由 scripts/generate-bindings.ps1 带 --bin 生成
+func loadArtifact(t *testing.T, name string, meta *bind.MetaData) artifact {
+	t.Helper()
+	if meta.Bin == "" {
+		t.Fatalf("%s 的绑定不包含字节码，在 nft-auction 目录执行 ./go/scripts/generate-bindings.ps1 重新生成", name)
+	}
+	parsed, err := meta.GetAbi()
+	if err != nil {
+		t.Fatalf("解析 %s 的 ABI: %v", name, err)
+	}
+	code, err := hexutil.Decode(meta.Bin)
+	if err != nil {
+		t.Fatalf("解析 %s 的字节码: %v", name, err)
+	}
+	return artifact{abi: parsed, code: code}
+}
+
+type testEnv struct {
+	*simtest.Chain
+
+	seller, bidder1, bidder2 *session
+
+	auction   common.Address
+	nft       common.Address
+	token     common.Address
+	ethFeed   common.Address
+	tokenFeed common.Address
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	artifacts := map[string]artifact{
+		"MockV3Aggregator":   loadArtifact(t, "MockV3Aggregator", mockfeed.MockV3AggregatorMetaData),
+		"MyNFT":              loadArtifact(t, "MyNFT", mynft.MyNFTMetaData),
+		"MyToken":            loadArtifact(t, "MyToken", mytoken.MyTokenMetaData),
+		"AuctionUpgradeable": loadArtifact(t, "AuctionUpgradeable", auction.AuctionMetaData),
+	}
+
+	chain := simtest.New(t, 3, 100)
+	signers := chain.Signers
+	env := &testEnv{Chain: chain}
+	sessions := make([]*session, len(signers))
+	for i, s := range signers {
+		sess, err := newSession(env.Client, s, big.NewInt(simtest.ChainID), txmgr.NewMemoryStore(), txmgr.Config{Confirmations: 1, PollInterval: 10 * time.Millisecond})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sessions[i] = sess
+	}
+	env.seller, env.bidder1, env.bidder2 = sessions[0], sessions[1], sessions[2]
+	env.AutoMine(t)
+
+	ctx := testContext(t)
+	env.ethFeed = env.deploy(t, ctx, artifacts["MockV3Aggregator"], uint8(8), ethPrice)
+	env.tokenFeed = env.deploy(t, ctx, artifacts["MockV3Aggregator"], uint8(8), tokenPrice)
+	env.nft = env.deploy(t, ctx, artifacts["MyNFT"])
+	env.token = env.deploy(t, ctx, artifacts["MyToken"])
+	// 实现合约没有禁用初始化，测试直接初始化实现合约，省去部署 ERC1967 代理
+	env.auction = env.deploy(t, ctx, artifacts["AuctionUpgradeable"])
+
+	market, err := auction.NewAuction(env.auction, env.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.send(t, ctx, env.seller, "initialize", func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return market.Initialize(auth, env.ethFeed)
+	})
+	env.send(t, ctx, env.seller, "setTokenPriceFeed", func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return market.SetTokenPriceFeed(auth, env.token, env.tokenFeed)
+	})
+	return env
+}
+
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func (e *testEnv) deploy(t *testing.T, ctx context.Context, art artifact, params ...interface{}) common.Address {
+	t.Helper()
+	var addr common.Address
+	e.send(t, ctx, e.seller, "deploy", func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		a, tx, _, err := bind.DeployContract(auth, *art.abi, art.code, e.Client, params...)
+		addr = a
+		return tx, err
+	})
+	return addr
+}
+
+func (e *testEnv) send(t *testing.T, ctx context.Context, s *session, desc string, fn func(*bind.TransactOpts) (*types.Transaction, error)) {
+	t.Helper()
+	if _, err := s.transact(ctx, desc, nil, fn); err != nil {
+		t.Fatalf("%s: %v", desc, err)
+	}
+}
+
+// advanceTime 把链上时间推到拍卖结束之后；AdjustTime 要求交易池为空，前面的交易都已等到确认
+func (e *testEnv) advanceTime(t *testing.T, d time.Duration) {
+	t.Helper()
+	if err := e.Sim.AdjustTime(d); err != nil {
+		t.Fatalf("AdjustTime: %v", err)
+	}
+}
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.Ether))
+}
+
+// startAuction 给卖家铸造一个 NFT 并以一小时时长上架
+func (e *testEnv) startAuction(t *testing.T, ctx context.Context) *big.Int {
+	t.Helper()
+	tokenID, err := mintNFT(ctx, e.seller, e.nft, e.seller.From())
+	if err != nil {
+		t.Fatalf("mintNFT: %v", err)
+	}
+	head, err := e.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := createAuction(ctx, e.seller, e.auction, e.nft, tokenID, new(big.Int).SetUint64(head.Time+3600))
+	if err != nil {
+		t.Fatalf("createAuction: %v", err)
+	}
+	if created.TokenId.Cmp(tokenID) != 0 || created.Seller != e.seller.From() {
+		t.Fatalf("AuctionCreated = %+v", created)
+	}
+	nft, err := mynft.NewMyNFT(e.nft, e.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner, err := nft.OwnerOf(nil, tokenID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner != e.auction {
+		t.Fatalf("上架后 NFT 持有人 = %s, 期望拍卖合约", owner.Hex())
+	}
+	return created.AuctionId
+}
+
+func TestAuctionLifecycle(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := testContext(t)
+	auctionID := env.startAuction(t, ctx)
+
+	token, err := mytoken.NewMyToken(env.token, env.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.send(t, ctx, env.seller, "mint MTK", func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return token.Mint(auth, env.bidder2.From(), ether(5000))
+	})
+
+	// 1 ETH = 2000 USD
+	bid, err := bidWithETH(ctx, env.bidder1, env.auction, auctionID, ether(1))
+	if err != nil {
+		t.Fatalf("bidWithETH: %v", err)
+	}
+	if bid.UsdValue.Cmp(ether(2000)) != 0 {
+		t.Fatalf("ETH 出价 USD = %s, 期望 2000e18", bid.UsdValue)
+	}
+
+	// 1000 MTK 低于当前最高价，估算 gas 时就会回滚
+	if _, err := bidWithERC20(ctx, env.bidder2, env.auction, auctionID, env.token, ether(1000)); err == nil || !strings.Contains(err.Error(), "Bid too low") {
+		t.Fatalf("期望 Bid too low, got %v", err)
+	}
+
+	// 2500 MTK：授权额度不足，自动补发 approve
+	bid, err = bidWithERC20(ctx, env.bidder2, env.auction, auctionID, env.token, ether(2500))
+	if err != nil {
+		t.Fatalf("bidWithERC20: %v", err)
+	}
+	if bid.BidToken != env.token || bid.UsdValue.Cmp(ether(2500)) != 0 {
+		t.Fatalf("ERC-20 出价事件 = %+v", bid)
+	}
+
+	// 2 ETH = 4000 USD，再次超价，bidder2 的代币进入退款队列
+	if _, err := bidWithETH(ctx, env.bidder1, env.auction, auctionID, ether(2)); err != nil {
+		t.Fatalf("第二次 bidWithETH: %v", err)
+	}
+
+	if _, err := endAuction(ctx, env.seller, env.auction, auctionID); err == nil || !strings.Contains(err.Error(), "Auction not ended") {
+		t.Fatalf("到期前结束拍卖应失败, got %v", err)
+	}
+
+	sellerBefore, err := env.Client.BalanceAt(ctx, env.seller.From(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.advanceTime(t, 2*time.Hour)
+	// 由 bidder2 结束拍卖，卖家余额变化不含 gas 费
+	ended, err := endAuction(ctx, env.bidder2, env.auction, auctionID)
+	if err != nil {
+		t.Fatalf("endAuction: %v", err)
+	}
+	if ended.Winner != env.bidder1.From() || ended.Amount.Cmp(ether(2)) != 0 || ended.BidToken != (common.Address{}) {
+		t.Fatalf("AuctionEnded = %+v", ended)
+	}
+	sellerAfter, err := env.Client.BalanceAt(ctx, env.seller.From(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := new(big.Int).Sub(sellerAfter, sellerBefore); got.Cmp(ether(2)) != 0 {
+		t.Fatalf("卖家收到 %s wei, 期望 2 ETH", got)
+	}
+	nft, err := mynft.NewMyNFT(env.nft, env.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := auction.NewAuctionCaller(env.auction, env.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := info.Auctions(nil, auctionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner, err := nft.OwnerOf(nil, state.TokenId); err != nil || owner != env.bidder1.From() {
+		t.Fatalf("成交后 NFT 持有人 = %s (%v), 期望 bidder1", owner.Hex(), err)
+	}
+
+	// bidder1 第一次出价的 1 ETH、bidder2 的 2500 MTK 都在退款队列里
+	withdrawn, err := withdrawRefunds(ctx, env.bidder1, env.auction, []common.Address{env.token})
+	if err != nil {
+		t.Fatalf("bidder1 withdraw: %v", err)
+	}
+	if len(withdrawn) != 1 || withdrawn[0].RefundToken != (common.Address{}) || withdrawn[0].Amount.Cmp(ether(1)) != 0 {
+		t.Fatalf("bidder1 退款 = %+v, 期望 1 ETH", withdrawn)
+	}
+	before, err := token.BalanceOf(nil, env.bidder2.From())
+	if err != nil {
+		t.Fatal(err)
+	}
+	withdrawn, err = withdrawRefunds(ctx, env.bidder2, env.auction, []common.Address{env.token})
+	if err != nil {
+		t.Fatalf("bidder2 withdraw: %v", err)
+	}
+	if len(withdrawn) != 1 || withdrawn[0].RefundToken != env.token || withdrawn[0].Amount.Cmp(ether(2500)) != 0 {
+		t.Fatalf("bidder2 退款 = %+v, 期望 2500 MTK", withdrawn)
+	}
+	after, err := token.BalanceOf(nil, env.bidder2.From())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := new(big.Int).Sub(after, before); got.Cmp(ether(2500)) != 0 {
+		t.Fatalf("bidder2 代币余额增加 %s, 期望 2500e18", got)
+	}
+
+	// 退款已清空，再次提取不发交易
+	withdrawn, err = withdrawRefunds(ctx, env.bidder2, env.auction, []common.Address{env.token})
+	if err != nil || len(withdrawn) != 0 {
+		t.Fatalf("重复提取 = %+v, %v", withdrawn, err)
+	}
+}
+
+func TestLatestPriceFollowsFeed(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := testContext(t)
+
+	price, decimals, err := latestPrice(ctx, env.Client, env.auction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Cmp(ethPrice) != 0 || decimals != 8 {
+		t.Fatalf("getLatestPrice = %s (精度 %d), 期望 %s (精度 8)", price, decimals, ethPrice)
+	}
+
+	feed, err := mockfeed.NewMockV3Aggregator(env.ethFeed, env.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.send(t, ctx, env.seller, "updateAnswer", func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return feed.UpdateAnswer(auth, big.NewInt(3000e8))
+	})
+	if price, _, err = latestPrice(ctx, env.Client, env.auction); err != nil || price.Cmp(big.NewInt(3000e8)) != 0 {
+		t.Fatalf("更新喂价后 getLatestPrice = %s, %v", price, err)
+	}
+
+	// 出价按新价格折算：1 ETH = 3000 USD
+	auctionID := env.startAuction(t, ctx)
+	bid, err := bidWithETH(ctx, env.bidder1, env.auction, auctionID, ether(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bid.UsdValue.Cmp(ether(3000)) != 0 {
+		t.Fatalf("USD = %s, 期望 3000e18", bid.UsdValue)
+	}
+}
+
+func TestCreateAuctionRequiresOwnership(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := testContext(t)
+
+	tokenID, err := mintNFT(ctx, env.seller, env.nft, env.bidder1.From())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = createAuction(ctx, env.seller, env.auction, env.nft, tokenID, big.NewInt(time.Now().Add(time.Hour).Unix()))
+	if err == nil || !strings.Contains(err.Error(), "持有人") {
+		t.Fatalf("期望持有人校验失败, got %v", err)
+	}
+}
+
+func TestParseBidAmount(t *testing.T) {
+	cases := []struct {
+		in       string
+		decimals uint8
+		want     string
+		wantErr  string
+	}{
+		{"1", 18, "1000000000000000000", ""},
+		{"0.5", 18, "500000000000000000", ""},
+		{"2500", 6, "2500000000", ""},
+		{"0.0000001", 6, "", "金额精度超过 6 位小数"},
+		// 换算规则与 lesson4/units 一致，0 能被解析，由出价规则拒绝
+		{"0", 18, "", "出价金额必须大于 0"},
+		{"-1", 18, "", "金额不能为负"},
+		{"abc", 18, "", "无效金额"},
+	}
+	for _, c := range cases {
+		got, err := parseBidAmount(c.in, c.decimals)
+		if c.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("parseBidAmount(%q, %d) = %v, %v, 期望报错 %q", c.in, c.decimals, got, err, c.wantErr)
+			}
+			continue
+		}
+		if err != nil || got.String() != c.want {
+			t.Errorf("parseBidAmount(%q, %d) = %v, %v, 期望 %s", c.in, c.decimals, got, err, c.want)
+		}
+	}
+}
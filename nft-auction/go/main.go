@@ -0,0 +1,499 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"nft-auction/bindings/auction"
+	"nft-auction/bindings/mytoken"
+
+	"lesson4/signer"
+	"lesson4/txmgr"
+	"lesson4/units"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	defaultChainID   = 11155111
+	defaultStorePath = "txstore.json"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "mint":
+		err = runMint(os.Args[2:])
+	case "create-auction":
+		err = runCreateAuction(os.Args[2:])
+	case "bid":
+		err = runBid(os.Args[2:])
+	case "end":
+		err = runEnd(os.Args[2:])
+	case "withdraw":
+		err = runWithdraw(os.Args[2:])
+	case "price":
+		err = runPrice(os.Args[2:])
+	case "show":
+		err = runShow(os.Args[2:])
+	default:
+		usage()
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatalf("%s 失败: %v", os.Args[1], err)
+	}
+}
+
+func usage() {
+	fmt.Println("NFT 拍卖合约工具")
+	fmt.Println("")
+	fmt.Println("用法:")
+	fmt.Println("  go run . mint <签名参数> -nft <NFT合约> [-to <接收地址>]")
+	fmt.Println("  go run . create-auction <签名参数> -auction <拍卖合约> -nft <NFT合约> -token-id <ID> [-duration 24h | -end <unix时间戳>]")
+	fmt.Println("  go run . bid <签名参数> -auction <拍卖合约> -id <拍卖ID> -amount <金额> [-token <ERC20地址>]")
+	fmt.Println("  go run . end <签名参数> -auction <拍卖合约> -id <拍卖ID>")
+	fmt.Println("  go run . withdraw <签名参数> -auction <拍卖合约> [-tokens <ERC20地址,...>]")
+	fmt.Println("  go run . price -auction <拍卖合约>")
+	fmt.Println("  go run . show -auction <拍卖合约> -id <拍卖ID>")
+	fmt.Println("")
+	fmt.Println("说明:")
+	fmt.Println("  -amount 按代币精度书写（ETH 为 18 位），例如 0.5；ERC-20 出价与 create-auction 会自动补发 approve")
+	fmt.Println("  所有交易共用 -rpc、-chainid（默认 11155111）、-store、-confirmations、-timeout")
+	fmt.Println("")
+	fmt.Println("签名参数（任选其一）:")
+	fmt.Println("  -keystore <文件> [-password-file <口令文件>]")
+	fmt.Println("  -mnemonic-file <文件> [-hd-path m/44'/60'/0'/0/0]")
+	fmt.Println("  -pk <PRIVATE_KEY>（不推荐）")
+	fmt.Println("")
+	fmt.Println("环境变量:")
+	fmt.Println("  RPC_URL / AUCTION_ADDRESS / NFT_ADDRESS / BID_TOKEN_ADDRESS")
+	fmt.Println("  KEYSTORE / PASSWORD_FILE / MNEMONIC_FILE（PRIVATE_KEY 仍兼容）")
+}
+
+// txFlags 发送交易的子命令共用的参数
+type txFlags struct {
+	rpcURL        *string
+	signer        *signer.Flags
+	chainID       *int64
+	storePath     *string
+	confirmations *uint64
+	timeout       *time.Duration
+}
+
+func registerTxFlags(fs *flag.FlagSet) *txFlags {
+	return &txFlags{
+		rpcURL:        fs.String("rpc", "", "RPC URL"),
+		signer:        signer.RegisterFlags(fs),
+		chainID:       fs.Int64("chainid", defaultChainID, "链 ID，Sepolia 默认为 11155111"),
+		storePath:     fs.String("store", defaultStorePath, "待确认交易记录文件"),
+		confirmations: fs.Uint64("confirmations", 1, "确认深度"),
+		timeout:       fs.Duration("timeout", 5*time.Minute, "整个命令的超时时间"),
+	}
+}
+
+// open 连接 RPC 并加载签名账户；返回的 cleanup 负责关闭连接与取消 ctx
+func (f *txFlags) open() (context.Context, *session, func(), error) {
+	resolvedRPC := firstNonEmpty(*f.rpcURL, os.Getenv("RPC_URL"))
+	if resolvedRPC == "" {
+		return nil, nil, nil, fmt.Errorf("缺少必要参数：需提供 RPC_URL（命令行参数或环境变量）")
+	}
+	txSigner, err := f.signer.Load()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *f.timeout)
+	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, fmt.Errorf("连接 RPC 失败: %w", err)
+	}
+	s, err := newSession(client, txSigner, big.NewInt(*f.chainID), txmgr.NewFileStore(*f.storePath), txmgr.Config{Confirmations: *f.confirmations})
+	if err != nil {
+		client.Close()
+		cancel()
+		return nil, nil, nil, err
+	}
+	return ctx, s, func() {
+		client.Close()
+		cancel()
+	}, nil
+}
+
+func runMint(args []string) error {
+	fs := flag.NewFlagSet("mint", flag.ContinueOnError)
+	tf := registerTxFlags(fs)
+	nftHex := fs.String("nft", "", "MyNFT 合约地址")
+	toHex := fs.String("to", "", "接收地址，默认为签名账户")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	nftAddr, err := requireAddress("NFT_ADDRESS", firstNonEmpty(*nftHex, os.Getenv("NFT_ADDRESS")))
+	if err != nil {
+		return err
+	}
+
+	ctx, s, cleanup, err := tf.open()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	to := s.From()
+	if *toHex != "" {
+		if to, err = requireAddress("-to", *toHex); err != nil {
+			return err
+		}
+	}
+	tokenID, err := mintNFT(ctx, s, nftAddr, to)
+	if err != nil {
+		return err
+	}
+	fmt.Println("=== 铸造成功 ===")
+	fmt.Printf("NFT 合约: %s\n", nftAddr.Hex())
+	fmt.Printf("接收地址: %s\n", to.Hex())
+	fmt.Printf("tokenId: %s\n", tokenID)
+	return nil
+}
+
+func runCreateAuction(args []string) error {
+	fs := flag.NewFlagSet("create-auction", flag.ContinueOnError)
+	tf := registerTxFlags(fs)
+	auctionHex := fs.String("auction", "", "拍卖合约（代理）地址")
+	nftHex := fs.String("nft", "", "MyNFT 合约地址")
+	tokenIDStr := fs.String("token-id", "", "要拍卖的 NFT tokenId")
+	duration := fs.Duration("duration", 24*time.Hour, "拍卖时长，从最新区块时间算起")
+	endUnix := fs.Int64("end", 0, "结束时间 unix 秒，设置后忽略 -duration")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	auctionAddr, err := requireAddress("AUCTION_ADDRESS", firstNonEmpty(*auctionHex, os.Getenv("AUCTION_ADDRESS")))
+	if err != nil {
+		return err
+	}
+	nftAddr, err := requireAddress("NFT_ADDRESS", firstNonEmpty(*nftHex, os.Getenv("NFT_ADDRESS")))
+	if err != nil {
+		return err
+	}
+	tokenID, err := parseBigInt("-token-id", *tokenIDStr)
+	if err != nil {
+		return err
+	}
+
+	ctx, s, cleanup, err := tf.open()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	endTime := big.NewInt(*endUnix)
+	if *endUnix == 0 {
+		// 以链上时间为准，避免本地时钟与出块时间不一致导致 Invalid end time
+		head, err := s.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("获取最新区块头失败: %w", err)
+		}
+		endTime.SetUint64(head.Time + uint64(duration.Seconds()))
+	}
+	ev, err := createAuction(ctx, s, auctionAddr, nftAddr, tokenID, endTime)
+	if err != nil {
+		return err
+	}
+	fmt.Println("=== 拍卖已创建 ===")
+	fmt.Printf("拍卖 ID: %s\n", ev.AuctionId)
+	fmt.Printf("NFT: %s #%s\n", ev.NftAddress.Hex(), ev.TokenId)
+	fmt.Printf("结束时间: %s\n", time.Unix(ev.EndTime.Int64(), 0).UTC().Format(time.RFC3339))
+	return nil
+}
+
+func runBid(args []string) error {
+	fs := flag.NewFlagSet("bid", flag.ContinueOnError)
+	tf := registerTxFlags(fs)
+	auctionHex := fs.String("auction", "", "拍卖合约（代理）地址")
+	idStr := fs.String("id", "", "拍卖 ID")
+	amountStr := fs.String("amount", "", "出价金额，按代币精度书写")
+	tokenHex := fs.String("token", "", "ERC-20 出价代币地址，不填则用 ETH 出价")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	auctionAddr, err := requireAddress("AUCTION_ADDRESS", firstNonEmpty(*auctionHex, os.Getenv("AUCTION_ADDRESS")))
+	if err != nil {
+		return err
+	}
+	auctionID, err := parseBigInt("-id", *idStr)
+	if err != nil {
+		return err
+	}
+	resolvedToken := firstNonEmpty(*tokenHex, os.Getenv("BID_TOKEN_ADDRESS"))
+
+	ctx, s, cleanup, err := tf.open()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	var ev *auction.AuctionBidPlaced
+	decimals := uint8(18)
+	symbol := "ETH"
+	if resolvedToken == "" {
+		amount, err := parseBidAmount(*amountStr, decimals)
+		if err != nil {
+			return err
+		}
+		ev, err = bidWithETH(ctx, s, auctionAddr, auctionID, amount)
+		if err != nil {
+			return err
+		}
+	} else {
+		tokenAddr, err := requireAddress("BID_TOKEN_ADDRESS", resolvedToken)
+		if err != nil {
+			return err
+		}
+		token, err := mytoken.NewMyTokenCaller(tokenAddr, s.backend)
+		if err != nil {
+			return fmt.Errorf("创建代币合约实例失败: %w", err)
+		}
+		opts := &bind.CallOpts{Context: ctx}
+		if decimals, err = token.Decimals(opts); err != nil {
+			return fmt.Errorf("读取代币精度失败: %w", err)
+		}
+		if symbol, err = token.Symbol(opts); err != nil {
+			return fmt.Errorf("读取代币符号失败: %w", err)
+		}
+		amount, err := parseBidAmount(*amountStr, decimals)
+		if err != nil {
+			return err
+		}
+		ev, err = bidWithERC20(ctx, s, auctionAddr, auctionID, tokenAddr, amount)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Println("=== 出价成功 ===")
+	fmt.Printf("拍卖 ID: %s\n", ev.AuctionId)
+	fmt.Printf("出价人: %s\n", ev.Bidder.Hex())
+	fmt.Printf("金额: %s %s\n", units.Format(ev.Amount, decimals), symbol)
+	fmt.Printf("折合 USD: %s\n", units.Format(ev.UsdValue, 18))
+	return nil
+}
+
+func runEnd(args []string) error {
+	fs := flag.NewFlagSet("end", flag.ContinueOnError)
+	tf := registerTxFlags(fs)
+	auctionHex := fs.String("auction", "", "拍卖合约（代理）地址")
+	idStr := fs.String("id", "", "拍卖 ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	auctionAddr, err := requireAddress("AUCTION_ADDRESS", firstNonEmpty(*auctionHex, os.Getenv("AUCTION_ADDRESS")))
+	if err != nil {
+		return err
+	}
+	auctionID, err := parseBigInt("-id", *idStr)
+	if err != nil {
+		return err
+	}
+
+	ctx, s, cleanup, err := tf.open()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ev, err := endAuction(ctx, s, auctionAddr, auctionID)
+	if err != nil {
+		return err
+	}
+	fmt.Println("=== 拍卖已结束 ===")
+	fmt.Printf("拍卖 ID: %s\n", ev.AuctionId)
+	fmt.Printf("卖家: %s\n", ev.Seller.Hex())
+	fmt.Printf("成交人: %s\n", ev.Winner.Hex())
+	fmt.Printf("成交金额: %s（代币 %s，0x0 为 ETH）\n", ev.Amount, ev.BidToken.Hex())
+	return nil
+}
+
+func runWithdraw(args []string) error {
+	fs := flag.NewFlagSet("withdraw", flag.ContinueOnError)
+	tf := registerTxFlags(fs)
+	auctionHex := fs.String("auction", "", "拍卖合约（代理）地址")
+	tokensStr := fs.String("tokens", "", "同时提取这些 ERC-20 的退款，逗号分隔；默认读取 BID_TOKEN_ADDRESS")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	auctionAddr, err := requireAddress("AUCTION_ADDRESS", firstNonEmpty(*auctionHex, os.Getenv("AUCTION_ADDRESS")))
+	if err != nil {
+		return err
+	}
+	var tokens []common.Address
+	for _, item := range strings.Split(firstNonEmpty(*tokensStr, os.Getenv("BID_TOKEN_ADDRESS")), ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		addr, err := requireAddress("-tokens", item)
+		if err != nil {
+			return err
+		}
+		tokens = append(tokens, addr)
+	}
+
+	ctx, s, cleanup, err := tf.open()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	withdrawn, err := withdrawRefunds(ctx, s, auctionAddr, tokens)
+	for _, ev := range withdrawn {
+		fmt.Printf("已提取退款: 代币 %s（0x0 为 ETH），数量 %s\n", ev.RefundToken.Hex(), ev.Amount)
+	}
+	if err != nil {
+		return err
+	}
+	if len(withdrawn) == 0 {
+		fmt.Println("没有待提取的退款")
+	}
+	return nil
+}
+
+func runPrice(args []string) error {
+	fs := flag.NewFlagSet("price", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "RPC URL")
+	auctionHex := fs.String("auction", "", "拍卖合约（代理）地址")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	auctionAddr, err := requireAddress("AUCTION_ADDRESS", firstNonEmpty(*auctionHex, os.Getenv("AUCTION_ADDRESS")))
+	if err != nil {
+		return err
+	}
+	ctx, client, cleanup, err := dialReadOnly(*rpcURL)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	price, decimals, err := latestPrice(ctx, client, auctionAddr)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("ETH/USD: %s（原始值 %s，精度 %d）\n", units.Format(price, decimals), price, decimals)
+	return nil
+}
+
+func runShow(args []string) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	rpcURL := fs.String("rpc", "", "RPC URL")
+	auctionHex := fs.String("auction", "", "拍卖合约（代理）地址")
+	idStr := fs.String("id", "", "拍卖 ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	auctionAddr, err := requireAddress("AUCTION_ADDRESS", firstNonEmpty(*auctionHex, os.Getenv("AUCTION_ADDRESS")))
+	if err != nil {
+		return err
+	}
+	auctionID, err := parseBigInt("-id", *idStr)
+	if err != nil {
+		return err
+	}
+	ctx, client, cleanup, err := dialReadOnly(*rpcURL)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	market, err := auction.NewAuctionCaller(auctionAddr, client)
+	if err != nil {
+		return fmt.Errorf("创建拍卖合约实例失败: %w", err)
+	}
+	info, err := market.Auctions(&bind.CallOpts{Context: ctx}, auctionID)
+	if err != nil {
+		return fmt.Errorf("读取拍卖 #%s 失败: %w", auctionID, err)
+	}
+	if !info.Exists {
+		return fmt.Errorf("拍卖 #%s 不存在", auctionID)
+	}
+	fmt.Printf("拍卖 ID: %s\n", auctionID)
+	fmt.Printf("卖家: %s\n", info.Seller.Hex())
+	fmt.Printf("NFT: %s #%s\n", info.NftAddress.Hex(), info.TokenId)
+	fmt.Printf("结束时间: %s\n", time.Unix(info.EndTime.Int64(), 0).UTC().Format(time.RFC3339))
+	fmt.Printf("最高出价: %s（代币 %s，0x0 为 ETH）\n", info.HighestBid, info.BidToken.Hex())
+	fmt.Printf("最高出价 USD: %s\n", units.Format(info.HighestBidUsd, 18))
+	fmt.Printf("最高出价人: %s\n", info.HighestBidder.Hex())
+	fmt.Printf("已结束: %t\n", info.Ended)
+	return nil
+}
+
+func dialReadOnly(rpcURL string) (context.Context, *ethclient.Client, func(), error) {
+	resolvedRPC := firstNonEmpty(rpcURL, os.Getenv("RPC_URL"))
+	if resolvedRPC == "" {
+		return nil, nil, nil, fmt.Errorf("缺少必要参数：需提供 RPC_URL（命令行参数或环境变量）")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	client, err := ethclient.DialContext(ctx, resolvedRPC)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, fmt.Errorf("连接 RPC 失败: %w", err)
+	}
+	return ctx, client, func() {
+		client.Close()
+		cancel()
+	}, nil
+}
+
+func requireAddress(name, value string) (common.Address, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return common.Address{}, fmt.Errorf("缺少必要参数：%s", name)
+	}
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("%s 地址不合法: %s", name, value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+func parseBigInt(name, value string) (*big.Int, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("缺少必要参数：%s", name)
+	}
+	n, ok := new(big.Int).SetString(strings.TrimSpace(value), 10)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("%s 不是合法的非负整数: %s", name, value)
+	}
+	return n, nil
+}
+
+// parseBidAmount 按代币精度换算出价金额；合约拒绝 0 出价，这里提前报错
+func parseBidAmount(amount string, decimals uint8) (*big.Int, error) {
+	n, err := units.Parse(amount, decimals)
+	if err != nil {
+		return nil, err
+	}
+	if n.Sign() == 0 {
+		return nil, fmt.Errorf("出价金额必须大于 0")
+	}
+	return n, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+$ErrorActionPreference = "Stop"
+
+$goRoot = Split-Path -Parent $PSScriptRoot
+$projectRoot = Split-Path -Parent $goRoot
+Set-Location $projectRoot
+
+if (!(Get-Command npx -ErrorAction SilentlyContinue)) {
+    throw "npx is required to compile the contracts with Hardhat."
+}
+npx hardhat compile
+
+$gopath = go env GOPATH
+$abigenPath = Join-Path $gopath "bin\abigen.exe"
+if (!(Test-Path $abigenPath)) {
+    go install github.com/ethereum/go-ethereum/cmd/abigen@v1.14.12
+}
+
+# Bindings embed the creation bytecode (--bin) so the Go tests can deploy the contracts
+# on a simulated backend without a local artifacts/ directory.
+$contracts = @(
+    @{ Artifact = "contracts\AuctionUpgradeable.sol\AuctionUpgradeable.json"; Name = "AuctionUpgradeable"; Pkg = "auction"; Type = "Auction" },
+    @{ Artifact = "contracts\MyNFT.sol\MyNFT.json"; Name = "MyNFT"; Pkg = "mynft"; Type = "MyNFT" },
+    @{ Artifact = "contracts\MyToken.sol\MyToken.json"; Name = "MyToken"; Pkg = "mytoken"; Type = "MyToken" },
+    @{ Artifact = "contracts\mocks\MockV3Aggregator.sol\MockV3Aggregator.json"; Name = "MockV3Aggregator"; Pkg = "mockfeed"; Type = "MockV3Aggregator" }
+)
+
+$abiDir = Join-Path $goRoot "abi"
+if (!(Test-Path $abiDir)) {
+    New-Item -Path $abiDir -ItemType Directory | Out-Null
+}
+
+foreach ($c in $contracts) {
+    $artifactPath = Join-Path $projectRoot (Join-Path "artifacts" $c.Artifact)
+    $abiPath = Join-Path $abiDir ($c.Name + ".abi")
+    $binPath = Join-Path $abiDir ($c.Name + ".bin")
+    $artifact = Get-Content $artifactPath -Raw | ConvertFrom-Json
+    $artifact.abi | ConvertTo-Json -Depth 32 -Compress | Set-Content -Path $abiPath -Encoding utf8
+    $artifact.bytecode | Set-Content -Path $binPath -Encoding ascii -NoNewline
+
+    $bindingsDir = Join-Path $goRoot (Join-Path "bindings" $c.Pkg)
+    if (!(Test-Path $bindingsDir)) {
+        New-Item -Path $bindingsDir -ItemType Directory | Out-Null
+    }
+    $outPath = Join-Path $bindingsDir ($c.Pkg + ".go")
+    & $abigenPath --abi $abiPath --bin $binPath --pkg $c.Pkg --type $c.Type --out $outPath
+    Write-Host "Go binding generated at: $outPath"
+}
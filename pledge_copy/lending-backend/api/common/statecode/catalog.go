@@ -0,0 +1,69 @@
+package statecode
+
+import "net/http"
+
+type entry struct {
+	status int
+	msg    map[int]string
+}
+
+// catalog 错误码目录：HTTP 状态码与各语言文案，新增错误码需同时补齐中英文
+var catalog = map[int]entry{
+	CommonSuccess:      {http.StatusOK, map[int]string{LangEn: "success", LangZh: "成功"}},
+	CommonErrServerErr: {http.StatusInternalServerError, map[int]string{LangEn: "server error", LangZh: "服务器错误"}},
+	ParameterEmptyErr:  {http.StatusBadRequest, map[int]string{LangEn: "parameter empty", LangZh: "参数为空"}},
+	ChainIdEmpty:       {http.StatusBadRequest, map[int]string{LangEn: "chain id empty", LangZh: "链 ID 为空"}},
+	ChainIdErr:         {http.StatusBadRequest, map[int]string{LangEn: "chain id error", LangZh: "链 ID 错误"}},
+	ParameterErr:       {http.StatusBadRequest, map[int]string{LangEn: "parameter error", LangZh: "参数错误"}},
+	AddressErr:         {http.StatusBadRequest, map[int]string{LangEn: "address error", LangZh: "地址错误"}},
+	AuctionNotExist:    {http.StatusNotFound, map[int]string{LangEn: "auction not exist", LangZh: "拍卖不存在"}},
+	TokenListEmpty:     {http.StatusNotFound, map[int]string{LangEn: "token list empty", LangZh: "代币列表为空"}},
+	Unauthorized:       {http.StatusUnauthorized, map[int]string{LangEn: "unauthorized", LangZh: "未授权"}},
+	TokenNotExist:      {http.StatusNotFound, map[int]string{LangEn: "token not exist", LangZh: "代币不存在"}},
+	LogoTooLarge:       {http.StatusRequestEntityTooLarge, map[int]string{LangEn: "logo too large", LangZh: "logo 文件过大"}},
+	LogoInvalid:        {http.StatusUnsupportedMediaType, map[int]string{LangEn: "logo invalid", LangZh: "logo 格式无效"}},
+	RouteNotFound:      {http.StatusNotFound, map[int]string{LangEn: "route not found", LangZh: "接口不存在"}},
+	Forbidden:          {http.StatusForbidden, map[int]string{LangEn: "forbidden", LangZh: "禁止访问"}},
+	JobNotExist:        {http.StatusNotFound, map[int]string{LangEn: "job not exist", LangZh: "任务不存在"}},
+	SchedulerOffline:   {http.StatusServiceUnavailable, map[int]string{LangEn: "scheduler offline", LangZh: "定时任务进程未运行"}},
+	TooManyRequests:    {http.StatusTooManyRequests, map[int]string{LangEn: "too many requests", LangZh: "请求过于频繁"}},
+	ApiKeyInvalid:      {http.StatusUnauthorized, map[int]string{LangEn: "api key invalid", LangZh: "API key 无效"}},
+}
+
+// 字段校验失败的原因
+const (
+	ReasonRequired    = "required"
+	ReasonInvalid     = "invalid"
+	ReasonUnsupported = "unsupported"
+	ReasonTooLarge    = "too_large"
+	ReasonOutOfRange  = "out_of_range"
+)
+
+type bundle struct {
+	unknown string
+	// reasons 字段错误文案，%s 为字段名
+	reasons map[string]string
+}
+
+var bundles = map[int]bundle{
+	LangEn: {
+		unknown: "unknown",
+		reasons: map[string]string{
+			ReasonRequired:    "%s is required",
+			ReasonInvalid:     "%s is invalid",
+			ReasonUnsupported: "%s is not supported",
+			ReasonTooLarge:    "%s is too large",
+			ReasonOutOfRange:  "%s is out of range",
+		},
+	},
+	LangZh: {
+		unknown: "未知错误",
+		reasons: map[string]string{
+			ReasonRequired:    "%s 不能为空",
+			ReasonInvalid:     "%s 格式错误",
+			ReasonUnsupported: "%s 不支持",
+			ReasonTooLarge:    "%s 过大",
+			ReasonOutOfRange:  "%s 超出范围",
+		},
+	},
+}
@@ -0,0 +1,76 @@
+package statecode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FieldError 单个请求字段的校验错误；Message 在响应时按语言填充
+type FieldError struct {
+	Field   string `json:"field"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+func Field(field, reason string) FieldError {
+	return FieldError{Field: field, Reason: reason}
+}
+
+// Error 带错误码的业务错误，cause 只用于日志，不返回给调用方
+type Error struct {
+	Code   int
+	Fields []FieldError
+	cause  error
+}
+
+func New(code int, fields ...FieldError) *Error {
+	return &Error{Code: code, Fields: fields}
+}
+
+func Wrap(code int, err error) *Error {
+	return &Error{Code: code, cause: err}
+}
+
+func (e *Error) Error() string {
+	msg := GetMsg(e.Code, LangEn)
+	if e.cause != nil {
+		return msg + ": " + e.cause.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+func (e *Error) Status() int {
+	return HTTPStatus(e.Code)
+}
+
+// Localize 返回填好文案的字段错误
+func (e *Error) Localize(lang int) []FieldError {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	b, ok := bundles[lang]
+	if !ok {
+		b = bundles[LangEn]
+	}
+	fields := make([]FieldError, len(e.Fields))
+	for i, f := range e.Fields {
+		fields[i] = f
+		if format, ok := b.reasons[f.Reason]; ok {
+			fields[i].Message = fmt.Sprintf(format, f.Field)
+		}
+	}
+	return fields
+}
+
+// FromError 非 *Error 的错误一律视为服务器错误
+func FromError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return Wrap(CommonErrServerErr, err)
+}
@@ -0,0 +1,58 @@
+package statecode
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	LangEn = 1
+	LangZh = 2
+)
+
+// ParseLang 按 Accept-Language 的 q 值选出第一个支持的语言，都不支持时为英文
+func ParseLang(acceptLanguage string) int {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	for _, c := range candidates {
+		switch {
+		case c.tag == "zh" || strings.HasPrefix(c.tag, "zh-"):
+			return LangZh
+		case c.tag == "en" || strings.HasPrefix(c.tag, "en-"), c.tag == "*":
+			return LangEn
+		}
+	}
+	return LangEn
+}
+
+// LangTag 用于 Content-Language 响应头
+func LangTag(lang int) string {
+	if lang == LangZh {
+		return "zh-CN"
+	}
+	return "en"
+}
@@ -0,0 +1,71 @@
+package statecode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCatalogComplete(t *testing.T) {
+	for code, e := range catalog {
+		if e.status < 200 || e.status > 599 {
+			t.Errorf("code %d: bad http status %d", code, e.status)
+		}
+		for lang := range bundles {
+			if e.msg[lang] == "" {
+				t.Errorf("code %d: missing message for lang %d", code, lang)
+			}
+		}
+	}
+	for lang, b := range bundles {
+		for reason := range bundles[LangEn].reasons {
+			if b.reasons[reason] == "" {
+				t.Errorf("lang %d: missing reason %s", lang, reason)
+			}
+		}
+	}
+}
+
+func TestParseLang(t *testing.T) {
+	cases := map[string]int{
+		"":                           LangEn,
+		"zh-CN,zh;q=0.9,en;q=0.8":    LangZh,
+		"en-US,en;q=0.9,zh-CN;q=0.8": LangEn,
+		"fr-FR, zh-TW;q=0.5":         LangZh,
+		"zh;q=0.2, en;q=0.7":         LangEn,
+		"zh;q=0, ja":                 LangEn,
+		"ZH-hans":                    LangZh,
+		"*":                          LangEn,
+	}
+	for header, want := range cases {
+		if got := ParseLang(header); got != want {
+			t.Errorf("ParseLang(%q) = %d, want %d", header, got, want)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	err := New(ChainIdErr, Field("chain_id", ReasonUnsupported))
+	if err.Status() != 400 {
+		t.Fatalf("status = %d", err.Status())
+	}
+	if got := err.Localize(LangZh)[0].Message; got != "chain_id 不支持" {
+		t.Fatalf("zh message = %q", got)
+	}
+	if got := err.Localize(LangEn)[0].Message; got != "chain_id is not supported" {
+		t.Fatalf("en message = %q", got)
+	}
+
+	cause := errors.New("db down")
+	wrapped := fmt.Errorf("query: %w", Wrap(TokenNotExist, cause))
+	e := FromError(wrapped)
+	if e.Code != TokenNotExist || !errors.Is(e, cause) || e.Status() != 404 {
+		t.Fatalf("FromError = %+v", e)
+	}
+	if e := FromError(cause); e.Code != CommonErrServerErr || e.Status() != 500 {
+		t.Fatalf("FromError(plain) = %+v", e)
+	}
+	if GetMsg(99999, LangZh) != "未知错误" || HTTPStatus(99999) != 500 {
+		t.Fatal("unknown code fallback")
+	}
+}
@@ -1,28 +1,43 @@
 package statecode
 
 const (
-	CommonSuccess       = 0
-	CommonErrServerErr  = 10001
-	ParameterEmptyErr   = 10002
-	ChainIdEmpty        = 10003
-	ChainIdErr          = 10004
+	CommonSuccess      = 0
+	CommonErrServerErr = 10001
+	ParameterEmptyErr  = 10002
+	ChainIdEmpty       = 10003
+	ChainIdErr         = 10004
+	ParameterErr       = 10005
+	AddressErr         = 10006
+	AuctionNotExist    = 10007
+	TokenListEmpty     = 10008
+	Unauthorized       = 10009
+	TokenNotExist      = 10010
+	LogoTooLarge       = 10011
+	LogoInvalid        = 10012
+	RouteNotFound      = 10013
+	Forbidden          = 10014
+	JobNotExist        = 10015
+	SchedulerOffline   = 10016
+	TooManyRequests    = 10017
+	ApiKeyInvalid      = 10018
 )
 
-const LangEn = 1
-
+// GetMsg 按语言返回错误码文案，缺少对应语言时退回英文
 func GetMsg(code int, lang int) string {
-	switch code {
-	case CommonSuccess:
-		return "success"
-	case CommonErrServerErr:
-		return "server error"
-	case ParameterEmptyErr:
-		return "parameter empty"
-	case ChainIdEmpty:
-		return "chain id empty"
-	case ChainIdErr:
-		return "chain id error"
-	default:
-		return "unknown"
+	e, ok := catalog[code]
+	if !ok {
+		return bundles[lang].unknown
+	}
+	if msg, ok := e.msg[lang]; ok {
+		return msg
+	}
+	return e.msg[LangEn]
+}
+
+// HTTPStatus 错误码对应的 HTTP 状态码，未登记的错误码按 500 处理
+func HTTPStatus(code int) int {
+	if e, ok := catalog[code]; ok {
+		return e.status
 	}
+	return 500
 }
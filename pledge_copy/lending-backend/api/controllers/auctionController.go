@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models/request"
+	"lending-copy/api/models/response"
+	"lending-copy/api/services"
+	"lending-copy/api/validate"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuctionController struct{}
+
+func (c *AuctionController) Auctions(ctx *gin.Context) {
+	res := response.Gin{Res: ctx}
+	req := request.AuctionList{}
+	if err := validate.NewAuction().AuctionList(ctx, &req); err != nil {
+		res.Error(ctx, err)
+		return
+	}
+	errCode, result := services.NewAuction().AuctionList(ctx.Request.Context(), &req)
+	if errCode != statecode.CommonSuccess {
+		res.Response(ctx, errCode, nil)
+		return
+	}
+	res.Response(ctx, statecode.CommonSuccess, result)
+}
+
+func (c *AuctionController) AuctionBids(ctx *gin.Context) {
+	res := response.Gin{Res: ctx}
+	req := request.AuctionBids{}
+	if err := validate.NewAuction().AuctionBids(ctx, &req); err != nil {
+		res.Error(ctx, err)
+		return
+	}
+	errCode, result := services.NewAuction().AuctionBids(ctx.Request.Context(), &req)
+	if errCode != statecode.CommonSuccess {
+		res.Response(ctx, errCode, nil)
+		return
+	}
+	res.Response(ctx, statecode.CommonSuccess, result)
+}
+
+func (c *AuctionController) UserRefunds(ctx *gin.Context) {
+	res := response.Gin{Res: ctx}
+	req := request.UserRefunds{}
+	if err := validate.NewAuction().UserRefunds(ctx, &req); err != nil {
+		res.Error(ctx, err)
+		return
+	}
+	errCode, result := services.NewAuction().UserRefunds(ctx.Request.Context(), &req)
+	if errCode != statecode.CommonSuccess {
+		res.Response(ctx, errCode, nil)
+		return
+	}
+	res.Response(ctx, statecode.CommonSuccess, result)
+}
@@ -0,0 +1,22 @@
+package controllers
+
+import (
+	"net/http"
+
+	"lending-copy/api/openapi"
+	"lending-copy/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DocsController OpenAPI 文档与文档页面
+type DocsController struct{}
+
+func (c *DocsController) Spec(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", openapi.JSON(config.Config.Env.Version))
+}
+
+// Docs 页面与 openapi.json 同在 /api/v<version> 下，按相对地址加载文档
+func (c *DocsController) Docs(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", openapi.DocsPage("openapi.json"))
+}
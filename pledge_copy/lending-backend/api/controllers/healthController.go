@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"net/http"
+
+	"lending-copy/api/models/response"
+	"lending-copy/api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const healthServiceName = "lending-backend"
+
+// HealthController 探活接口不走统一响应结构，直接按 HTTP 状态码判断
+type HealthController struct{}
+
+// Healthz 存活检查：进程能处理请求即返回 200
+func (c *HealthController) Healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, response.Health{Status: services.HealthOK, Service: healthServiceName})
+}
+
+// Readyz 就绪检查：MySQL、Redis、RPC 任一不可用返回 503
+func (c *HealthController) Readyz(ctx *gin.Context) {
+	ready, checks := services.NewHealth().Ready(ctx.Request.Context())
+	if !ready {
+		ctx.JSON(http.StatusServiceUnavailable, response.Health{Status: services.HealthUnavailable, Service: healthServiceName, Checks: checks})
+		return
+	}
+	ctx.JSON(http.StatusOK, response.Health{Status: services.HealthOK, Service: healthServiceName, Checks: checks})
+}
+
+// Status 同步进度与数据新鲜度，超过 health.max_sync_age 未成功同步时返回 503
+func (c *HealthController) Status(ctx *gin.Context) {
+	status := services.NewHealth().Status(ctx.Request.Context())
+	if status.Status != services.HealthOK {
+		ctx.JSON(http.StatusServiceUnavailable, status)
+		return
+	}
+	ctx.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"net/http"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models/request"
+	"lending-copy/api/models/response"
+	"lending-copy/api/services"
+	"lending-copy/api/validate"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobController 管理接口：查看定时任务与执行记录，手动触发一次执行
+type JobController struct{}
+
+func (c *JobController) Jobs(ctx *gin.Context) {
+	res := response.Gin{Res: ctx}
+	errCode, result := services.NewJob().List(ctx.Request.Context())
+	if errCode != statecode.CommonSuccess {
+		res.Response(ctx, errCode, nil)
+		return
+	}
+	res.Response(ctx, statecode.CommonSuccess, result)
+}
+
+func (c *JobController) JobRuns(ctx *gin.Context) {
+	res := response.Gin{Res: ctx}
+	req := request.JobRuns{}
+	if err := validate.NewJob().JobRuns(ctx, &req); err != nil {
+		res.Error(ctx, err)
+		return
+	}
+	errCode, result := services.NewJob().Runs(ctx.Request.Context(), &req)
+	if errCode != statecode.CommonSuccess {
+		res.Response(ctx, errCode, nil)
+		return
+	}
+	res.Response(ctx, statecode.CommonSuccess, result)
+}
+
+// RunJob 触发是异步的，返回 202，执行结果在执行记录中查看
+func (c *JobController) RunJob(ctx *gin.Context) {
+	res := response.Gin{Res: ctx}
+	req := request.JobTrigger{}
+	if err := validate.NewJob().JobTrigger(ctx, &req); err != nil {
+		res.Error(ctx, err)
+		return
+	}
+	errCode, result := services.NewJob().Trigger(ctx.Request.Context(), &req)
+	if errCode != statecode.CommonSuccess {
+		res.Response(ctx, errCode, nil)
+		return
+	}
+	res.Response(ctx, statecode.CommonSuccess, result, http.StatusAccepted)
+}
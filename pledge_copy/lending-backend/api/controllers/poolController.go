@@ -1,9 +1,9 @@
 package controllers
 
 import (
+	"net/http"
 	"regexp"
 	"strings"
-	"time"
 
 	"lending-copy/api/common/statecode"
 	"lending-copy/api/models"
@@ -22,12 +22,11 @@ func (c *PoolController) PoolBaseInfo(ctx *gin.Context) {
 	res := response.Gin{Res: ctx}
 	req := request.PoolBaseInfo{}
 	var result []models.PoolBaseInfoRes
-	errCode := validate.NewPoolBaseInfo().PoolBaseInfo(ctx, &req)
-	if errCode != statecode.CommonSuccess {
-		res.Response(ctx, errCode, nil)
+	if err := validate.NewPoolBaseInfo().PoolBaseInfo(ctx, &req); err != nil {
+		res.Error(ctx, err)
 		return
 	}
-	errCode = services.NewPool().PoolBaseInfo(req.ChainId, &result)
+	errCode := services.NewPool().PoolBaseInfo(ctx.Request.Context(), req.ChainId, &result)
 	if errCode != statecode.CommonSuccess {
 		res.Response(ctx, errCode, nil)
 		return
@@ -39,12 +38,11 @@ func (c *PoolController) PoolDataInfo(ctx *gin.Context) {
 	res := response.Gin{Res: ctx}
 	req := request.PoolDataInfo{}
 	var result []models.PoolDataInfoRes
-	errCode := validate.NewPoolDataInfo().PoolDataInfo(ctx, &req)
-	if errCode != statecode.CommonSuccess {
-		res.Response(ctx, errCode, nil)
+	if err := validate.NewPoolDataInfo().PoolDataInfo(ctx, &req); err != nil {
+		res.Error(ctx, err)
 		return
 	}
-	errCode = services.NewPool().PoolDataInfo(req.ChainId, &result)
+	errCode := services.NewPool().PoolDataInfo(ctx.Request.Context(), req.ChainId, &result)
 	if errCode != statecode.CommonSuccess {
 		res.Response(ctx, errCode, nil)
 		return
@@ -53,46 +51,63 @@ func (c *PoolController) PoolDataInfo(ctx *gin.Context) {
 }
 
 func (c *PoolController) TokenList(ctx *gin.Context) {
+	res := response.Gin{Res: ctx}
 	req := request.TokenList{}
-	result := response.TokenList{}
-	errCode := validate.NewTokenList().TokenList(ctx, &req)
+	if err := validate.NewTokenList().TokenList(ctx, &req); err != nil {
+		res.Error(ctx, err)
+		return
+	}
+	// 成功时按 token-list 规范直接返回列表本身，错误仍使用统一响应结构
+	errCode, list := services.NewTokenList().GetTokenList(ctx.Request.Context(), &req, c.GetBaseURL())
 	if errCode != statecode.CommonSuccess {
-		ctx.JSON(200, map[string]string{"error": "chainId error"})
+		res.Response(ctx, errCode, nil)
 		return
 	}
-	errCode, data := services.NewTokenList().GetTokenList(&req)
+	ctx.Header("ETag", list.ETag)
+	ctx.Header("Cache-Control", "public, max-age=60")
+	if etagMatch(ctx.GetHeader("If-None-Match"), list.ETag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", list.Body)
+}
+
+// UploadTokenLogo 管理接口：上传代币 logo，统一为 PNG 后写回 token_info.logo
+func (c *PoolController) UploadTokenLogo(ctx *gin.Context) {
+	res := response.Gin{Res: ctx}
+	req := request.TokenLogo{}
+	if err := validate.NewTokenList().TokenLogo(ctx, &req); err != nil {
+		res.Error(ctx, err)
+		return
+	}
+	errCode, result := services.NewTokenLogo().Upload(ctx.Request.Context(), &req, c.GetBaseURL())
 	if errCode != statecode.CommonSuccess {
-		ctx.JSON(200, map[string]string{"error": "chainId error"})
+		res.Response(ctx, errCode, nil)
 		return
 	}
-	baseURL := c.GetBaseURL()
-	result.Name = "Lending Copy Token List"
-	result.LogoURI = baseURL + "storage/img/logo.png"
-	result.Timestamp = time.Now()
-	result.Version = response.Version{Major: 1, Minor: 0, Patch: 0}
-	for _, v := range data {
-		result.Tokens = append(result.Tokens, response.Token{
-			Name:     v.Symbol,
-			Symbol:   v.Symbol,
-			Decimals: v.Decimals,
-			Address:  v.Token,
-			ChainID:  v.ChainId,
-			LogoURI:  v.Logo,
-		})
+	res.Response(ctx, statecode.CommonSuccess, result)
+}
+
+// etagMatch 支持 If-None-Match 中的多个值、弱校验前缀 W/ 与 *
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, v := range strings.Split(ifNoneMatch, ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == "*" || v == etag {
+			return true
+		}
 	}
-	ctx.JSON(200, result)
+	return false
 }
 
 func (c *PoolController) Search(ctx *gin.Context) {
 	res := response.Gin{Res: ctx}
 	req := request.Search{}
 	result := response.Search{}
-	errCode := validate.NewSearch().Search(ctx, &req)
-	if errCode != statecode.CommonSuccess {
-		res.Response(ctx, errCode, nil)
+	if err := validate.NewSearch().Search(ctx, &req); err != nil {
+		res.Error(ctx, err)
 		return
 	}
-	errCode, count, pools := services.NewSearch().Search(&req)
+	errCode, count, pools := services.NewSearch().Search(ctx.Request.Context(), &req)
 	if errCode != statecode.CommonSuccess {
 		res.Response(ctx, errCode, nil)
 		return
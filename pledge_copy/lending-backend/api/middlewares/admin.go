@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models/response"
+	"lending-copy/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuth 校验 Authorization: Bearer <admin.token>；未配置 token 时管理接口关闭
+func AdminAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		res := response.Gin{Res: c}
+		token := config.Config.Admin.Token
+		if token == "" {
+			res.Response(c, statecode.Forbidden, nil)
+			c.Abort()
+			return
+		}
+		got := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			res.Response(c, statecode.Unauthorized, nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
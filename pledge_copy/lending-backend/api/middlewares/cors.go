@@ -2,21 +2,159 @@ package middlewares
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"lending-copy/config"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Cors 按 config.cors 输出跨域响应头；按请求路径选择最长前缀匹配的策略。
+// 来源在允许列表中时回显该来源（任意来源且不带凭据时为 "*"），不在列表中时不输出 CORS 头，预检请求返回 403
 func Cors() gin.HandlerFunc {
+	return cors(config.Config.Cors)
+}
+
+func cors(conf config.CorsConfig) gin.HandlerFunc {
+	policies := compileCors(conf.Policies())
 	return func(c *gin.Context) {
-		method := c.Request.Method
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Headers", "Content-Type,AccessToken,X-CSRF-Token, Authorization, Token")
-		c.Header("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
-		c.Header("Access-Control-Expose-Headers", "Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		if method == "OPTIONS" {
+		p := policies[len(policies)-1]
+		for _, candidate := range policies {
+			if candidate.path != "" && matchPath(c.Request.URL.Path, candidate.path) {
+				p = candidate
+				break
+			}
+		}
+		// 响应随 Origin 变化时必须带 Vary，否则缓存可能把一个来源的响应给另一个来源
+		if !p.anyOrigin || p.credentials {
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if !p.allow(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+		if p.anyOrigin && !p.credentials {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if p.credentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+		if preflight {
+			c.Header("Access-Control-Allow-Methods", p.methods)
+			c.Header("Access-Control-Allow-Headers", p.headers)
+			if p.maxAge != "" {
+				c.Header("Access-Control-Max-Age", p.maxAge)
+			}
 			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		if p.exposed != "" {
+			c.Header("Access-Control-Expose-Headers", p.exposed)
 		}
 		c.Next()
 	}
 }
+
+type corsPolicy struct {
+	path        string
+	anyOrigin   bool
+	origins     []originPattern
+	methods     string
+	headers     string
+	exposed     string
+	credentials bool
+	maxAge      string
+}
+
+// originPattern host 以 "*." 开头时匹配其任意子域名（不含主域名本身）
+type originPattern struct {
+	scheme   string
+	host     string
+	port     string
+	wildcard bool
+}
+
+func compileCors(policies []config.CorsPolicy) []corsPolicy {
+	compiled := make([]corsPolicy, 0, len(policies))
+	for _, p := range policies {
+		cp := corsPolicy{
+			path:        p.Path,
+			methods:     strings.Join(p.AllowedMethods, ", "),
+			headers:     strings.Join(p.AllowedHeaders, ", "),
+			exposed:     strings.Join(p.ExposedHeaders, ", "),
+			credentials: p.AllowCredentials,
+		}
+		if p.MaxAge > 0 {
+			cp.maxAge = strconv.Itoa(int(p.MaxAge.Seconds()))
+		}
+		for _, origin := range p.AllowedOrigins {
+			if origin == "*" {
+				cp.anyOrigin = true
+				continue
+			}
+			if pattern, ok := parseOrigin(origin); ok {
+				cp.origins = append(cp.origins, pattern)
+			}
+		}
+		compiled = append(compiled, cp)
+	}
+	return compiled
+}
+
+func parseOrigin(origin string) (originPattern, bool) {
+	pattern := originPattern{}
+	if i := strings.Index(origin, "://*."); i >= 0 {
+		pattern.wildcard = true
+		origin = origin[:i] + "://" + origin[i+len("://*."):]
+	}
+	u, err := url.Parse(strings.ToLower(origin))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return pattern, false
+	}
+	pattern.scheme, pattern.host, pattern.port = u.Scheme, u.Hostname(), u.Port()
+	return pattern, true
+}
+
+// matchPath 按路径段匹配前缀，/api/v1/admin 不匹配 /api/v1/administrator
+func matchPath(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	return len(path) == len(prefix) || strings.HasSuffix(prefix, "/") || path[len(prefix)] == '/'
+}
+
+func (p corsPolicy) allow(origin string) bool {
+	if p.anyOrigin {
+		return true
+	}
+	got, ok := parseOrigin(origin)
+	if !ok || got.wildcard {
+		return false
+	}
+	for _, want := range p.origins {
+		if got.scheme != want.scheme || got.port != want.port {
+			continue
+		}
+		if got.host == want.host && !want.wildcard {
+			return true
+		}
+		if want.wildcard && strings.HasSuffix(got.host, "."+want.host) {
+			return true
+		}
+	}
+	return false
+}
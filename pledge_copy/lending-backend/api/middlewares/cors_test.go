@@ -0,0 +1,106 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"lending-copy/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCors(t *testing.T) {
+	credentials := true
+	conf := config.CorsConfig{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.pledge.finance"},
+		AllowedMethods: []string{"GET", "POST", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type", "X-API-Key"},
+		ExposedHeaders: []string{"ETag"},
+		MaxAge:         config.Duration{Duration: 10 * time.Minute},
+		Routes: []config.CorsRoute{
+			{Path: "/api/v1/admin", AllowedOrigins: []string{"http://localhost:3000"}, AllowCredentials: &credentials, MaxAge: config.Duration{Duration: time.Minute}},
+			{Path: "/public", AllowedOrigins: []string{"*"}},
+		},
+	}
+	gin.SetMode(gin.TestMode)
+	app := gin.New()
+	app.Use(cors(conf))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	app.GET("/api/v1/pools", ok)
+	app.GET("/api/v1/admin/jobs", ok)
+	app.GET("/api/v1/administrator", ok)
+	app.GET("/public/token", ok)
+
+	do := func(method, path, origin string, preflight bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if preflight {
+			req.Header.Set("Access-Control-Request-Method", "POST")
+			req.Header.Set("Access-Control-Request-Headers", "content-type")
+		}
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("preflight", func(t *testing.T) {
+		w := do(http.MethodOptions, "/api/v1/pools", "https://app.example.com", true)
+		h := w.Header()
+		if w.Code != http.StatusNoContent || h.Get("Access-Control-Allow-Origin") != "https://app.example.com" ||
+			h.Get("Access-Control-Allow-Methods") != "GET, POST, OPTIONS" || h.Get("Access-Control-Allow-Headers") != "Content-Type, X-API-Key" ||
+			h.Get("Access-Control-Max-Age") != "600" || h.Get("Access-Control-Allow-Credentials") != "" || h.Get("Vary") != "Origin" {
+			t.Fatalf("preflight = %d %v", w.Code, h)
+		}
+		// 通配子域名：任意层级的子域名都匹配，主域名本身与其他协议不匹配
+		if w := do(http.MethodOptions, "/api/v1/pools", "https://a.b.pledge.finance", true); w.Code != http.StatusNoContent {
+			t.Fatalf("subdomain preflight = %d", w.Code)
+		}
+		for _, origin := range []string{"https://pledge.finance", "http://app.pledge.finance", "https://evilpledge.finance", "https://app.example.com:8443"} {
+			if w := do(http.MethodOptions, "/api/v1/pools", origin, true); w.Code != http.StatusForbidden || w.Header().Get("Access-Control-Allow-Origin") != "" {
+				t.Fatalf("%s preflight = %d %v", origin, w.Code, w.Header())
+			}
+		}
+		// 路由覆盖：来源、凭据与缓存时长
+		w = do(http.MethodOptions, "/api/v1/admin/jobs", "http://localhost:3000", true)
+		if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Credentials") != "true" || w.Header().Get("Access-Control-Max-Age") != "60" {
+			t.Fatalf("admin preflight = %d %v", w.Code, w.Header())
+		}
+		if w := do(http.MethodOptions, "/api/v1/admin/jobs", "https://app.example.com", true); w.Code != http.StatusForbidden {
+			t.Fatalf("admin preflight from public origin = %d", w.Code)
+		}
+		if w := do(http.MethodOptions, "/api/v1/administrator", "https://app.example.com", true); w.Code != http.StatusNoContent {
+			t.Fatalf("prefix matched across path segment: %d", w.Code)
+		}
+	})
+
+	t.Run("simple", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/v1/pools", "https://x.pledge.finance", false)
+		h := w.Header()
+		if w.Code != http.StatusOK || h.Get("Access-Control-Allow-Origin") != "https://x.pledge.finance" ||
+			h.Get("Access-Control-Expose-Headers") != "ETag" || h.Get("Vary") != "Origin" || h.Get("Access-Control-Allow-Methods") != "" {
+			t.Fatalf("simple = %d %v", w.Code, h)
+		}
+		// 不允许的来源照常处理请求，但不带 CORS 头，由浏览器拦截
+		w = do(http.MethodGet, "/api/v1/pools", "https://evil.com", false)
+		if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" || w.Header().Get("Vary") != "Origin" {
+			t.Fatalf("disallowed = %d %v", w.Code, w.Header())
+		}
+		w = do(http.MethodGet, "/api/v1/pools", "", false)
+		if w.Header().Get("Access-Control-Allow-Origin") != "" || w.Header().Get("Vary") != "Origin" {
+			t.Fatalf("same origin = %v", w.Header())
+		}
+		w = do(http.MethodGet, "/api/v1/admin/jobs", "http://localhost:3000", false)
+		if w.Header().Get("Access-Control-Allow-Origin") != "http://localhost:3000" || w.Header().Get("Access-Control-Allow-Credentials") != "true" {
+			t.Fatalf("admin = %v", w.Header())
+		}
+		// 任意来源且不带凭据时输出 "*"，响应不随来源变化
+		w = do(http.MethodGet, "/public/token", "https://anything.io", false)
+		if w.Header().Get("Access-Control-Allow-Origin") != "*" || w.Header().Get("Vary") != "" || w.Header().Get("Access-Control-Allow-Credentials") != "" {
+			t.Fatalf("public = %v", w.Header())
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package middlewares
+
+import (
+	"lending-copy/api/common/statecode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Lang 按 Accept-Language 选择响应文案语言（en / zh）
+func Lang() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := statecode.ParseLang(c.GetHeader("Accept-Language"))
+		c.Set("lang", lang)
+		c.Header("Content-Language", statecode.LangTag(lang))
+		// 追加而不是覆盖：Cors 在前面已经写入了 Vary: Origin
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
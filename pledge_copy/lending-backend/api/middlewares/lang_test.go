@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"lending-copy/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 与 main.go 相同的中间件顺序：Cors 写入的 Vary: Origin 不能被 Lang 覆盖
+func TestLangKeepsCorsVary(t *testing.T) {
+	prev := config.Config
+	defer func() { config.Config = prev }()
+	config.Config = &config.Conf{Cors: config.CorsConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET"},
+	}}
+
+	gin.SetMode(gin.TestMode)
+	app := gin.New()
+	app.Use(Cors())
+	app.Use(Lang())
+	app.GET("/api/v1/pools", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, origin := range []string{"https://app.example.com", ""} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/pools", nil)
+		req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		if got, want := w.Header().Values("Vary"), []string{"Origin", "Accept-Language"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("origin=%q Vary = %q, want %q", origin, got, want)
+		}
+		if got := w.Header().Get("Content-Language"); got != "zh-CN" {
+			t.Errorf("origin=%q Content-Language = %q", origin, got)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"time"
+
+	"lending-copy/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics 记录每个请求的次数与耗时，按路由模板聚合，未匹配的路由统一记为 "unmatched"，避免标签基数失控
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveHTTP(route, c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
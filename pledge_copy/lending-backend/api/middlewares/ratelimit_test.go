@@ -0,0 +1,93 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lending-copy/api/models"
+	"lending-copy/config"
+	"lending-copy/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimit(t *testing.T) {
+	prev := config.Config
+	defer func() { config.Config = prev }()
+	config.Config = &config.Conf{RateLimit: config.RateLimitConfig{Enabled: true, IpRate: 60, IpBurst: 2, KeyRate: 600, KeyBurst: 10, SearchCost: 2}}
+
+	lookup := func(ctx context.Context, raw string) (*models.ApiKey, error) {
+		switch raw {
+		case "good":
+			return &models.ApiKey{Id: 7, Burst: 3}, nil
+		case "broken":
+			return nil, errors.New("db down")
+		}
+		return nil, nil
+	}
+	gin.SetMode(gin.TestMode)
+	app := gin.New()
+	app.Use(rateLimit(ratelimit.NewMemory(), lookup, func(c *gin.Context) int {
+		if c.FullPath() == "/search" {
+			return config.Current().RateLimit.SearchCost
+		}
+		return 1
+	}))
+	app.GET("/pools", func(c *gin.Context) { c.Status(http.StatusOK) })
+	app.POST("/search", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	do := func(method, path, ip, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":1234"
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "/pools", "1.1.1.1", "")
+	if w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "2" || w.Header().Get("RateLimit-Remaining") != "1" ||
+		w.Header().Get("RateLimit-Reset") != "1" || w.Header().Get("RateLimit-Policy") != "2;w=2" {
+		t.Fatalf("first = %d %v", w.Code, w.Header())
+	}
+	// 搜索消耗 search_cost 个令牌，剩余不足
+	w = do(http.MethodPost, "/search", "1.1.1.1", "")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "1" {
+		t.Fatalf("search = %d %v", w.Code, w.Header())
+	}
+	if w := do(http.MethodGet, "/pools", "2.2.2.2", ""); w.Code != http.StatusOK {
+		t.Fatalf("other ip = %d", w.Code)
+	}
+
+	// key 有自己的桶：burst 取自 key，rate 取默认值
+	for i := 0; i < 3; i++ {
+		if w := do(http.MethodGet, "/pools", "1.1.1.1", "good"); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "3" {
+			t.Fatalf("key request %d = %d %v", i, w.Code, w.Header())
+		}
+	}
+	if w := do(http.MethodGet, "/pools", "3.3.3.3", "good"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("key over quota = %d", w.Code)
+	}
+
+	// 无效 key 返回 401 并计入 IP 配额
+	if w := do(http.MethodGet, "/pools", "4.4.4.4", "bad"); w.Code != http.StatusUnauthorized || w.Header().Get("RateLimit-Remaining") != "1" {
+		t.Fatalf("bad key = %d %v", w.Code, w.Header())
+	}
+	// 查库失败时按 IP 限流
+	if w := do(http.MethodGet, "/pools", "4.4.4.4", "broken"); w.Code != http.StatusOK || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("lookup error = %d %v", w.Code, w.Header())
+	}
+
+	config.Config.RateLimit.Enabled = false
+	if w := do(http.MethodPost, "/search", "1.1.1.1", ""); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Fatalf("disabled = %d %v", w.Code, w.Header())
+	}
+	if w := do(http.MethodGet, "/pools", "5.5.5.5", "bad"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("bad key with limiting disabled = %d", w.Code)
+	}
+}
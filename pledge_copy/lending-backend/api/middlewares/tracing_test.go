@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lending-copy/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	gin.SetMode(gin.TestMode)
+	app := gin.New()
+	app.Use(Tracing())
+	app.GET("/pools/:id", func(c *gin.Context) {
+		_, span := tracing.Tracer().Start(c.Request.Context(), "child")
+		span.End()
+		c.Status(http.StatusInternalServerError)
+	})
+
+	const parent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	req := httptest.NewRequest(http.MethodGet, "/pools/7", nil)
+	req.Header.Set("traceparent", parent)
+	app.ServeHTTP(httptest.NewRecorder(), req)
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+	child, server, unmatched := spans[0], spans[1], spans[2]
+	if server.Name != "GET /pools/:id" || server.SpanKind != trace.SpanKindServer {
+		t.Fatalf("server span = %s (%s)", server.Name, server.SpanKind)
+	}
+	if server.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || server.Parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Fatalf("server span not continued from traceparent: %v parent %v", server.SpanContext, server.Parent)
+	}
+	if child.Name != "child" || child.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Fatalf("child span parent = %v, want %v", child.Parent.SpanID(), server.SpanContext.SpanID())
+	}
+	if server.Status.Code != codes.Error {
+		t.Fatalf("server span status = %v, want error for 500", server.Status)
+	}
+	status := ""
+	for _, kv := range server.Attributes {
+		if kv.Key == "http.status_code" {
+			status = kv.Value.Emit()
+		}
+	}
+	if status != "500" {
+		t.Fatalf("http.status_code = %q", status)
+	}
+	if unmatched.Name != "GET unmatched" || unmatched.Parent.IsValid() {
+		t.Fatalf("unmatched span = %s parent %v", unmatched.Name, unmatched.Parent)
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"context"
+
+	"lending-copy/config"
+	"lending-copy/db"
+	"lending-copy/utils"
+
+	"gorm.io/gorm"
+)
+
+// ApiKey 公共接口的 API key；库中只存明文 key 的 SHA-256，prefix 为明文前几位，用于辨认；
+// rate_per_minute / burst 为 0 时使用 rate_limit.key_rate / key_burst；revoked_at 非 0 表示已吊销
+type ApiKey struct {
+	Id            int    `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	Name          string `json:"name" gorm:"column:name;type:varchar(64)"`
+	Prefix        string `json:"prefix" gorm:"column:prefix;type:varchar(16)"`
+	KeyHash       string `json:"-" gorm:"column:key_hash;type:char(64);uniqueIndex:uk_api_keys_hash"`
+	RatePerMinute int    `json:"rate_per_minute" gorm:"column:rate_per_minute"`
+	Burst         int    `json:"burst" gorm:"column:burst"`
+	RevokedAt     int64  `json:"revoked_at" gorm:"column:revoked_at"`
+	CreatedAt     string `json:"created_at" gorm:"column:created_at"`
+}
+
+func (ApiKey) TableName() string { return "api_keys" }
+
+func NewApiKey() *ApiKey {
+	return &ApiKey{}
+}
+
+// InitTable API 服务自有的表
+func InitTable() {
+	db.Mysql.AutoMigrate(&ApiKey{})
+}
+
+// Quota 该 key 的实际配额
+func (k *ApiKey) Quota(conf config.RateLimitConfig) (rate, burst int) {
+	rate, burst = k.RatePerMinute, k.Burst
+	if rate == 0 {
+		rate = conf.KeyRate
+	}
+	if burst == 0 {
+		burst = conf.KeyBurst
+	}
+	return rate, burst
+}
+
+func (k *ApiKey) Create(ctx context.Context, key *ApiKey) error {
+	key.CreatedAt = utils.GetCurDateTimeFormat()
+	return db.Mysql.WithContext(ctx).Table("api_keys").Create(key).Error
+}
+
+func (k *ApiKey) List(ctx context.Context) ([]ApiKey, error) {
+	var rows []ApiKey
+	err := db.Mysql.WithContext(ctx).Table("api_keys").Order("id asc").Find(&rows).Error
+	return rows, err
+}
+
+// GetActive 按哈希查找未吊销的 key，不存在时返回 gorm.ErrRecordNotFound
+func (k *ApiKey) GetActive(ctx context.Context, hash string) (*ApiKey, error) {
+	key := &ApiKey{}
+	err := db.Mysql.WithContext(ctx).Table("api_keys").Where("key_hash = ? and revoked_at = 0", hash).First(key).Error
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Revoke 吊销 key；已吊销或不存在时返回 gorm.ErrRecordNotFound
+func (k *ApiKey) Revoke(ctx context.Context, id int, at int64) error {
+	tx := db.Mysql.WithContext(ctx).Table("api_keys").Where("id = ? and revoked_at = 0", id).Update("revoked_at", at)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetQuota 修改配额，0 表示使用默认值；不存在时返回 gorm.ErrRecordNotFound
+func (k *ApiKey) SetQuota(ctx context.Context, id, rate, burst int) error {
+	if err := db.Mysql.WithContext(ctx).Table("api_keys").Where("id = ?", id).First(&ApiKey{}).Error; err != nil {
+		return err
+	}
+	return db.Mysql.WithContext(ctx).Table("api_keys").Where("id = ?", id).Updates(map[string]interface{}{
+		"rate_per_minute": rate,
+		"burst":           burst,
+	}).Error
+}
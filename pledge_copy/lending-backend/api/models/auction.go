@@ -0,0 +1,146 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"time"
+
+	"lending-copy/api/models/request"
+	"lending-copy/db"
+	"lending-copy/schedule/models"
+
+	"gorm.io/gorm"
+)
+
+// AuctionInfo 拍卖及其展示状态：active 竞拍中，expired 已到期待 endAuction，ended 已成交
+type AuctionInfo struct {
+	models.Auction
+	Status string `json:"status"`
+}
+
+// PendingRefund 某个代币尚未提取的退款，零地址为 ETH
+type PendingRefund struct {
+	RefundToken string `json:"refund_token"`
+	Amount      string `json:"amount"`
+}
+
+func NewAuctionInfo() *AuctionInfo {
+	return &AuctionInfo{}
+}
+
+func auctionStatus(a *models.Auction, now int64) string {
+	if a.Ended {
+		return "ended"
+	}
+	if a.EndTime <= now {
+		return "expired"
+	}
+	return "active"
+}
+
+func (a *AuctionInfo) Pagination(ctx context.Context, chainId, contract string, req *request.AuctionList) (error, int64, []AuctionInfo) {
+	var total int64
+	now := time.Now().Unix()
+	query := db.Mysql.WithContext(ctx).Table("auctions").Where("chain_id=? and contract=?", chainId, contract)
+	switch req.Status {
+	case "active":
+		query = query.Where("ended=? and end_time>?", false, now)
+	case "expired":
+		query = query.Where("ended=? and end_time<=?", false, now)
+	case "ended":
+		query = query.Where("ended=?", true)
+	}
+	if req.Seller != "" {
+		query = query.Where("seller=?", req.Seller)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return err, 0, nil
+	}
+	auctions := []models.Auction{}
+	err := query.Order("auction_id desc").Limit(req.PageSize).Offset((req.Page - 1) * req.PageSize).Find(&auctions).Error
+	if err != nil {
+		return err, 0, nil
+	}
+	rows := make([]AuctionInfo, 0, len(auctions))
+	for _, v := range auctions {
+		rows = append(rows, AuctionInfo{Auction: v, Status: auctionStatus(&v, now)})
+	}
+	return nil, total, rows
+}
+
+// GetAuction 拍卖不存在时返回 gorm.ErrRecordNotFound
+func (a *AuctionInfo) GetAuction(ctx context.Context, chainId, contract string, auctionId int64) (error, *AuctionInfo) {
+	auction := models.Auction{}
+	err := db.Mysql.WithContext(ctx).Table("auctions").Where("chain_id=? and contract=? and auction_id=?", chainId, contract, auctionId).First(&auction).Error
+	if err != nil {
+		return err, nil
+	}
+	return nil, &AuctionInfo{Auction: auction, Status: auctionStatus(&auction, time.Now().Unix())}
+}
+
+func (a *AuctionInfo) Bids(ctx context.Context, chainId, contract string, req *request.AuctionBids) (error, int64, []models.AuctionBid) {
+	var total int64
+	query := db.Mysql.WithContext(ctx).Table("auction_bids").Where("chain_id=? and contract=? and auction_id=?", chainId, contract, req.AuctionId)
+	if err := query.Count(&total).Error; err != nil {
+		return err, 0, nil
+	}
+	bids := []models.AuctionBid{}
+	err := query.Order("block_number desc, log_index desc").Limit(req.PageSize).Offset((req.Page - 1) * req.PageSize).Find(&bids).Error
+	if err != nil {
+		return err, 0, nil
+	}
+	return nil, total, bids
+}
+
+func (a *AuctionInfo) Refunds(ctx context.Context, chainId, contract string, req *request.UserRefunds) (error, int64, []models.AuctionRefund) {
+	var total int64
+	query := db.Mysql.WithContext(ctx).Table("auction_refunds").Where("chain_id=? and contract=? and bidder=?", chainId, contract, req.Address)
+	if err := query.Count(&total).Error; err != nil {
+		return err, 0, nil
+	}
+	refunds := []models.AuctionRefund{}
+	err := query.Order("block_number desc, log_index desc").Limit(req.PageSize).Offset((req.Page - 1) * req.PageSize).Find(&refunds).Error
+	if err != nil {
+		return err, 0, nil
+	}
+	return nil, total, refunds
+}
+
+// PendingRefunds 按代币汇总 queued - withdrawn，与合约 pendingEthReturns / pendingTokenReturns 对应
+func (a *AuctionInfo) PendingRefunds(ctx context.Context, chainId, contract, bidder string) (error, []PendingRefund) {
+	refunds := []models.AuctionRefund{}
+	err := db.Mysql.WithContext(ctx).Table("auction_refunds").Select("refund_token, action, amount").
+		Where("chain_id=? and contract=? and bidder=?", chainId, contract, bidder).Find(&refunds).Error
+	if err != nil {
+		return err, nil
+	}
+	sums := map[string]*big.Int{}
+	for _, r := range refunds {
+		amount, ok := new(big.Int).SetString(r.Amount, 10)
+		if !ok {
+			return errors.New("invalid refund amount " + r.Amount), nil
+		}
+		if sums[r.RefundToken] == nil {
+			sums[r.RefundToken] = new(big.Int)
+		}
+		if r.Action == models.RefundActionWithdrawn {
+			sums[r.RefundToken].Sub(sums[r.RefundToken], amount)
+		} else {
+			sums[r.RefundToken].Add(sums[r.RefundToken], amount)
+		}
+	}
+	pending := []PendingRefund{}
+	for token, amount := range sums {
+		if amount.Sign() > 0 {
+			pending = append(pending, PendingRefund{RefundToken: token, Amount: amount.String()})
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].RefundToken < pending[j].RefundToken })
+	return nil, pending
+}
+
+func IsNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
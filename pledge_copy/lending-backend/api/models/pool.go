@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -33,18 +34,18 @@ func NewPool() *Pool {
 	return &Pool{}
 }
 
-func (p *Pool) Pagination(req *request.Search, whereCondition string) (error, int64, []Pool) {
+func (p *Pool) Pagination(ctx context.Context, req *request.Search, whereCondition string) (error, int64, []Pool) {
 	var total int64
 	pools := []Pool{}
 	poolBase := []models.PoolBase{}
-	db.Mysql.Table("poolbases").Where(whereCondition).Count(&total)
-	err := db.Mysql.Table("poolbases").Where(whereCondition).Order("pool_id desc").Limit(req.PageSize).Offset((req.Page - 1) * req.PageSize).Find(&poolBase).Error
+	db.Mysql.WithContext(ctx).Table("poolbases").Where(whereCondition).Count(&total)
+	err := db.Mysql.WithContext(ctx).Table("poolbases").Where(whereCondition).Order("pool_id desc").Limit(req.PageSize).Offset((req.Page - 1) * req.PageSize).Find(&poolBase).Error
 	if err != nil {
 		return err, 0, nil
 	}
 	for _, b := range poolBase {
 		poolData := PoolData{}
-		err = db.Mysql.Table("pooldata").Where("chain_id=? and pool_id=?", fmt.Sprint(req.ChainID), b.PoolId).First(&poolData).Error
+		err = db.Mysql.WithContext(ctx).Table("pooldata").Where("chain_id=? and pool_id=?", fmt.Sprint(req.ChainID), b.PoolId).First(&poolData).Error
 		if err != nil {
 			return err, 0, nil
 		}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -74,9 +75,9 @@ func (p *PoolBases) TableName() string {
 	return "poolbases"
 }
 
-func (p *PoolBases) PoolBaseInfo(chainId int, res *[]PoolBaseInfoRes) error {
+func (p *PoolBases) PoolBaseInfo(ctx context.Context, chainId int, res *[]PoolBaseInfoRes) error {
 	var poolBases []PoolBases
-	err := db.Mysql.Table("poolbases").Where("chain_id=?", fmt.Sprint(chainId)).Order("pool_id asc").Find(&poolBases).Error
+	err := db.Mysql.WithContext(ctx).Table("poolbases").Where("chain_id=?", fmt.Sprint(chainId)).Order("pool_id asc").Find(&poolBases).Error
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -32,9 +33,9 @@ func (p *PoolData) TableName() string {
 	return "pooldata"
 }
 
-func (p *PoolData) PoolDataInfo(chainId int, res *[]PoolDataInfoRes) error {
+func (p *PoolData) PoolDataInfo(ctx context.Context, chainId int, res *[]PoolDataInfoRes) error {
 	var rows []PoolData
-	err := db.Mysql.Table("pooldata").Where("chain_id=?", fmt.Sprint(chainId)).Order("pool_id asc").Find(&rows).Error
+	err := db.Mysql.WithContext(ctx).Table("pooldata").Where("chain_id=?", fmt.Sprint(chainId)).Order("pool_id asc").Find(&rows).Error
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,20 @@
+package request
+
+type AuctionList struct {
+	Status   string `form:"status" binding:"omitempty,oneof=active expired ended"`
+	Seller   string `form:"seller" binding:"omitempty,eth_address"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type AuctionBids struct {
+	AuctionId int64 `uri:"id" binding:"min=0"`
+	Page      int   `form:"page" binding:"omitempty,min=1"`
+	PageSize  int   `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type UserRefunds struct {
+	Address  string `uri:"address" binding:"required,eth_address"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
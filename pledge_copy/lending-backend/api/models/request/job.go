@@ -0,0 +1,11 @@
+package request
+
+type JobRuns struct {
+	Name     string `uri:"name" binding:"required"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+type JobTrigger struct {
+	Name string `uri:"name" binding:"required"`
+}
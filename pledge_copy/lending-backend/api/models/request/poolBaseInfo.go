@@ -1,21 +1,29 @@
 package request
 
 type PoolBaseInfo struct {
-	ChainId int `form:"chain_id" json:"chain_id" validate:"required"`
+	ChainId int `form:"chain_id" json:"chain_id" binding:"required,chain_id"`
 }
 
 type PoolDataInfo struct {
-	ChainId int `form:"chain_id" json:"chain_id" validate:"required"`
+	ChainId int `form:"chain_id" json:"chain_id" binding:"required,chain_id"`
 }
 
+// TokenLogo Logo 为上传文件内容，由校验层读取
+type TokenLogo struct {
+	ChainId int    `uri:"chain_id" binding:"required,chain_id"`
+	Address string `uri:"address" binding:"required,eth_address"`
+	Logo    []byte `uri:"-"`
+}
+
+// TokenList chain_id 为空时返回全部链
 type TokenList struct {
-	ChainId int `form:"chain_id" json:"chain_id" validate:"required"`
+	ChainId int `form:"chain_id" json:"chain_id" binding:"omitempty,chain_id"`
 }
 
 type Search struct {
-	ChainID         int    `json:"chain_id" validate:"required"`
-	Page            int    `json:"page"`
-	PageSize        int    `json:"page_size"`
-	LendTokenSymbol string `json:"lend_token_symbol"`
-	State           string `json:"state"`
+	ChainID         int    `json:"chain_id" binding:"required,chain_id"`
+	Page            int    `json:"page" binding:"omitempty,min=1"`
+	PageSize        int    `json:"page_size" binding:"omitempty,min=1,max=100"`
+	LendTokenSymbol string `json:"lend_token_symbol" binding:"omitempty,token_symbol"`
+	State           string `json:"state" binding:"omitempty,pool_state"`
 }
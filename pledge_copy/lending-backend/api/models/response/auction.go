@@ -0,0 +1,24 @@
+package response
+
+import (
+	"lending-copy/api/models"
+	schedmodels "lending-copy/schedule/models"
+)
+
+type AuctionList struct {
+	Rows  []models.AuctionInfo `json:"rows"`
+	Count int64                `json:"count"`
+}
+
+type AuctionBids struct {
+	Auction *models.AuctionInfo      `json:"auction"`
+	Rows    []schedmodels.AuctionBid `json:"rows"`
+	Count   int64                    `json:"count"`
+}
+
+type UserRefunds struct {
+	Address string                      `json:"address"`
+	Pending []models.PendingRefund      `json:"pending"`
+	Rows    []schedmodels.AuctionRefund `json:"rows"`
+	Count   int64                       `json:"count"`
+}
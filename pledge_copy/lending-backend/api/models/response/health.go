@@ -0,0 +1,36 @@
+package response
+
+// Health /healthz 与 /readyz 的响应，status 为 ok 或 unavailable（HTTP 503）
+type Health struct {
+	Status  string        `json:"status"`
+	Service string        `json:"service"`
+	Checks  []HealthCheck `json:"checks,omitempty"`
+}
+
+type HealthCheck struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// SyncStatus /status 的响应，status 为 stale 或 unavailable 时 HTTP 状态码为 503
+type SyncStatus struct {
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	MaxSyncAge string    `json:"max_sync_age"`
+	Syncs      []SyncJob `json:"syncs"`
+}
+
+// SyncJob 一条链上一个同步任务的进度；从未成功同步时 last_success_at 为 0、age_seconds 为 null
+type SyncJob struct {
+	Job           string  `json:"job"`
+	ChainId       string  `json:"chain_id"`
+	LastSuccessAt int64   `json:"last_success_at"`
+	AgeSeconds    *int64  `json:"age_seconds"`
+	Stale         bool    `json:"stale"`
+	IndexedBlock  *uint64 `json:"indexed_block,omitempty"`
+	HeadBlock     *uint64 `json:"head_block,omitempty"`
+	LagBlocks     *uint64 `json:"lag_blocks,omitempty"`
+	HeadError     string  `json:"head_error,omitempty"`
+}
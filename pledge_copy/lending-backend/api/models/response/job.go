@@ -0,0 +1,23 @@
+package response
+
+import schedmodels "lending-copy/schedule/models"
+
+// JobInfo 一个定时任务的调度策略与最近一次执行；从未执行过时 last_run 为 null
+type JobInfo struct {
+	schedmodels.Job
+	LastRun *schedmodels.JobRun `json:"last_run"`
+}
+
+type JobList struct {
+	Rows []JobInfo `json:"rows"`
+}
+
+type JobRuns struct {
+	Rows  []schedmodels.JobRun `json:"rows"`
+	Count int64                `json:"count"`
+}
+
+// JobTrigger 触发已发给定时任务进程，执行结果见执行记录
+type JobTrigger struct {
+	Job string `json:"job"`
+}
@@ -2,6 +2,7 @@ package response
 
 import (
 	"lending-copy/api/common/statecode"
+	"lending-copy/log"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,10 +11,12 @@ type Gin struct {
 	Res *gin.Context
 }
 
+// Response 统一响应结构；errors 仅在参数校验失败时返回字段明细
 type Response struct {
-	Code int         `json:"code"`
-	Msg  string      `json:"message"`
-	Data interface{} `json:"data"`
+	Code   int                    `json:"code"`
+	Msg    string                 `json:"message"`
+	Data   interface{}            `json:"data"`
+	Errors []statecode.FieldError `json:"errors,omitempty"`
 }
 
 type Page struct {
@@ -23,31 +26,47 @@ type Page struct {
 	Data  interface{} `json:"data"`
 }
 
-func (g *Gin) Response(c *gin.Context, code int, data interface{}, httpStatus ...int) {
-	lang := statecode.LangEn
+// Lang 由 middlewares.Lang 根据 Accept-Language 写入，未经过中间件时为英文
+func Lang(c *gin.Context) int {
 	if langInf, ok := c.Get("lang"); ok {
-		lang = langInf.(int)
-	}
-	rsp := Response{
-		Code: code,
-		Msg:  statecode.GetMsg(code, lang),
-		Data: data,
+		if lang, ok := langInf.(int); ok {
+			return lang
+		}
 	}
-	status := 200
+	return statecode.LangEn
+}
+
+// Response 未指定 httpStatus 时按错误码目录映射
+func (g *Gin) Response(c *gin.Context, code int, data interface{}, httpStatus ...int) {
+	status := statecode.HTTPStatus(code)
 	if len(httpStatus) > 0 {
 		status = httpStatus[0]
 	}
-	g.Res.JSON(status, rsp)
+	g.Res.JSON(status, Response{
+		Code: code,
+		Msg:  statecode.GetMsg(code, Lang(c)),
+		Data: data,
+	})
+}
+
+// Error 输出 *statecode.Error 的错误码与字段明细；其他错误记录日志后按服务器错误返回
+func (g *Gin) Error(c *gin.Context, err error) {
+	e := statecode.FromError(err)
+	if e.Code == statecode.CommonErrServerErr {
+		log.Logger.Sugar().Error(c.Request.Method, " ", c.Request.URL.Path, " ", err)
+	}
+	lang := Lang(c)
+	g.Res.JSON(e.Status(), Response{
+		Code:   e.Code,
+		Msg:    statecode.GetMsg(e.Code, lang),
+		Errors: e.Localize(lang),
+	})
 }
 
 func (g *Gin) ResponsePages(c *gin.Context, code int, totalCount int, data interface{}) {
-	lang := statecode.LangEn
-	if langInf, ok := c.Get("lang"); ok {
-		lang = langInf.(int)
-	}
-	g.Res.JSON(200, Page{
+	g.Res.JSON(statecode.HTTPStatus(code), Page{
 		Code:  code,
-		Msg:   statecode.GetMsg(code, lang),
+		Msg:   statecode.GetMsg(code, Lang(c)),
 		Total: totalCount,
 		Data:  data,
 	})
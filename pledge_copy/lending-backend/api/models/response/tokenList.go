@@ -1,13 +1,14 @@
 package response
 
-import "time"
-
+// TokenList 遵循 Uniswap token-list schema（https://uniswap.org/tokenlist.schema.json）
 type TokenList struct {
-	Name      string    `json:"name"`
-	LogoURI   string    `json:"logoURI"`
-	Timestamp time.Time `json:"timestamp"`
-	Version   Version   `json:"version"`
-	Tokens    []Token   `json:"tokens"`
+	Name      string                   `json:"name"`
+	Timestamp string                   `json:"timestamp"`
+	Version   Version                  `json:"version"`
+	Tokens    []Token                  `json:"tokens"`
+	Keywords  []string                 `json:"keywords,omitempty"`
+	Tags      map[string]TagDefinition `json:"tags,omitempty"`
+	LogoURI   string                   `json:"logoURI,omitempty"`
 }
 
 type Version struct {
@@ -16,11 +17,27 @@ type Version struct {
 	Patch int `json:"patch"`
 }
 
+type TagDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
 type Token struct {
-	Name     string `json:"name"`
-	Symbol   string `json:"symbol"`
-	Decimals int    `json:"decimals"`
-	Address  string `json:"address"`
-	ChainID  string `json:"chainId"`
-	LogoURI  string `json:"logoURI"`
+	ChainID    int                    `json:"chainId"`
+	Address    string                 `json:"address"`
+	Name       string                 `json:"name"`
+	Symbol     string                 `json:"symbol"`
+	Decimals   int                    `json:"decimals"`
+	LogoURI    string                 `json:"logoURI,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// TokenLogo logo 为写入 token_info 的地址，logoURI 为补全后的完整地址
+type TokenLogo struct {
+	ChainId int    `json:"chain_id"`
+	Address string `json:"address"`
+	Logo    string `json:"logo"`
+	LogoURI string `json:"logoURI"`
+	Sha256  string `json:"sha256"`
 }
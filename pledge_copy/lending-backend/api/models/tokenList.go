@@ -1,11 +1,19 @@
 package models
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"lending-copy/api/models/request"
 	"lending-copy/db"
+	"lending-copy/schedule/models"
+	"lending-copy/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TokenList struct {
@@ -17,6 +25,20 @@ type TokenList struct {
 	ChainId  string `json:"chain_id" gorm:"column:chain_id"`
 }
 
+// TokenUsage 代币在池子中作为出借/抵押资产出现的次数
+type TokenUsage struct {
+	LendPools   int
+	BorrowPools int
+}
+
+// 版本号按 token-list 规范递增的级别
+const (
+	VersionBumpNone = iota
+	VersionBumpPatch
+	VersionBumpMinor
+	VersionBumpMajor
+)
+
 func (TokenList) TableName() string { return "token_info" }
 
 func NewTokenListModel() *TokenListModel {
@@ -25,12 +47,97 @@ func NewTokenListModel() *TokenListModel {
 
 type TokenListModel struct{}
 
-func (m *TokenListModel) GetTokenList(req *request.TokenList) (error, []TokenList) {
+// GetTokenList ChainId 为 0 时返回全部链
+func (m *TokenListModel) GetTokenList(ctx context.Context, req *request.TokenList) (error, []TokenList) {
 	var tokenList []TokenList
-	cid := fmt.Sprint(req.ChainId)
-	err := db.Mysql.Table("token_info").Where("chain_id = ?", cid).Find(&tokenList).Error
+	query := db.Mysql.WithContext(ctx).Table("token_info")
+	if req.ChainId != 0 {
+		query = query.Where("chain_id = ?", fmt.Sprint(req.ChainId))
+	}
+	err := query.Order("chain_id asc, id asc").Find(&tokenList).Error
 	if err != nil {
 		return errors.New("record select err " + err.Error()), nil
 	}
 	return nil, tokenList
 }
+
+// UpdateLogo 地址不区分大小写匹配；found 为 false 表示 token_info 中没有该代币
+func (m *TokenListModel) UpdateLogo(ctx context.Context, chainId, token, logo string) (error, bool) {
+	var count int64
+	query := db.Mysql.WithContext(ctx).Table("token_info").Where("chain_id = ? and LOWER(token) = ?", chainId, strings.ToLower(token))
+	if err := query.Count(&count).Error; err != nil {
+		return errors.New("record select err " + err.Error()), false
+	}
+	if count == 0 {
+		return nil, false
+	}
+	err := db.Mysql.WithContext(ctx).Table("token_info").Where("chain_id = ? and LOWER(token) = ?", chainId, strings.ToLower(token)).Update("logo", logo).Error
+	if err != nil {
+		return errors.New("record update err " + err.Error()), false
+	}
+	return nil, true
+}
+
+// TokenUsage 按 chain_id + 小写地址统计池子引用次数
+func (m *TokenListModel) TokenUsage(ctx context.Context) (error, map[string]TokenUsage) {
+	var pools []models.PoolBase
+	err := db.Mysql.WithContext(ctx).Table("poolbases").Select("chain_id, lend_token, borrow_token").Find(&pools).Error
+	if err != nil {
+		return errors.New("record select err " + err.Error()), nil
+	}
+	usage := map[string]TokenUsage{}
+	for _, p := range pools {
+		lendKey := p.ChainId + ":" + strings.ToLower(p.LendToken)
+		u := usage[lendKey]
+		u.LendPools++
+		usage[lendKey] = u
+
+		borrowKey := p.ChainId + ":" + strings.ToLower(p.BorrowToken)
+		u = usage[borrowKey]
+		u.BorrowPools++
+		usage[borrowKey] = u
+	}
+	return nil, usage
+}
+
+// SyncVersion 用 tokens 快照与已保存的快照比较，bump 返回需要递增的级别；并发请求在行锁内串行
+func (m *TokenListModel) SyncVersion(ctx context.Context, scope, tokens string, bump func(prevTokens string) int) (error, models.TokenListVersion) {
+	version := models.TokenListVersion{}
+	err := db.Mysql.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		nowDateTime := utils.GetCurDateTimeFormat()
+		now := time.Now().UTC().Format(time.RFC3339)
+		err := tx.Table("token_list_version").Clauses(clause.Locking{Strength: "UPDATE"}).Where("scope = ?", scope).First(&version).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			version = models.TokenListVersion{Scope: scope, Major: 1, Tokens: tokens, Timestamp: now, UpdatedAt: nowDateTime}
+			return tx.Table("token_list_version").Create(&version).Error
+		}
+		if err != nil {
+			return err
+		}
+		switch bump(version.Tokens) {
+		case VersionBumpMajor:
+			version.Major, version.Minor, version.Patch = version.Major+1, 0, 0
+		case VersionBumpMinor:
+			version.Minor, version.Patch = version.Minor+1, 0
+		case VersionBumpPatch:
+			version.Patch++
+		default:
+			return nil
+		}
+		version.Tokens = tokens
+		version.Timestamp = now
+		version.UpdatedAt = nowDateTime
+		return tx.Table("token_list_version").Where("id = ?", version.Id).Updates(map[string]interface{}{
+			"major":      version.Major,
+			"minor":      version.Minor,
+			"patch":      version.Patch,
+			"tokens":     version.Tokens,
+			"timestamp":  version.Timestamp,
+			"updated_at": version.UpdatedAt,
+		}).Error
+	})
+	if err != nil {
+		return errors.New("token list version err " + err.Error()), version
+	}
+	return nil, version
+}
@@ -0,0 +1,321 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// GenerateClient 根据文档生成 lending-copy/client 的 client_gen.go：components.schemas 中的每个对象生成一个结构体，
+// 每个接口生成一个 Client 方法。路径参数按出现顺序作为方法参数，query 与 header 参数放在 <operationId>Params 中；
+// 响应为统一响应结构（allOf Response + data）时方法返回 data，非 JSON 响应返回原始内容
+func GenerateClient(doc *Document) ([]byte, error) {
+	g := &generator{buf: &bytes.Buffer{}}
+	g.printf("// Code generated by lending_openapi from api/openapi/openapi.json; DO NOT EDIT.\n\n")
+	g.printf("package client\n\nimport (\n\t\"context\"\n\t\"net/http\"\n)\n")
+
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := g.schema(name, doc.Components.Schemas[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		methods := make([]string, 0, len(doc.Paths[path]))
+		for method := range doc.Paths[path] {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			if err := g.operation(method, path, doc.Paths[path][method]); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+		}
+	}
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated client: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	buf *bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g.buf, format, args...)
+}
+
+func (g *generator) schema(name string, s *Schema) error {
+	if s.Type != "object" || s.Properties == nil {
+		return fmt.Errorf("schema %s: only objects with properties are supported", name)
+	}
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	g.printf("\ntype %s struct {\n", name)
+	seen := map[string]string{}
+	for _, prop := range props {
+		field := goName(prop)
+		if other, ok := seen[field]; ok {
+			return fmt.Errorf("schema %s: properties %s and %s both map to field %s", name, other, prop, field)
+		}
+		seen[field] = prop
+		typ, err := goType(s.Properties[prop])
+		if err != nil {
+			return fmt.Errorf("schema %s.%s: %w", name, prop, err)
+		}
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		if doc := fieldDoc("", s.Properties[prop], false); doc != "" {
+			g.printf("\t// %s %s\n", field, doc)
+		}
+		g.printf("\t%s %s `json:\"%s\"`\n", field, typ, tag)
+	}
+	g.printf("}\n")
+	return nil
+}
+
+func (g *generator) operation(method, path string, op *Operation) error {
+	var args, pathParams, optional []string
+	var params []Parameter
+	for _, p := range op.Parameters {
+		typ, err := goType(p.Schema)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		if p.In == "path" {
+			arg := lowerFirst(goName(p.Name))
+			args = append(args, arg+" "+typ)
+			pathParams = append(pathParams, p.Name, arg)
+			continue
+		}
+		params = append(params, p)
+		optional = append(optional, typ)
+	}
+	if len(params) > 0 {
+		g.printf("\n// %sParams %s 的 query 与 header 参数，非必填参数为零值时不发送\n", op.OperationId, op.OperationId)
+		g.printf("type %sParams struct {\n", op.OperationId)
+		for i, p := range params {
+			if doc := fieldDoc(p.Description, p.Schema, p.Required); doc != "" {
+				g.printf("\t// %s %s\n", goName(p.Name), doc)
+			}
+			g.printf("\t%s %s\n", goName(p.Name), optional[i])
+		}
+		g.printf("}\n")
+		args = append(args, "params "+op.OperationId+"Params")
+	}
+
+	var body string
+	if op.RequestBody != nil {
+		if mt, ok := op.RequestBody.Content["application/json"]; ok {
+			typ, err := goType(mt.Schema)
+			if err != nil {
+				return fmt.Errorf("request body: %w", err)
+			}
+			args = append(args, "body *"+typ)
+			body = "\tif err := r.json(body); err != nil {\n\t\treturn out, err\n\t}\n"
+		} else if mt, ok := op.RequestBody.Content["multipart/form-data"]; ok && len(mt.Schema.Properties) == 1 {
+			for field := range mt.Schema.Properties {
+				arg := lowerFirst(goName(field))
+				args = append(args, arg+" []byte")
+				body = fmt.Sprintf("\tif err := r.multipart(%q, %s); err != nil {\n\t\treturn out, err\n\t}\n", field, arg)
+			}
+		} else {
+			return fmt.Errorf("unsupported request body")
+		}
+	}
+
+	result, envelope, err := g.result(op)
+	if err != nil {
+		return err
+	}
+
+	g.printf("\n// %s %s\n", op.OperationId, op.Summary)
+	if op.Description != "" {
+		g.printf("// %s\n", op.Description)
+	}
+	g.printf("//\n// %s %s\n", strings.ToUpper(method), path)
+	g.printf("func (c *Client) %s(%s) (out %s, err error) {\n", op.OperationId, strings.Join(append([]string{"ctx context.Context"}, args...), ", "), result)
+	g.printf("\tr := c.newRequest(http.Method%s, %s)\n", methodName(method), pathExpr(path, pathParams))
+	if admin(op) {
+		g.printf("\tr.admin = true\n")
+	}
+	for _, p := range params {
+		if p.In == "header" {
+			g.printf("\tr.setHeader(%q, params.%s)\n", p.Name, goName(p.Name))
+		} else {
+			g.printf("\tr.setQuery(%q, params.%s, %t)\n", p.Name, goName(p.Name), p.Required)
+		}
+	}
+	g.printf("%s", body)
+	g.printf("\terr = c.do(ctx, r, %t, &out)\n\treturn out, err\n}\n", envelope)
+	return nil
+}
+
+// result 取最小的 2xx 响应作为返回值
+func (g *generator) result(op *Operation) (typ string, envelope bool, err error) {
+	for status := http.StatusOK; status < 300; status++ {
+		resp, ok := op.Responses[fmt.Sprint(status)]
+		if !ok {
+			continue
+		}
+		mt, ok := resp.Content["application/json"]
+		if !ok {
+			return "[]byte", false, nil
+		}
+		s := mt.Schema
+		if len(s.AllOf) == 2 && s.AllOf[0].Ref == refPrefix+"Response" && s.AllOf[1].Properties["data"] != nil {
+			s, envelope = s.AllOf[1].Properties["data"], true
+		}
+		typ, err = goType(s)
+		if err == nil && strings.HasPrefix(s.Ref, refPrefix) {
+			typ = "*" + typ
+		}
+		return typ, envelope, err
+	}
+	return "", false, fmt.Errorf("no 2xx response")
+}
+
+// fieldDoc 字段注释：说明、是否必填与可选值
+func fieldDoc(desc string, s *Schema, required bool) string {
+	var parts []string
+	if required {
+		parts = append(parts, "必填")
+	}
+	for _, d := range []string{desc, s.Description} {
+		if d != "" {
+			parts = append(parts, d)
+		}
+	}
+	if len(s.Enum) > 0 {
+		parts = append(parts, "可选值 "+strings.Join(s.Enum, "、"))
+	}
+	return strings.Join(parts, "；")
+}
+
+func admin(op *Operation) bool {
+	for _, req := range op.Security {
+		if _, ok := req["AdminToken"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func goType(s *Schema) (string, error) {
+	if s.Ref != "" {
+		return strings.TrimPrefix(s.Ref, refPrefix), nil
+	}
+	if len(s.AllOf) == 1 {
+		typ, err := goType(s.AllOf[0])
+		if s.Nullable {
+			typ = "*" + typ
+		}
+		return typ, err
+	}
+	if s.Nullable {
+		inner := *s
+		inner.Nullable = false
+		typ, err := goType(&inner)
+		return "*" + typ, err
+	}
+	switch s.Type {
+	case "":
+		return "interface{}", nil
+	case "string":
+		if s.Format == "byte" || s.Format == "binary" {
+			return "[]byte", nil
+		}
+		return "string", nil
+	case "boolean":
+		return "bool", nil
+	case "number":
+		return "float64", nil
+	case "integer":
+		switch s.Format {
+		case "int32", "int64", "uint64":
+			return s.Format, nil
+		}
+		return "int", nil
+	case "array":
+		typ, err := goType(s.Items)
+		return "[]" + typ, err
+	case "object":
+		if s.Properties == nil {
+			if s.AdditionalProperties == nil {
+				return "map[string]interface{}", nil
+			}
+			typ, err := goType(s.AdditionalProperties)
+			return "map[string]" + typ, err
+		}
+	}
+	return "", fmt.Errorf("unsupported schema %+v", *s)
+}
+
+// initialisms 转换为 Go 字段名时整体大写的单词
+var initialisms = map[string]bool{"ID": true, "URI": true, "URL": true, "API": true, "USD": true, "HTTP": true, "JSON": true}
+
+// goName pool_id、If-None-Match、logoURI 分别转为 PoolID、IfNoneMatch、LogoURI
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		if initialisms[strings.ToUpper(part)] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	for i, r := range s {
+		if r < 'A' || r > 'Z' {
+			if i > 1 {
+				i--
+			}
+			if i == 0 {
+				return s
+			}
+			return strings.ToLower(s[:i]) + s[i:]
+		}
+	}
+	return strings.ToLower(s)
+}
+
+func methodName(method string) string {
+	return strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+}
+
+// pathExpr 生成拼接路径的表达式，pathParams 为 参数名、变量名 交替排列
+func pathExpr(path string, pathParams []string) string {
+	expr := fmt.Sprintf("%q", path)
+	for i := 0; i < len(pathParams); i += 2 {
+		expr = strings.Replace(expr, "{"+pathParams[i]+"}", `"+pathParam(`+pathParams[i+1]+`)+"`, 1)
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(expr, `""+`), `+""`)
+}
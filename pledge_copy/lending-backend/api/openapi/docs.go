@@ -0,0 +1,28 @@
+package openapi
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// docsPage 使用 Redoc 渲染文档，脚本从 CDN 加载
+var docsPage = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>lending-backend API</title>
+</head>
+<body>
+  <redoc spec-url="{{.}}"></redoc>
+  <script src="https://cdn.redoc.ly/redoc/v2.1.5/bundles/redoc.standalone.js"></script>
+</body>
+</html>
+`))
+
+// DocsPage 文档页面，specURL 为 openapi.json 的地址
+func DocsPage(specURL string) []byte {
+	buf := &bytes.Buffer{}
+	_ = docsPage.Execute(buf, specURL)
+	return buf.Bytes()
+}
@@ -0,0 +1,226 @@
+// Package openapi 根据路由说明与请求/响应结构生成 OpenAPI 3 文档，以及 lending-copy/client 中的类型化客户端。
+// 修改路由或响应结构后执行 go generate ./api/openapi，重新生成 openapi.json 与 client/client_gen.go
+package openapi
+
+//go:generate go run ../../cmd/lending_openapi -spec openapi.json -client ../../client/client_gen.go
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models/response"
+)
+
+// SpecVersion 提交到仓库的 openapi.json 与客户端按 env.version = "1" 生成
+const SpecVersion = "1"
+
+const description = `成功与业务错误均使用统一响应结构 {code, message, data, errors}，errors 仅在参数校验失败时返回字段明细；message 的语言由 Accept-Language 决定。
+/api/v* 下的接口按 IP 或 X-API-Key 限流，响应头带 RateLimit-Limit、RateLimit-Remaining、RateLimit-Reset、RateLimit-Policy，超出时返回 429 与 Retry-After。`
+
+// Build 生成文档；version 为 env.version，决定路径前缀 /api/v<version>
+func Build(version string) *Document {
+	r := newRegistry()
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: "lending-backend API", Description: description, Version: version},
+		Servers: []Server{{Url: "/"}},
+		Tags:    tags,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: r.schemas,
+			SecuritySchemes: map[string]SecurityScheme{
+				"ApiKey":     {Type: "apiKey", In: "header", Name: "X-API-Key", Description: "可选；带 key 时按 key 的配额限流，否则按 IP 限流"},
+				"AdminToken": {Type: "http", Scheme: "bearer", Description: "config.toml 中的 admin.token"},
+			},
+		},
+	}
+	r.of(reflect.TypeOf(response.Response{}))
+	for _, op := range operations("/api/v" + version) {
+		path := openapiPath(op.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = r.operation(op)
+	}
+	return doc
+}
+
+var (
+	specOnce sync.Once
+	spec     []byte
+)
+
+// JSON 序列化后的文档；env.version 不支持热更新，按首次调用时的版本生成后缓存
+func JSON(version string) []byte {
+	specOnce.Do(func() {
+		var err error
+		if spec, err = Marshal(Build(version)); err != nil {
+			panic(err)
+		}
+	})
+	return spec
+}
+
+// Marshal 缩进两格、不转义 HTML 字符，与提交到仓库的 openapi.json 格式一致
+func Marshal(doc *Document) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// openapiPath gin 的 :name 与 *name 参数改为 {name}
+func openapiPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (r *registry) operation(op operation) *Operation {
+	o := &Operation{
+		OperationId: op.Id,
+		Summary:     op.Summary,
+		Description: op.Desc,
+		Tags:        []string{op.Tag},
+		Responses:   map[string]*Response{},
+	}
+	codes := append([]int{}, op.Codes...)
+	if op.Params != nil {
+		t := reflect.TypeOf(op.Params)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			p := Parameter{Name: f.Tag.Get("form"), In: "query"}
+			if uri := f.Tag.Get("uri"); uri != "" {
+				p = Parameter{Name: uri, In: "path", Required: true}
+			}
+			if p.Name == "" || p.Name == "-" {
+				continue
+			}
+			p.Schema = r.of(f.Type)
+			binding := f.Tag.Get("binding")
+			if applyBinding(p.Schema, binding) {
+				p.Required = true
+			}
+			codes = append(codes, bindingCodes(binding)...)
+			o.Parameters = append(o.Parameters, p)
+		}
+	}
+	if op.Cache {
+		o.Parameters = append(o.Parameters, Parameter{Name: "If-None-Match", In: "header", Description: "上次响应的 ETag，未变化时返回 304", Schema: &Schema{Type: "string"}})
+	}
+	if op.Body != nil {
+		t := reflect.TypeOf(op.Body)
+		for i := 0; i < t.NumField(); i++ {
+			codes = append(codes, bindingCodes(t.Field(i).Tag.Get("binding"))...)
+		}
+		o.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: r.of(t)}}}
+	}
+	if op.Upload != "" {
+		codes = append(codes, statecode.ParameterEmptyErr, statecode.ParameterErr)
+		o.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{"multipart/form-data": {Schema: &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{op.Upload: {Type: "string", Format: "binary"}},
+			Required:   []string{op.Upload},
+		}}}}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status)}
+	switch {
+	case op.Produces != "":
+		success.Content = map[string]MediaType{op.Produces: {Schema: &Schema{Type: "string"}}}
+	case op.Raw:
+		success.Content = map[string]MediaType{"application/json": {Schema: r.of(reflect.TypeOf(op.Result))}}
+	default:
+		// 统一响应结构，data 为具体类型
+		success.Content = map[string]MediaType{"application/json": {Schema: &Schema{AllOf: []*Schema{
+			{Ref: refPrefix + "Response"},
+			{Type: "object", Properties: map[string]*Schema{"data": r.of(reflect.TypeOf(op.Result))}, Required: []string{"data"}},
+		}}}}
+	}
+	if op.Cache {
+		success.Headers = map[string]Header{
+			"ETag":          {Description: "列表内容的哈希", Schema: &Schema{Type: "string"}},
+			"Cache-Control": {Schema: &Schema{Type: "string"}},
+		}
+		o.Responses[strconv.Itoa(http.StatusNotModified)] = &Response{Description: "与 If-None-Match 一致，内容未变化"}
+	}
+	o.Responses[strconv.Itoa(status)] = success
+	if op.Unavailable {
+		o.Responses[strconv.Itoa(http.StatusServiceUnavailable)] = &Response{Description: "依赖不可用或数据过期", Content: success.Content}
+	}
+
+	if !op.Raw || len(codes) > 0 {
+		codes = append(codes, statecode.CommonErrServerErr)
+	}
+	if op.Limited {
+		codes = append(codes, statecode.TooManyRequests, statecode.ApiKeyInvalid)
+		o.Security = []map[string][]string{{}, {"ApiKey": {}}}
+	}
+	if op.Admin {
+		codes = append(codes, statecode.Unauthorized, statecode.Forbidden)
+		o.Security = []map[string][]string{{"AdminToken": {}}, {"AdminToken": {}, "ApiKey": {}}}
+	}
+	for status, desc := range errorResponses(codes) {
+		if o.Responses[status] == nil {
+			o.Responses[status] = &Response{Description: desc, Content: map[string]MediaType{"application/json": {Schema: &Schema{Ref: refPrefix + "Response"}}}}
+		}
+	}
+	return o
+}
+
+// bindingCodes 参数校验失败时可能返回的错误码，与 api/validate 中 errCode 的规则一致
+func bindingCodes(binding string) []int {
+	if binding == "" {
+		return nil
+	}
+	codes := []int{statecode.ParameterErr}
+	for _, rule := range strings.Split(binding, ",") {
+		switch rule {
+		case "required":
+			codes = append(codes, statecode.ParameterEmptyErr)
+		case "chain_id":
+			codes = append(codes, statecode.ChainIdEmpty, statecode.ChainIdErr)
+		case "eth_address":
+			codes = append(codes, statecode.AddressErr)
+		}
+	}
+	return codes
+}
+
+// errorResponses 按 HTTP 状态码归并错误码，说明中列出错误码与文案
+func errorResponses(codes []int) map[string]string {
+	sort.Ints(codes)
+	byStatus := map[string][]string{}
+	for i, code := range codes {
+		if i > 0 && codes[i-1] == code {
+			continue
+		}
+		status := strconv.Itoa(statecode.HTTPStatus(code))
+		byStatus[status] = append(byStatus[status], fmt.Sprintf("%d %s", code, statecode.GetMsg(code, statecode.LangZh)))
+	}
+	desc := make(map[string]string, len(byStatus))
+	for status, lines := range byStatus {
+		desc[status] = strings.Join(lines, "；")
+	}
+	return desc
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// 修改响应结构、请求参数或 operations 后未重新生成时失败
+func TestSpecUpToDate(t *testing.T) {
+	want, err := Marshal(Build(SpecVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("api/openapi/openapi.json is out of date, run go generate ./api/openapi")
+	}
+}
+
+func TestClientUpToDate(t *testing.T) {
+	want, err := GenerateClient(Build(SpecVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../client/client_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("client/client_gen.go is out of date, run go generate ./api/openapi")
+	}
+}
+
+func TestBuild(t *testing.T) {
+	doc := Build("2")
+	op := doc.Paths["/api/v2/auctions/{id}/bids"]["get"]
+	if op == nil || op.OperationId != "AuctionBids" {
+		t.Fatalf("auction bids = %+v", op)
+	}
+	if len(op.Parameters) != 3 || op.Parameters[0].In != "path" || !op.Parameters[0].Required || *op.Parameters[1].Schema.Minimum != 1 {
+		t.Fatalf("parameters = %+v", op.Parameters)
+	}
+	for _, status := range []string{"200", "400", "401", "404", "429", "500"} {
+		if op.Responses[status] == nil {
+			t.Fatalf("missing %s response", status)
+		}
+	}
+	if op.Responses["200"].Content["application/json"].Schema.AllOf[1].Properties["data"].Ref != refPrefix+"AuctionBids" {
+		t.Fatal("success response is not wrapped in Response")
+	}
+
+	// 请求与响应中的同名结构分别登记
+	search := doc.Components.Schemas["SearchRequest"]
+	if search == nil || doc.Components.Schemas["Search"] == nil || len(search.Required) != 1 || search.Required[0] != "chain_id" {
+		t.Fatalf("search request = %+v", search)
+	}
+	// 可空的引用包在 allOf 中
+	if s := doc.Components.Schemas["JobInfo"].Properties["last_run"]; len(s.AllOf) != 1 || !s.Nullable {
+		t.Fatalf("last_run = %+v", s)
+	}
+	// 嵌入的结构体展开，json:"-" 的字段不出现
+	auction := doc.Components.Schemas["AuctionInfo"]
+	if auction.Properties["auction_id"] == nil || auction.Properties["status"] == nil || auction.Properties["Id"] != nil {
+		t.Fatalf("auction info = %+v", auction.Properties)
+	}
+	if doc.Paths["/healthz"]["get"].Security != nil || doc.Paths["/api/v2/admin/jobs"]["get"].Security[0]["AdminToken"] == nil {
+		t.Fatal("security requirements")
+	}
+}
+
+func TestGoName(t *testing.T) {
+	cases := map[string]string{
+		"pool_id":                "PoolID",
+		"autoLiquidateThreshold": "AutoLiquidateThreshold",
+		"logoURI":                "LogoURI",
+		"If-None-Match":          "IfNoneMatch",
+		"highest_bid_usd":        "HighestBidUSD",
+	}
+	for in, want := range cases {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := lowerFirst("ChainID"); got != "chainID" {
+		t.Errorf("lowerFirst = %q", got)
+	}
+	if got := lowerFirst("ID"); got != "id" {
+		t.Errorf("lowerFirst = %q", got)
+	}
+}
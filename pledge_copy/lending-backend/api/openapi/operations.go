@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"net/http"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models"
+	"lending-copy/api/models/request"
+	"lending-copy/api/models/response"
+)
+
+// operation 一个路由的接口说明，与 routes.InitRoute 一一对应。
+// Params 为绑定 uri/form 参数的请求结构，Body 为 JSON 请求体，Result 为统一响应结构中 data 的类型；
+// Raw 表示响应不包在统一响应结构中，Produces 为非 JSON 响应的内容类型
+type operation struct {
+	Id       string
+	Method   string
+	Path     string
+	Tag      string
+	Summary  string
+	Desc     string
+	Params   interface{}
+	Body     interface{}
+	Upload   string
+	Result   interface{}
+	Raw      bool
+	Produces string
+	Status   int
+	// Unavailable 依赖不可用时以同样的结构返回 503
+	Unavailable bool
+	// Cache 响应带 ETag，支持 If-None-Match 返回 304
+	Cache   bool
+	Limited bool
+	Admin   bool
+	// Codes 业务错误码，参数校验、限流、鉴权与服务器错误的错误码会自动补上
+	Codes []int
+}
+
+var tags = []Tag{
+	{Name: "pool", Description: "借贷池"},
+	{Name: "auction", Description: "拍卖"},
+	{Name: "admin", Description: "管理接口，需要 Authorization: Bearer <admin.token>"},
+	{Name: "ops", Description: "探活、监控与接口文档"},
+}
+
+// operations prefix 为 /api/v + env.version
+func operations(prefix string) []operation {
+	return []operation{
+		{Id: "Metrics", Method: http.MethodGet, Path: "/metrics", Tag: "ops", Summary: "Prometheus 指标", Raw: true, Produces: "text/plain"},
+		{Id: "Healthz", Method: http.MethodGet, Path: "/healthz", Tag: "ops", Summary: "存活检查", Result: response.Health{}, Raw: true},
+		{Id: "Readyz", Method: http.MethodGet, Path: "/readyz", Tag: "ops", Summary: "就绪检查", Desc: "MySQL、Redis、RPC 任一不可用返回 503", Result: response.Health{}, Raw: true, Unavailable: true},
+		{Id: "Status", Method: http.MethodGet, Path: "/status", Tag: "ops", Summary: "同步进度与数据新鲜度", Desc: "超过 health.max_sync_age 未成功同步时返回 503", Result: response.SyncStatus{}, Raw: true, Unavailable: true},
+
+		{Id: "PoolBaseInfo", Method: http.MethodGet, Path: prefix + "/poolBaseInfo", Tag: "pool", Summary: "借贷池基础信息", Params: request.PoolBaseInfo{}, Result: []models.PoolBaseInfoRes{}, Limited: true},
+		{Id: "PoolDataInfo", Method: http.MethodGet, Path: prefix + "/poolDataInfo", Tag: "pool", Summary: "借贷池数据", Params: request.PoolDataInfo{}, Result: []models.PoolDataInfoRes{}, Limited: true},
+		{Id: "TokenList", Method: http.MethodGet, Path: prefix + "/token", Tag: "pool", Summary: "代币列表", Desc: "成功时按 Uniswap token-list 规范直接返回列表，错误仍使用统一响应结构；chain_id 为空时返回全部链", Params: request.TokenList{}, Result: response.TokenList{}, Raw: true, Cache: true, Limited: true, Codes: []int{statecode.TokenListEmpty}},
+		{Id: "SearchPools", Method: http.MethodPost, Path: prefix + "/pool/search", Tag: "pool", Summary: "搜索借贷池", Desc: "每次请求消耗 rate_limit.search_cost 个令牌", Body: request.Search{}, Result: response.Search{}, Limited: true},
+
+		{Id: "UploadTokenLogo", Method: http.MethodPost, Path: prefix + "/admin/tokens/:chain_id/:address/logo", Tag: "admin", Summary: "上传代币 logo", Desc: "文件不超过 1 MiB，统一转为 PNG 后写回 token_info.logo", Params: request.TokenLogo{}, Upload: "file", Result: response.TokenLogo{}, Limited: true, Admin: true, Codes: []int{statecode.TokenNotExist, statecode.LogoTooLarge, statecode.LogoInvalid}},
+		{Id: "ListJobs", Method: http.MethodGet, Path: prefix + "/admin/jobs", Tag: "admin", Summary: "定时任务列表", Result: response.JobList{}, Limited: true, Admin: true},
+		{Id: "JobRuns", Method: http.MethodGet, Path: prefix + "/admin/jobs/:name/runs", Tag: "admin", Summary: "定时任务执行记录", Params: request.JobRuns{}, Result: response.JobRuns{}, Limited: true, Admin: true, Codes: []int{statecode.JobNotExist}},
+		{Id: "RunJob", Method: http.MethodPost, Path: prefix + "/admin/jobs/:name/run", Tag: "admin", Summary: "立即执行定时任务", Desc: "触发发给定时任务进程后即返回 202，执行结果见执行记录", Params: request.JobTrigger{}, Result: response.JobTrigger{}, Status: http.StatusAccepted, Limited: true, Admin: true, Codes: []int{statecode.JobNotExist, statecode.SchedulerOffline}},
+
+		{Id: "ListAuctions", Method: http.MethodGet, Path: prefix + "/auctions", Tag: "auction", Summary: "拍卖列表", Params: request.AuctionList{}, Result: response.AuctionList{}, Limited: true},
+		{Id: "AuctionBids", Method: http.MethodGet, Path: prefix + "/auctions/:id/bids", Tag: "auction", Summary: "拍卖出价记录", Params: request.AuctionBids{}, Result: response.AuctionBids{}, Limited: true, Codes: []int{statecode.AuctionNotExist}},
+		{Id: "UserRefunds", Method: http.MethodGet, Path: prefix + "/users/:address/refunds", Tag: "auction", Summary: "用户待领取与已领取的退款", Params: request.UserRefunds{}, Result: response.UserRefunds{}, Limited: true},
+
+		{Id: "OpenAPI", Method: http.MethodGet, Path: prefix + "/openapi.json", Tag: "ops", Summary: "本文档", Result: map[string]interface{}{}, Raw: true, Limited: true},
+		{Id: "Docs", Method: http.MethodGet, Path: prefix + "/docs", Tag: "ops", Summary: "接口文档页面", Raw: true, Produces: "text/html", Limited: true},
+	}
+}
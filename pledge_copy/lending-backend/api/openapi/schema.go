@@ -0,0 +1,172 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const refPrefix = "#/components/schemas/"
+
+// registry 按 Go 类型生成 components.schemas；结构体以类型名登记，api/models/request 中的类型加 Request 后缀，
+// 避免与同名的响应结构冲突。不同类型登记为同一个名字时 panic，由测试发现
+type registry struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func newRegistry() *registry {
+	return &registry{schemas: map[string]*Schema{}, types: map[string]reflect.Type{}}
+}
+
+func schemaName(t reflect.Type) string {
+	if strings.HasSuffix(t.PkgPath(), "/request") {
+		return t.Name() + "Request"
+	}
+	return t.Name()
+}
+
+// of 返回类型对应的 schema，结构体返回 $ref
+func (r *registry) of(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := r.of(t.Elem())
+		if s.Ref != "" {
+			// 3.0 中 $ref 的同级字段会被忽略，可空的引用需要包一层 allOf
+			return &Schema{AllOf: []*Schema{s}, Nullable: true}
+		}
+		s.Nullable = true
+		return s
+	case reflect.Struct:
+		return &Schema{Ref: refPrefix + r.define(t)}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.of(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "uint64", Minimum: float(0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	}
+	panic(fmt.Sprintf("openapi: unsupported type %s", t))
+}
+
+func (r *registry) define(t reflect.Type) string {
+	name := schemaName(t)
+	if prev, ok := r.types[name]; ok {
+		if prev != t {
+			panic(fmt.Sprintf("openapi: schema %s is used by both %s and %s", name, prev, t))
+		}
+		return name
+	}
+	r.types[name] = t
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	r.schemas[name] = s
+	r.fields(t, s)
+	return name
+}
+
+// fields 按 encoding/json 的规则展开字段：json:"-" 跳过，未命名的嵌入结构体展开到外层；
+// 有 binding 标签时按其中的 required 判断必填，否则没有 omitempty 即为必填
+func (r *registry) fields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := splitTag(tag)
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			r.fields(f.Type, s)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		prop := r.of(f.Type)
+		required := !strings.Contains(opts, "omitempty")
+		if binding, ok := f.Tag.Lookup("binding"); ok {
+			required = applyBinding(prop, binding)
+		}
+		s.Properties[name] = prop
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+func splitTag(tag string) (name, opts string) {
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+// rules api/validate 中自定义校验规则对应的约束
+var rules = map[string]Schema{
+	"chain_id":     {Description: "config.toml 中 test_net / main_net 的链 ID"},
+	"eth_address":  {Pattern: "^(0x)?[0-9a-fA-F]{40}$"},
+	"token_symbol": {Pattern: "^[A-Za-z0-9._-]{1,20}$"},
+	"pool_state":   {Description: "合约 PoolState，依次为 MATCH、EXECUTION、FINISH、LIQUIDATION、UNDONE", Enum: []string{"0", "1", "2", "3", "4"}},
+}
+
+// applyBinding 把 binding 标签中的校验规则写入 schema，返回是否必填
+func applyBinding(s *Schema, binding string) bool {
+	required := false
+	for _, rule := range strings.Split(binding, ",") {
+		key, value := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			key, value = rule[:i], rule[i+1:]
+		}
+		switch key {
+		case "required":
+			required = true
+		case "min", "gte":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				s.Minimum = &n
+			}
+		case "max", "lte":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				s.Maximum = &n
+			}
+		case "oneof":
+			s.Enum = strings.Fields(value)
+		default:
+			if c, ok := rules[key]; ok {
+				if c.Description != "" {
+					s.Description = c.Description
+				}
+				if c.Pattern != "" {
+					s.Pattern = c.Pattern
+				}
+				if c.Enum != nil {
+					s.Enum = c.Enum
+				}
+			}
+		}
+	}
+	return required
+}
+
+func float(n float64) *float64 {
+	return &n
+}
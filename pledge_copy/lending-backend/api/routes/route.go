@@ -1,18 +1,57 @@
 package routes
 
 import (
+	"lending-copy/api/common/statecode"
 	"lending-copy/api/controllers"
+	"lending-copy/api/middlewares"
+	"lending-copy/api/models/response"
 	"lending-copy/config"
+	"lending-copy/metrics"
 
 	"github.com/gin-gonic/gin"
 )
 
 func InitRoute(e *gin.Engine) *gin.Engine {
-	v1 := e.Group("/api/v" + config.Config.Env.Version)
+	e.NoRoute(func(c *gin.Context) {
+		res := response.Gin{Res: c}
+		res.Response(c, statecode.RouteNotFound, nil)
+	})
+
+	e.GET("/metrics", gin.WrapH(metrics.Handler()))
+	healthController := controllers.HealthController{}
+	e.GET("/healthz", healthController.Healthz)
+	e.GET("/readyz", healthController.Readyz)
+	e.GET("/status", healthController.Status)
+
+	prefix := "/api/v" + config.Config.Env.Version
+	// 搜索每个池子都要再查一次 pooldata，按 rate_limit.search_cost 计费，其他接口每次消耗一个令牌
+	v1 := e.Group(prefix, middlewares.RateLimit(func(c *gin.Context) int {
+		if c.FullPath() == prefix+"/pool/search" {
+			return config.Current().RateLimit.SearchCost
+		}
+		return 1
+	}))
 	poolController := controllers.PoolController{}
 	v1.GET("/poolBaseInfo", poolController.PoolBaseInfo)
 	v1.GET("/poolDataInfo", poolController.PoolDataInfo)
 	v1.GET("/token", poolController.TokenList)
 	v1.POST("/pool/search", poolController.Search)
+
+	admin := v1.Group("/admin", middlewares.AdminAuth())
+	admin.POST("/tokens/:chain_id/:address/logo", poolController.UploadTokenLogo)
+	jobController := controllers.JobController{}
+	admin.GET("/jobs", jobController.Jobs)
+	admin.GET("/jobs/:name/runs", jobController.JobRuns)
+	admin.POST("/jobs/:name/run", jobController.RunJob)
+
+	auctionController := controllers.AuctionController{}
+	v1.GET("/auctions", auctionController.Auctions)
+	v1.GET("/auctions/:id/bids", auctionController.AuctionBids)
+	v1.GET("/users/:address/refunds", auctionController.UserRefunds)
+
+	// 接口说明见 api/openapi，新增或修改路由时同步更新 operations
+	docsController := controllers.DocsController{}
+	v1.GET("/openapi.json", docsController.Spec)
+	v1.GET("/docs", docsController.Docs)
 	return e
 }
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"lending-copy/api/openapi"
+	"lending-copy/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+var pathParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+// 注册的路由与 api/openapi 中的接口说明必须一一对应
+func TestRoutesMatchSpec(t *testing.T) {
+	prev := config.Config
+	defer func() { config.Config = prev }()
+	config.Config = &config.Conf{}
+	config.Config.Env.Version = openapi.SpecVersion
+
+	gin.SetMode(gin.TestMode)
+	e := InitRoute(gin.New())
+	doc := openapi.Build(openapi.SpecVersion)
+
+	registered := map[string]bool{}
+	for _, r := range e.Routes() {
+		path := pathParam.ReplaceAllString(r.Path, "{$1}")
+		registered[r.Method+" "+path] = true
+		if doc.Paths[path][strings.ToLower(r.Method)] == nil {
+			t.Errorf("%s %s is not documented in api/openapi operations", r.Method, path)
+		}
+	}
+	for path, item := range doc.Paths {
+		for method := range item {
+			if !registered[strings.ToUpper(method)+" "+path] {
+				t.Errorf("%s %s is documented but not registered", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
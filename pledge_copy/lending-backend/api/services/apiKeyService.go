@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"lending-copy/api/models"
+)
+
+// ApiKeyHeader 调用方携带 API key 的请求头
+const ApiKeyHeader = "X-API-Key"
+
+const (
+	apiKeyPrefix = "lk_"
+	// apiKeyCacheTTL 查询结果（包括不存在的 key）在进程内缓存的时长，吊销与配额修改最迟在该时长后生效
+	apiKeyCacheTTL = time.Minute
+	// apiKeyCacheMax 缓存条目上限，达到时先清理过期条目，仍满则不再缓存，防止随机 key 撑大内存
+	apiKeyCacheMax = 10000
+)
+
+type cachedApiKey struct {
+	key     *models.ApiKey
+	expires time.Time
+}
+
+var apiKeyCache = struct {
+	sync.Mutex
+	entries map[string]cachedApiKey
+}{entries: map[string]cachedApiKey{}}
+
+type ApiKeyService struct{}
+
+func NewApiKey() *ApiKeyService {
+	return &ApiKeyService{}
+}
+
+// Generate 生成新的明文 key，返回明文、用于辨认的前缀与入库的哈希；明文只在创建时展示一次
+func (s *ApiKeyService) Generate() (raw, prefix, hash string, err error) {
+	b := make([]byte, 24)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	raw = apiKeyPrefix + hex.EncodeToString(b)
+	return raw, raw[:len(apiKeyPrefix)+6], s.Hash(raw), nil
+}
+
+func (s *ApiKeyService) Hash(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// Lookup 查找未吊销的 key，不存在时返回 nil；结果缓存 apiKeyCacheTTL
+func (s *ApiKeyService) Lookup(ctx context.Context, raw string) (*models.ApiKey, error) {
+	hash := s.Hash(raw)
+	now := time.Now()
+	apiKeyCache.Lock()
+	if c, ok := apiKeyCache.entries[hash]; ok && now.Before(c.expires) {
+		apiKeyCache.Unlock()
+		return c.key, nil
+	}
+	apiKeyCache.Unlock()
+
+	key, err := models.NewApiKey().GetActive(ctx, hash)
+	if err != nil && !models.IsNotFound(err) {
+		return nil, err
+	}
+	apiKeyCache.Lock()
+	defer apiKeyCache.Unlock()
+	if len(apiKeyCache.entries) >= apiKeyCacheMax {
+		for h, c := range apiKeyCache.entries {
+			if !now.Before(c.expires) {
+				delete(apiKeyCache.entries, h)
+			}
+		}
+	}
+	if len(apiKeyCache.entries) < apiKeyCacheMax {
+		apiKeyCache.entries[hash] = cachedApiKey{key: key, expires: now.Add(apiKeyCacheTTL)}
+	}
+	return key, nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models"
+	"lending-copy/api/models/request"
+	"lending-copy/api/models/response"
+	"lending-copy/config"
+	"lending-copy/log"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type AuctionService struct{}
+
+func NewAuction() *AuctionService {
+	return &AuctionService{}
+}
+
+// target 索引任务写库时使用的 chain_id 与校验和合约地址
+func (s *AuctionService) target() (string, string) {
+	return config.Config.Auction.ChainId, common.HexToAddress(config.Config.Auction.AuctionAddr).Hex()
+}
+
+func (s *AuctionService) AuctionList(ctx context.Context, req *request.AuctionList) (int, *response.AuctionList) {
+	chainId, contract := s.target()
+	err, total, rows := models.NewAuctionInfo().Pagination(ctx, chainId, contract, req)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	return statecode.CommonSuccess, &response.AuctionList{Rows: rows, Count: total}
+}
+
+func (s *AuctionService) AuctionBids(ctx context.Context, req *request.AuctionBids) (int, *response.AuctionBids) {
+	chainId, contract := s.target()
+	err, auction := models.NewAuctionInfo().GetAuction(ctx, chainId, contract, req.AuctionId)
+	if err != nil {
+		if models.IsNotFound(err) {
+			return statecode.AuctionNotExist, nil
+		}
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	err, total, rows := models.NewAuctionInfo().Bids(ctx, chainId, contract, req)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	return statecode.CommonSuccess, &response.AuctionBids{Auction: auction, Rows: rows, Count: total}
+}
+
+func (s *AuctionService) UserRefunds(ctx context.Context, req *request.UserRefunds) (int, *response.UserRefunds) {
+	chainId, contract := s.target()
+	err, pending := models.NewAuctionInfo().PendingRefunds(ctx, chainId, contract, req.Address)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	err, total, rows := models.NewAuctionInfo().Refunds(ctx, chainId, contract, req)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	return statecode.CommonSuccess, &response.UserRefunds{Address: req.Address, Pending: pending, Rows: rows, Count: total}
+}
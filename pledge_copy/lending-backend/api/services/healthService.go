@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"lending-copy/api/models/response"
+	"lending-copy/config"
+	"lending-copy/db"
+	"lending-copy/log"
+	"lending-copy/metrics"
+	schedmodels "lending-copy/schedule/models"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// healthTimeout 单个依赖检查（MySQL / Redis / RPC）的超时
+const healthTimeout = 3 * time.Second
+
+const (
+	HealthOK          = "ok"
+	HealthUnavailable = "unavailable"
+	HealthStale       = "stale"
+)
+
+type HealthService struct{}
+
+func NewHealth() *HealthService {
+	return &HealthService{}
+}
+
+// syncTarget 配置中启用的同步任务；合约地址为零地址的任务不会运行，也不参与检查
+type syncTarget struct {
+	job     string
+	chainId string
+	netURL  string
+	blocks  bool
+}
+
+func syncTargets(conf *config.Conf) []syncTarget {
+	var targets []syncTarget
+	if configured(conf.TestNet.LendingPoolAddr) {
+		targets = append(targets, syncTarget{job: schedmodels.SyncJobPool, chainId: conf.TestNet.ChainId, netURL: conf.TestNet.NetUrl})
+	}
+	if configured(conf.Auction.AuctionAddr) {
+		targets = append(targets, syncTarget{job: schedmodels.SyncJobAuction, chainId: conf.Auction.ChainId, netURL: conf.Auction.NetUrl, blocks: true})
+	}
+	return targets
+}
+
+func configured(addr string) bool {
+	return common.IsHexAddress(addr) && common.HexToAddress(addr) != (common.Address{})
+}
+
+// Ready 依次检查 MySQL、Redis 与已启用同步任务的 RPC 节点，任一失败即未就绪
+func (s *HealthService) Ready(ctx context.Context) (bool, []response.HealthCheck) {
+	checks := []response.HealthCheck{
+		runCheck(ctx, "mysql", func(ctx context.Context) error {
+			sqlDB, err := db.Mysql.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}),
+		runCheck(ctx, "redis", db.RedisPing),
+	}
+	seen := map[string]bool{}
+	for _, t := range syncTargets(config.Current()) {
+		if seen[t.netURL] {
+			continue
+		}
+		seen[t.netURL] = true
+		netURL := t.netURL
+		checks = append(checks, runCheck(ctx, "rpc:"+t.chainId, func(ctx context.Context) error {
+			_, err := blockNumber(ctx, netURL)
+			return err
+		}))
+	}
+	ready := true
+	for _, c := range checks {
+		if c.Status != HealthOK {
+			ready = false
+			log.Ctx(ctx).Sugar().Warn("readyz ", c.Name, " ", c.Error)
+		}
+	}
+	return ready, checks
+}
+
+func runCheck(ctx context.Context, name string, fn func(context.Context) error) response.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+	start := time.Now()
+	err := fn(ctx)
+	check := response.HealthCheck{Name: name, Status: HealthOK, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		check.Status = "error"
+		check.Error = err.Error()
+	}
+	return check
+}
+
+func blockNumber(ctx context.Context, netURL string) (uint64, error) {
+	c, err := metrics.DialEth(netURL)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+	return c.BlockNumber(ctx)
+}
+
+// headResult 按区块同步的任务所在链的最新区块
+type headResult struct {
+	block uint64
+	err   error
+}
+
+// Status 汇总各同步任务最近一次成功的时间与区块进度；健康与否只看数据新鲜度，RPC 失败只记录在 head_error
+func (s *HealthService) Status(ctx context.Context) *response.SyncStatus {
+	conf := config.Current()
+	maxAge := conf.Health.MaxSyncAge.Duration
+	rows, err := schedmodels.NewSyncStatus().List(ctx)
+	if err != nil {
+		log.Ctx(ctx).Sugar().Error("status list sync_status ", err)
+		return &response.SyncStatus{Status: HealthUnavailable, Error: err.Error(), MaxSyncAge: maxAge.String(), Syncs: []response.SyncJob{}}
+	}
+	targets := syncTargets(conf)
+	heads := map[string]headResult{}
+	for _, t := range targets {
+		if !t.blocks {
+			continue
+		}
+		hctx, cancel := context.WithTimeout(ctx, healthTimeout)
+		block, err := blockNumber(hctx, t.netURL)
+		cancel()
+		heads[t.job+":"+t.chainId] = headResult{block: block, err: err}
+	}
+	return buildSyncStatus(targets, rows, heads, time.Now(), maxAge)
+}
+
+func buildSyncStatus(targets []syncTarget, rows []schedmodels.SyncStatus, heads map[string]headResult, now time.Time, maxAge time.Duration) *response.SyncStatus {
+	byKey := map[string]schedmodels.SyncStatus{}
+	for _, r := range rows {
+		byKey[r.Job+":"+r.ChainId] = r
+	}
+	result := &response.SyncStatus{Status: HealthOK, MaxSyncAge: maxAge.String(), Syncs: []response.SyncJob{}}
+	for _, t := range targets {
+		key := t.job + ":" + t.chainId
+		job := response.SyncJob{Job: t.job, ChainId: t.chainId, Stale: true}
+		row, synced := byKey[key]
+		if synced {
+			age := now.Unix() - row.LastSuccessAt
+			job.LastSuccessAt = row.LastSuccessAt
+			job.AgeSeconds = &age
+			job.Stale = time.Duration(age)*time.Second > maxAge
+		}
+		if t.blocks {
+			if synced {
+				indexed := row.LastBlock
+				job.IndexedBlock = &indexed
+			}
+			if h, ok := heads[key]; ok {
+				if h.err != nil {
+					job.HeadError = h.err.Error()
+				} else {
+					head := h.block
+					job.HeadBlock = &head
+					if job.IndexedBlock != nil && head >= *job.IndexedBlock {
+						lag := head - *job.IndexedBlock
+						job.LagBlocks = &lag
+					}
+				}
+			}
+		}
+		if job.Stale {
+			result.Status = HealthStale
+		}
+		result.Syncs = append(result.Syncs, job)
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"lending-copy/config"
+	schedmodels "lending-copy/schedule/models"
+)
+
+func TestSyncTargets(t *testing.T) {
+	conf := &config.Conf{}
+	conf.TestNet.ChainId = "97"
+	conf.TestNet.LendingPoolAddr = "0x0000000000000000000000000000000000000000"
+	conf.Auction.ChainId = "11155111"
+	conf.Auction.AuctionAddr = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	targets := syncTargets(conf)
+	if len(targets) != 1 || targets[0].job != schedmodels.SyncJobAuction || !targets[0].blocks {
+		t.Fatalf("targets = %+v, want only the auction indexer", targets)
+	}
+}
+
+func TestBuildSyncStatus(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	pool := syncTarget{job: schedmodels.SyncJobPool, chainId: "97"}
+	auction := syncTarget{job: schedmodels.SyncJobAuction, chainId: "11155111", blocks: true}
+	rows := []schedmodels.SyncStatus{
+		{Job: schedmodels.SyncJobPool, ChainId: "97", LastSuccessAt: now.Unix() - 60},
+		{Job: schedmodels.SyncJobAuction, ChainId: "11155111", LastSuccessAt: now.Unix() - 30, LastBlock: 900},
+	}
+
+	st := buildSyncStatus([]syncTarget{pool, auction}, rows, map[string]headResult{"auction_sync:11155111": {block: 906}}, now, 10*time.Minute)
+	if st.Status != HealthOK || len(st.Syncs) != 2 {
+		t.Fatalf("status = %+v", st)
+	}
+	p, a := st.Syncs[0], st.Syncs[1]
+	if p.AgeSeconds == nil || *p.AgeSeconds != 60 || p.Stale || p.IndexedBlock != nil {
+		t.Fatalf("pool sync = %+v", p)
+	}
+	if *a.IndexedBlock != 900 || *a.HeadBlock != 906 || *a.LagBlocks != 6 || a.Stale {
+		t.Fatalf("auction sync = %+v", a)
+	}
+
+	// 超过 max_sync_age 或从未同步都算过期；RPC 失败只记录不影响状态
+	st = buildSyncStatus([]syncTarget{pool, auction}, rows, map[string]headResult{"auction_sync:11155111": {err: errors.New("dial timeout")}}, now, 45*time.Second)
+	if st.Status != HealthStale || !st.Syncs[0].Stale || st.Syncs[1].Stale || st.Syncs[1].HeadError != "dial timeout" || st.Syncs[1].LagBlocks != nil {
+		t.Fatalf("status = %+v", st)
+	}
+	st = buildSyncStatus([]syncTarget{pool}, nil, nil, now, time.Hour)
+	if st.Status != HealthStale || st.Syncs[0].AgeSeconds != nil || st.Syncs[0].LastSuccessAt != 0 {
+		t.Fatalf("never synced = %+v", st.Syncs[0])
+	}
+	if st = buildSyncStatus(nil, nil, nil, now, time.Hour); st.Status != HealthOK || len(st.Syncs) != 0 {
+		t.Fatalf("no targets = %+v", st)
+	}
+}
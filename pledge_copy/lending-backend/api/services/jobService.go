@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models/request"
+	"lending-copy/api/models/response"
+	"lending-copy/db"
+	"lending-copy/log"
+	schedmodels "lending-copy/schedule/models"
+)
+
+// JobService 定时任务的管理接口；任务定义与执行记录由定时任务进程写库，手动触发经 Redis 频道转发给它
+type JobService struct{}
+
+func NewJob() *JobService {
+	return &JobService{}
+}
+
+func (s *JobService) List(ctx context.Context) (int, *response.JobList) {
+	jobs, err := schedmodels.NewJob().List(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	last, err := schedmodels.NewJob().LastRuns(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	rows := make([]response.JobInfo, 0, len(jobs))
+	for _, j := range jobs {
+		info := response.JobInfo{Job: j}
+		if run, ok := last[j.Name]; ok {
+			info.LastRun = &run
+		}
+		rows = append(rows, info)
+	}
+	return statecode.CommonSuccess, &response.JobList{Rows: rows}
+}
+
+func (s *JobService) Runs(ctx context.Context, req *request.JobRuns) (int, *response.JobRuns) {
+	if code := s.exists(ctx, req.Name); code != statecode.CommonSuccess {
+		return code, nil
+	}
+	rows, total, err := schedmodels.NewJob().Runs(ctx, req.Name, req.Page, req.PageSize)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	return statecode.CommonSuccess, &response.JobRuns{Rows: rows, Count: total}
+}
+
+// Trigger 通知定时任务进程立即执行一次；没有进程订阅频道时返回 SchedulerOffline
+func (s *JobService) Trigger(ctx context.Context, req *request.JobTrigger) (int, *response.JobTrigger) {
+	if code := s.exists(ctx, req.Name); code != statecode.CommonSuccess {
+		return code, nil
+	}
+	receivers, err := db.RedisPublish(ctx, schedmodels.JobTriggerChannel, req.Name)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	if receivers == 0 {
+		return statecode.SchedulerOffline, nil
+	}
+	log.Ctx(ctx).Sugar().Info("JobService trigger ", req.Name)
+	return statecode.CommonSuccess, &response.JobTrigger{Job: req.Name}
+}
+
+func (s *JobService) exists(ctx context.Context, name string) int {
+	ok, err := schedmodels.NewJob().Exists(ctx, name)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr
+	}
+	if !ok {
+		return statecode.JobNotExist
+	}
+	return statecode.CommonSuccess
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"lending-copy/api/common/statecode"
 	"lending-copy/api/models"
 	"lending-copy/log"
@@ -12,19 +13,19 @@ func NewPool() *poolService {
 	return &poolService{}
 }
 
-func (s *poolService) PoolBaseInfo(chainId int, result *[]models.PoolBaseInfoRes) int {
-	err := models.NewPoolBases().PoolBaseInfo(chainId, result)
+func (s *poolService) PoolBaseInfo(ctx context.Context, chainId int, result *[]models.PoolBaseInfoRes) int {
+	err := models.NewPoolBases().PoolBaseInfo(ctx, chainId, result)
 	if err != nil {
-		log.Logger.Error(err.Error())
+		log.Ctx(ctx).Error(err.Error())
 		return statecode.CommonErrServerErr
 	}
 	return statecode.CommonSuccess
 }
 
-func (s *poolService) PoolDataInfo(chainId int, result *[]models.PoolDataInfoRes) int {
-	err := models.NewPoolData().PoolDataInfo(chainId, result)
+func (s *poolService) PoolDataInfo(ctx context.Context, chainId int, result *[]models.PoolDataInfoRes) int {
+	err := models.NewPoolData().PoolDataInfo(ctx, chainId, result)
 	if err != nil {
-		log.Logger.Error(err.Error())
+		log.Ctx(ctx).Error(err.Error())
 		return statecode.CommonErrServerErr
 	}
 	return statecode.CommonSuccess
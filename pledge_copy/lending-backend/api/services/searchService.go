@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 
 	"lending-copy/api/common/statecode"
@@ -15,7 +16,7 @@ func NewSearch() *SearchService {
 	return &SearchService{}
 }
 
-func (c *SearchService) Search(req *request.Search) (int, int64, []models.Pool) {
+func (c *SearchService) Search(ctx context.Context, req *request.Search) (int, int64, []models.Pool) {
 	whereCondition := fmt.Sprintf(`chain_id='%v'`, req.ChainID)
 	if req.LendTokenSymbol != "" {
 		whereCondition += fmt.Sprintf(` and lend_token_symbol='%v'`, req.LendTokenSymbol)
@@ -23,9 +24,9 @@ func (c *SearchService) Search(req *request.Search) (int, int64, []models.Pool)
 	if req.State != "" {
 		whereCondition += fmt.Sprintf(` and state='%v'`, req.State)
 	}
-	err, total, data := models.NewPool().Pagination(req, whereCondition)
+	err, total, data := models.NewPool().Pagination(ctx, req, whereCondition)
 	if err != nil {
-		log.Logger.Error(err.Error())
+		log.Ctx(ctx).Error(err.Error())
 		return statecode.CommonErrServerErr, 0, nil
 	}
 	return statecode.CommonSuccess, total, data
@@ -1,21 +1,183 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
 	"lending-copy/api/common/statecode"
 	"lending-copy/api/models"
 	"lending-copy/api/models/request"
+	"lending-copy/api/models/response"
+	"lending-copy/db"
+	"lending-copy/log"
+	"lending-copy/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	tokenListName         = "Lending Copy Token List"
+	tokenListCacheSeconds = 60
+	tokenSymbolMaxLength  = 20
 )
 
+var tokenListTags = map[string]response.TagDefinition{
+	"lend":   {Name: "Lend asset", Description: "Token that can be supplied to at least one lending pool"},
+	"borrow": {Name: "Collateral asset", Description: "Token that can be pledged as collateral in at least one lending pool"},
+}
+
 type TokenList struct{}
 
+// TokenListResult 序列化好的 token list 与对应的 ETag，整体缓存在 Redis
+type TokenListResult struct {
+	ETag string `json:"etag"`
+	Body []byte `json:"body"`
+}
+
 func NewTokenList() *TokenList {
 	return &TokenList{}
 }
 
-func (c *TokenList) GetTokenList(req *request.TokenList) (int, []models.TokenList) {
-	err, tokenList := models.NewTokenListModel().GetTokenList(req)
+// GetTokenList ChainId 为 0 时返回全部链；版本号按 token-list 规范随代币增删改自动递增
+func (c *TokenList) GetTokenList(ctx context.Context, req *request.TokenList, baseURL string) (int, *TokenListResult) {
+	scope := "all"
+	if req.ChainId != 0 {
+		scope = strconv.Itoa(req.ChainId)
+	}
+	cacheKey := "token_list:" + scope
+	if cached, _ := db.RedisGet(ctx, cacheKey); len(cached) > 0 {
+		result := TokenListResult{}
+		if err := json.Unmarshal(cached, &result); err == nil && result.ETag != "" {
+			return statecode.CommonSuccess, &result
+		}
+	}
+
+	err, rows := models.NewTokenListModel().GetTokenList(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	err, usage := models.NewTokenListModel().TokenUsage(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	tokens := buildTokens(rows, usage, baseURL)
+	if len(tokens) == 0 {
+		return statecode.TokenListEmpty, nil
+	}
+
+	snapshot, _ := json.Marshal(tokens)
+	err, version := models.NewTokenListModel().SyncVersion(ctx, scope, string(snapshot), func(prevTokens string) int {
+		var prev []response.Token
+		if err := json.Unmarshal([]byte(prevTokens), &prev); err != nil {
+			return models.VersionBumpMajor
+		}
+		return versionBump(prev, tokens)
+	})
 	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
 		return statecode.CommonErrServerErr, nil
 	}
-	return statecode.CommonSuccess, tokenList
+
+	body, _ := json.Marshal(response.TokenList{
+		Name:      tokenListName,
+		Timestamp: version.Timestamp,
+		Version:   response.Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch},
+		Tokens:    tokens,
+		Keywords:  []string{"lending", "pledge"},
+		Tags:      tokenListTags,
+		LogoURI:   baseURL + "storage/img/logo.png",
+	})
+	result := &TokenListResult{ETag: `"` + utils.Md5(string(body)) + `"`, Body: body}
+	_ = db.RedisSet(ctx, cacheKey, result, tokenListCacheSeconds)
+	return statecode.CommonSuccess, result
+}
+
+// buildTokens 过滤掉不满足 schema 的记录（链 ID、地址、符号、精度），同链同地址只保留第一条
+func buildTokens(rows []models.TokenList, usage map[string]models.TokenUsage, baseURL string) []response.Token {
+	seen := map[string]bool{}
+	tokens := make([]response.Token, 0, len(rows))
+	for _, v := range rows {
+		chainID, err := strconv.Atoi(v.ChainId)
+		symbol := strings.TrimSpace(v.Symbol)
+		if err != nil || chainID < 1 || !strings.HasPrefix(v.Token, "0x") || !common.IsHexAddress(v.Token) ||
+			symbol == "" || strings.ContainsAny(symbol, " \t\r\n") || len(symbol) > tokenSymbolMaxLength ||
+			v.Decimals < 0 || v.Decimals > 255 {
+			log.Logger.Sugar().Warn("token list skip invalid token_info: chain_id=", v.ChainId, " token=", v.Token, " symbol=", v.Symbol)
+			continue
+		}
+		key := v.ChainId + ":" + strings.ToLower(v.Token)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		token := response.Token{
+			ChainID:  chainID,
+			Address:  common.HexToAddress(v.Token).Hex(),
+			Name:     symbol,
+			Symbol:   symbol,
+			Decimals: v.Decimals,
+			LogoURI:  logoURI(v.Logo, baseURL),
+		}
+		if u, ok := usage[key]; ok {
+			if u.LendPools > 0 {
+				token.Tags = append(token.Tags, "lend")
+			}
+			if u.BorrowPools > 0 {
+				token.Tags = append(token.Tags, "borrow")
+			}
+			token.Extensions = map[string]interface{}{"lendPools": u.LendPools, "borrowPools": u.BorrowPools}
+		}
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].ChainID != tokens[j].ChainID {
+			return tokens[i].ChainID < tokens[j].ChainID
+		}
+		return strings.ToLower(tokens[i].Address) < strings.ToLower(tokens[j].Address)
+	})
+	return tokens
+}
+
+// logoURI 相对路径按服务地址补全，schema 要求 logoURI 为完整 URI
+func logoURI(logo, baseURL string) string {
+	logo = strings.TrimSpace(logo)
+	if logo == "" || strings.Contains(logo, "://") {
+		return logo
+	}
+	return baseURL + strings.TrimPrefix(logo, "/")
+}
+
+// versionBump 与 @uniswap/token-lists 的 minVersionBump 一致：删除为 major，新增为 minor，其余字段变化为 patch
+func versionBump(prev, next []response.Token) int {
+	index := func(tokens []response.Token) map[string]string {
+		m := make(map[string]string, len(tokens))
+		for _, t := range tokens {
+			b, _ := json.Marshal(t)
+			m[strconv.Itoa(t.ChainID)+":"+strings.ToLower(t.Address)] = string(b)
+		}
+		return m
+	}
+	before, after := index(prev), index(next)
+	bump := models.VersionBumpNone
+	for key, old := range before {
+		cur, ok := after[key]
+		if !ok {
+			return models.VersionBumpMajor
+		}
+		if cur != old && bump < models.VersionBumpPatch {
+			bump = models.VersionBumpPatch
+		}
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			bump = models.VersionBumpMinor
+		}
+	}
+	return bump
 }
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"lending-copy/api/models"
+	"lending-copy/api/models/response"
+)
+
+const (
+	busd = "0xE0dFf7e5E2aB7b2E7A7CD4cC1D8B6a3E7C1f3bA1"
+	usdt = "0x55d398326f99059fF775485246999027B3197955"
+	weth = "0x2170Ed0880ac9A755fd29B2688956BD959F933F8"
+)
+
+func token(chainID int, address, symbol string, decimals int) response.Token {
+	return response.Token{ChainID: chainID, Address: address, Name: symbol, Symbol: symbol, Decimals: decimals}
+}
+
+func TestVersionBump(t *testing.T) {
+	base := []response.Token{token(56, usdt, "USDT", 18), token(56, weth, "WETH", 18)}
+	renamed := token(56, usdt, "USDT", 18)
+	renamed.Name = "Tether USD"
+	tagged := token(56, weth, "WETH", 18)
+	tagged.Tags = []string{"lend"}
+
+	cases := []struct {
+		name string
+		next []response.Token
+		want int
+	}{
+		{"no change", []response.Token{token(56, usdt, "USDT", 18), token(56, weth, "WETH", 18)}, models.VersionBumpNone},
+		{"reordered", []response.Token{token(56, weth, "WETH", 18), token(56, usdt, "USDT", 18)}, models.VersionBumpNone},
+		// 地址大小写不同视为同一代币，但 address 字段本身变化属于修改
+		{"address case", []response.Token{token(56, usdt, "USDT", 18), token(56, "0x2170ed0880ac9a755fd29b2688956bd959f933f8", "WETH", 18)}, models.VersionBumpPatch},
+		{"changed name", []response.Token{renamed, token(56, weth, "WETH", 18)}, models.VersionBumpPatch},
+		{"changed decimals", []response.Token{token(56, usdt, "USDT", 6), token(56, weth, "WETH", 18)}, models.VersionBumpPatch},
+		{"changed tags", []response.Token{token(56, usdt, "USDT", 18), tagged}, models.VersionBumpPatch},
+		{"added", append(append([]response.Token{}, base...), token(56, busd, "BUSD", 18)), models.VersionBumpMinor},
+		{"added and changed", []response.Token{renamed, token(56, weth, "WETH", 18), token(56, busd, "BUSD", 18)}, models.VersionBumpMinor},
+		// 同地址换链视为删除 + 新增
+		{"same address other chain", []response.Token{token(56, usdt, "USDT", 18), token(1, weth, "WETH", 18)}, models.VersionBumpMajor},
+		{"removed", []response.Token{token(56, usdt, "USDT", 18)}, models.VersionBumpMajor},
+		{"removed and added", []response.Token{token(56, usdt, "USDT", 18), token(56, busd, "BUSD", 18)}, models.VersionBumpMajor},
+		{"all removed", nil, models.VersionBumpMajor},
+	}
+	for _, c := range cases {
+		if got := versionBump(base, c.next); got != c.want {
+			t.Errorf("%s: versionBump = %d, want %d", c.name, got, c.want)
+		}
+	}
+
+	if got := versionBump(nil, base); got != models.VersionBumpMinor {
+		t.Errorf("from empty: versionBump = %d, want minor", got)
+	}
+}
+
+// 上一版本从数据库快照反序列化（extensions 中的数字变为 float64），内容不变时不应递增
+func TestVersionBumpSnapshotRoundTrip(t *testing.T) {
+	usage := map[string]models.TokenUsage{"56:" + "0x55d398326f99059ff775485246999027b3197955": {LendPools: 2, BorrowPools: 1}}
+	tokens := buildTokens([]models.TokenList{{ChainId: "56", Token: usdt, Symbol: "USDT", Decimals: 18, Logo: "storage/img/usdt.png"}}, usage, "https://api.example.com/")
+	snapshot, _ := json.Marshal(tokens)
+	var prev []response.Token
+	if err := json.Unmarshal(snapshot, &prev); err != nil {
+		t.Fatal(err)
+	}
+	if got := versionBump(prev, tokens); got != models.VersionBumpNone {
+		t.Fatalf("versionBump = %d, want none", got)
+	}
+}
+
+func TestBuildTokens(t *testing.T) {
+	rows := []models.TokenList{
+		{ChainId: "56", Token: weth, Symbol: " WETH ", Decimals: 18, Logo: "/storage/img/weth.png"},
+		{ChainId: "56", Token: "0x55d398326f99059ff775485246999027b3197955", Symbol: "USDT", Decimals: 18, Logo: "https://cdn.example.com/usdt.png"},
+		// 同链同地址（大小写不同）只保留第一条
+		{ChainId: "56", Token: usdt, Symbol: "USDT2", Decimals: 6},
+		{ChainId: "1", Token: usdt, Symbol: "USDT", Decimals: 6},
+		// 以下均不满足 schema
+		{ChainId: "", Token: busd, Symbol: "BUSD", Decimals: 18},
+		{ChainId: "0", Token: busd, Symbol: "BUSD", Decimals: 18},
+		{ChainId: "bsc", Token: busd, Symbol: "BUSD", Decimals: 18},
+		{ChainId: "56", Token: "E0dFf7e5E2aB7b2E7A7CD4cC1D8B6a3E7C1f3bA1", Symbol: "BUSD", Decimals: 18},
+		{ChainId: "56", Token: "0x1234", Symbol: "BUSD", Decimals: 18},
+		{ChainId: "56", Token: busd, Symbol: "  ", Decimals: 18},
+		{ChainId: "56", Token: busd, Symbol: "B USD", Decimals: 18},
+		{ChainId: "56", Token: busd, Symbol: "BUSDBUSDBUSDBUSDBUSDB", Decimals: 18},
+		{ChainId: "56", Token: busd, Symbol: "BUSD", Decimals: -1},
+		{ChainId: "56", Token: busd, Symbol: "BUSD", Decimals: 256},
+	}
+	usage := map[string]models.TokenUsage{
+		"56:0x55d398326f99059ff775485246999027b3197955": {LendPools: 2},
+		"56:0x2170ed0880ac9a755fd29b2688956bd959f933f8": {LendPools: 1, BorrowPools: 3},
+	}
+	tokens := buildTokens(rows, usage, "https://api.example.com/")
+
+	want := []struct {
+		chainID  int
+		address  string
+		symbol   string
+		decimals int
+		logo     string
+		tags     int
+	}{
+		{1, usdt, "USDT", 6, "", 0},
+		{56, weth, "WETH", 18, "https://api.example.com/storage/img/weth.png", 2},
+		{56, usdt, "USDT", 18, "https://cdn.example.com/usdt.png", 1},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens, want %d: %+v", len(tokens), len(want), tokens)
+	}
+	for i, w := range want {
+		got := tokens[i]
+		if got.ChainID != w.chainID || got.Address != w.address || got.Symbol != w.symbol || got.Name != w.symbol ||
+			got.Decimals != w.decimals || got.LogoURI != w.logo || len(got.Tags) != w.tags {
+			t.Errorf("tokens[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+	if ext := tokens[1].Extensions; ext["lendPools"] != 1 || ext["borrowPools"] != 3 {
+		t.Errorf("extensions = %v", ext)
+	}
+	if tokens[0].Extensions != nil {
+		t.Errorf("未使用的代币不应有 extensions: %v", tokens[0].Extensions)
+	}
+}
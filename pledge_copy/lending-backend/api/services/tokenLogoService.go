@@ -0,0 +1,230 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+	"strconv"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models"
+	"lending-copy/api/models/request"
+	"lending-copy/api/models/response"
+	"lending-copy/db"
+	"lending-copy/log"
+	"lending-copy/storage"
+)
+
+const (
+	// LogoSize 统一输出的 PNG 边长
+	LogoSize = 256
+	// 解码前按图片头部限制尺寸，防止小文件解压出超大位图
+	minLogoSide = 16
+	maxLogoSide = 4096
+)
+
+var (
+	errLogoType = errors.New("logo must be png, jpeg or gif")
+	errLogoSize = errors.New("logo dimensions out of range")
+)
+
+var allowedLogoTypes = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpeg",
+	"image/gif":  "gif",
+}
+
+type tokenLogoRepo interface {
+	UpdateLogo(ctx context.Context, chainId, token, logo string) (error, bool)
+}
+
+type TokenLogo struct {
+	store      storage.Storage
+	tokens     tokenLogoRepo
+	invalidate func(ctx context.Context, keys ...string)
+}
+
+func NewTokenLogo() *TokenLogo {
+	return &TokenLogo{
+		store:  storage.Store,
+		tokens: models.NewTokenListModel(),
+		invalidate: func(ctx context.Context, keys ...string) {
+			if err := db.RedisDelete(ctx, keys...); err != nil {
+				log.Ctx(ctx).Sugar().Warn("TokenLogo invalidate token list cache ", err)
+			}
+		},
+	}
+}
+
+// Upload 校验并统一为 LogoSize×LogoSize 的 PNG，按内容哈希存储后写回 token_info.logo
+func (s *TokenLogo) Upload(ctx context.Context, req *request.TokenLogo, baseURL string) (int, *response.TokenLogo) {
+	normalized, err := NormalizeLogo(req.Logo)
+	if err != nil {
+		log.Ctx(ctx).Sugar().Info("TokenLogo reject upload: ", err)
+		return statecode.LogoInvalid, nil
+	}
+	sum := sha256.Sum256(normalized)
+	digest := hex.EncodeToString(sum[:])
+	key := "img/tokens/" + digest + ".png"
+
+	exists, err := s.store.Exists(ctx, key)
+	if err != nil {
+		log.Ctx(ctx).Sugar().Error("TokenLogo storage exists ", err)
+		return statecode.CommonErrServerErr, nil
+	}
+	if !exists {
+		if err := s.store.Put(ctx, key, normalized, "image/png"); err != nil {
+			log.Ctx(ctx).Sugar().Error("TokenLogo storage put ", err)
+			return statecode.CommonErrServerErr, nil
+		}
+	}
+
+	chainId := strconv.Itoa(req.ChainId)
+	logo := s.store.URL(key)
+	err, found := s.tokens.UpdateLogo(ctx, chainId, req.Address, logo)
+	if err != nil {
+		log.Ctx(ctx).Error(err.Error())
+		return statecode.CommonErrServerErr, nil
+	}
+	if !found {
+		return statecode.TokenNotExist, nil
+	}
+	s.invalidate(ctx, "token_list:all", "token_list:"+chainId)
+
+	return statecode.CommonSuccess, &response.TokenLogo{
+		ChainId: req.ChainId,
+		Address: req.Address,
+		Logo:    logo,
+		LogoURI: logoURI(logo, baseURL),
+		Sha256:  digest,
+	}
+}
+
+// NormalizeLogo 按文件内容识别类型，等比缩放居中到透明画布并重新编码为 PNG（同时去掉元数据）
+func NormalizeLogo(data []byte) ([]byte, error) {
+	format, ok := allowedLogoTypes[http.DetectContentType(data)]
+	if !ok {
+		return nil, errLogoType
+	}
+	conf, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decoded != format {
+		return nil, errLogoType
+	}
+	if conf.Width < minLogoSide || conf.Height < minLogoSide || conf.Width > maxLogoSide || conf.Height > maxLogoSide {
+		return nil, errLogoSize
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errLogoType
+	}
+
+	w, h := LogoSize, LogoSize
+	if conf.Width > conf.Height {
+		h = maxInt(1, LogoSize*conf.Height/conf.Width)
+	} else if conf.Height > conf.Width {
+		w = maxInt(1, LogoSize*conf.Width/conf.Height)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, LogoSize, LogoSize))
+	offset := image.Pt((LogoSize-w)/2, (LogoSize-h)/2)
+	scaled := resize(toRGBA(src), w, h)
+	draw.Draw(dst, scaled.Bounds().Add(offset), scaled, image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(&buf, dst); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	b := src.Bounds()
+	img := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(img, img.Bounds(), src, b.Min, draw.Src)
+	return img
+}
+
+// resize 缩小时按区域平均，放大时双线性插值；在预乘 alpha 空间计算，透明边缘不发黑
+func resize(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	if sw == w && sh == h {
+		copy(dst.Pix, src.Pix)
+		return dst
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var c color.RGBA
+			if sw > w || sh > h {
+				c = boxAverage(src, x*sw/w, y*sh/h, maxInt((x+1)*sw/w, x*sw/w+1), maxInt((y+1)*sh/h, y*sh/h+1))
+			} else {
+				c = bilinear(src, (float64(x)+0.5)*float64(sw)/float64(w)-0.5, (float64(y)+0.5)*float64(sh)/float64(h)-0.5)
+			}
+			dst.SetRGBA(x, y, c)
+		}
+	}
+	return dst
+}
+
+func boxAverage(src *image.RGBA, x0, y0, x1, y1 int) color.RGBA {
+	var r, g, b, a, n uint64
+	for y := y0; y < y1; y++ {
+		i := src.PixOffset(x0, y)
+		for x := x0; x < x1; x++ {
+			r += uint64(src.Pix[i])
+			g += uint64(src.Pix[i+1])
+			b += uint64(src.Pix[i+2])
+			a += uint64(src.Pix[i+3])
+			n++
+			i += 4
+		}
+	}
+	return color.RGBA{R: uint8((r + n/2) / n), G: uint8((g + n/2) / n), B: uint8((b + n/2) / n), A: uint8((a + n/2) / n)}
+}
+
+func bilinear(src *image.RGBA, fx, fy float64) color.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	clamp := func(v, hi int) int {
+		if v < 0 {
+			return 0
+		}
+		if v > hi {
+			return hi
+		}
+		return v
+	}
+	x0, y0 := int(fx), int(fy)
+	if fx < 0 {
+		x0 = -1
+	}
+	if fy < 0 {
+		y0 = -1
+	}
+	dx, dy := fx-float64(x0), fy-float64(y0)
+	var out [4]float64
+	for j, wy := range [2]float64{1 - dy, dy} {
+		for i, wx := range [2]float64{1 - dx, dx} {
+			p := src.PixOffset(clamp(x0+i, sw-1), clamp(y0+j, sh-1))
+			for k := 0; k < 4; k++ {
+				out[k] += wx * wy * float64(src.Pix[p+k])
+			}
+		}
+	}
+	return color.RGBA{R: uint8(out[0] + 0.5), G: uint8(out[1] + 0.5), B: uint8(out[2] + 0.5), A: uint8(out[3] + 0.5)}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models/request"
+	"lending-copy/storage"
+)
+
+type fakeTokenRepo struct {
+	logos map[string]string
+}
+
+func (r *fakeTokenRepo) UpdateLogo(ctx context.Context, chainId, token, logo string) (error, bool) {
+	key := chainId + ":" + strings.ToLower(token)
+	if _, ok := r.logos[key]; !ok {
+		return nil, false
+	}
+	r.logos[key] = logo
+	return nil, true
+}
+
+func solid(w, h int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestNormalizeLogo(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	var jpg, gf bytes.Buffer
+	if err := jpeg.Encode(&jpg, solid(600, 600, red), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := gif.Encode(&gf, solid(20, 20, red), nil); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name   string
+		data   []byte
+		wantOK bool
+		// 横向图片上下留透明边
+		padded bool
+	}{
+		{"png downscale", encodePNG(t, solid(1000, 1000, red)), true, false},
+		{"png upscale", encodePNG(t, solid(32, 32, red)), true, false},
+		{"png wide", encodePNG(t, solid(512, 256, red)), true, true},
+		{"jpeg", jpg.Bytes(), true, false},
+		{"gif", gf.Bytes(), true, false},
+		{"too small", encodePNG(t, solid(8, 8, red)), false, false},
+		{"too large", encodePNG(t, solid(5000, 16, red)), false, false},
+		{"svg", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), false, false},
+		{"truncated png", encodePNG(t, solid(64, 64, red))[:40], false, false},
+		{"empty", nil, false, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := NormalizeLogo(tc.data)
+			if !tc.wantOK {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, format, err := image.Decode(bytes.NewReader(out))
+			if err != nil || format != "png" {
+				t.Fatalf("output decode = %s, %v", format, err)
+			}
+			if b := img.Bounds(); b.Dx() != LogoSize || b.Dy() != LogoSize {
+				t.Fatalf("output size = %v", b)
+			}
+			r, _, _, a := img.At(LogoSize/2, LogoSize/2).RGBA()
+			if a != 0xffff || r < 0xf000 {
+				t.Fatalf("center pixel = %v", img.At(LogoSize/2, LogoSize/2))
+			}
+			_, _, _, a = img.At(LogoSize/2, 0).RGBA()
+			if tc.padded != (a == 0) {
+				t.Fatalf("top pixel alpha = %d, padded = %v", a, tc.padded)
+			}
+		})
+	}
+}
+
+func TestUploadTokenLogo(t *testing.T) {
+	const token = "0x1111111111111111111111111111111111111111"
+	store := storage.NewMemory("storage/")
+	repo := &fakeTokenRepo{logos: map[string]string{"97:" + token: ""}}
+	var invalidated []string
+	svc := &TokenLogo{store: store, tokens: repo, invalidate: func(ctx context.Context, keys ...string) { invalidated = append(invalidated, keys...) }}
+	logo := encodePNG(t, solid(64, 64, color.NRGBA{B: 255, A: 255}))
+
+	code, res := svc.Upload(context.Background(), &request.TokenLogo{ChainId: 97, Address: token, Logo: logo}, "http://api.example.com/")
+	if code != statecode.CommonSuccess {
+		t.Fatalf("code = %d", code)
+	}
+	key := "img/tokens/" + res.Sha256 + ".png"
+	obj, ok := store.Get(key)
+	if !ok || obj.ContentType != "image/png" {
+		t.Fatalf("stored object = %+v, %v", obj, ok)
+	}
+	if res.Logo != "storage/"+key || repo.logos["97:"+token] != res.Logo {
+		t.Fatalf("logo = %s, repo = %s", res.Logo, repo.logos["97:"+token])
+	}
+	if res.LogoURI != "http://api.example.com/storage/"+key {
+		t.Fatalf("logoURI = %s", res.LogoURI)
+	}
+	if strings.Join(invalidated, ",") != "token_list:all,token_list:97" {
+		t.Fatalf("invalidated = %v", invalidated)
+	}
+
+	// 相同内容得到相同 key，不重复存储
+	code, again := svc.Upload(context.Background(), &request.TokenLogo{ChainId: 97, Address: token, Logo: logo}, "")
+	if code != statecode.CommonSuccess || again.Sha256 != res.Sha256 || store.Len() != 1 {
+		t.Fatalf("re-upload code = %d sha = %s len = %d", code, again.Sha256, store.Len())
+	}
+
+	code, _ = svc.Upload(context.Background(), &request.TokenLogo{ChainId: 56, Address: token, Logo: logo}, "")
+	if code != statecode.TokenNotExist {
+		t.Fatalf("unknown token code = %d", code)
+	}
+	code, _ = svc.Upload(context.Background(), &request.TokenLogo{ChainId: 97, Address: token, Logo: []byte("not an image")}, "")
+	if code != statecode.LogoInvalid {
+		t.Fatalf("invalid logo code = %d", code)
+	}
+}
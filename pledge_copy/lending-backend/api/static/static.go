@@ -1,15 +0,0 @@
-package static
-
-import (
-	"path"
-	"runtime"
-)
-
-func GetCurrentAbPathByCaller() string {
-	var abPath string
-	_, filename, _, ok := runtime.Caller(0)
-	if ok {
-		abPath = path.Dir(filename)
-	}
-	return abPath
-}
@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models/request"
+)
+
+type Auction struct{}
+
+func NewAuction() *Auction {
+	return &Auction{}
+}
+
+func (s *Auction) AuctionList(c *gin.Context, req *request.AuctionList) *statecode.Error {
+	if err := c.ShouldBindQuery(req); err != nil {
+		return bindErr(err)
+	}
+	if req.Seller != "" {
+		req.Seller = common.HexToAddress(strings.TrimSpace(req.Seller)).Hex()
+	}
+	pagination(&req.Page, &req.PageSize)
+	return nil
+}
+
+func (s *Auction) AuctionBids(c *gin.Context, req *request.AuctionBids) *statecode.Error {
+	if err := c.ShouldBindUri(req); err != nil {
+		return bindErr(err, "id")
+	}
+	if err := c.ShouldBindQuery(req); err != nil {
+		return bindErr(err)
+	}
+	pagination(&req.Page, &req.PageSize)
+	return nil
+}
+
+func (s *Auction) UserRefunds(c *gin.Context, req *request.UserRefunds) *statecode.Error {
+	if err := c.ShouldBindUri(req); err != nil {
+		return bindErr(err)
+	}
+	if err := c.ShouldBindQuery(req); err != nil {
+		return bindErr(err)
+	}
+	// 库里统一存 EIP-55 校验和地址
+	req.Address = common.HexToAddress(strings.TrimSpace(req.Address)).Hex()
+	pagination(&req.Page, &req.PageSize)
+	return nil
+}
@@ -0,0 +1,32 @@
+package validate
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models/request"
+)
+
+type Job struct{}
+
+func NewJob() *Job {
+	return &Job{}
+}
+
+func (s *Job) JobRuns(c *gin.Context, req *request.JobRuns) *statecode.Error {
+	if err := c.ShouldBindUri(req); err != nil {
+		return bindErr(err, "name")
+	}
+	if err := c.ShouldBindQuery(req); err != nil {
+		return bindErr(err)
+	}
+	pagination(&req.Page, &req.PageSize)
+	return nil
+}
+
+func (s *Job) JobTrigger(c *gin.Context, req *request.JobTrigger) *statecode.Error {
+	if err := c.ShouldBindUri(req); err != nil {
+		return bindErr(err, "name")
+	}
+	return nil
+}
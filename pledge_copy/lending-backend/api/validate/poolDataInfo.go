@@ -1,8 +1,6 @@
 package validate
 
 import (
-	"io"
-
 	"github.com/gin-gonic/gin"
 
 	"lending-copy/api/common/statecode"
@@ -15,15 +13,9 @@ func NewPoolDataInfo() *PoolDataInfo {
 	return &PoolDataInfo{}
 }
 
-func (s *PoolDataInfo) PoolDataInfo(c *gin.Context, req *request.PoolDataInfo) int {
-	err := c.ShouldBindQuery(req)
-	if err == io.EOF {
-		return statecode.ParameterEmptyErr
-	} else if err != nil {
-		return statecode.CommonErrServerErr
-	}
-	if req.ChainId != 97 && req.ChainId != 56 {
-		return statecode.ChainIdErr
+func (s *PoolDataInfo) PoolDataInfo(c *gin.Context, req *request.PoolDataInfo) *statecode.Error {
+	if err := c.ShouldBindQuery(req); err != nil {
+		return bindErr(err, "chain_id")
 	}
-	return statecode.CommonSuccess
+	return nil
 }
@@ -2,28 +2,64 @@ package validate
 
 import (
 	"io"
+	"net/http"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 
 	"lending-copy/api/common/statecode"
 	"lending-copy/api/models/request"
 )
 
+// MaxLogoBytes 上传 logo 的文件大小上限
+const MaxLogoBytes = 1 << 20
+
 type TokenList struct{}
 
 func NewTokenList() *TokenList {
 	return &TokenList{}
 }
 
-func (s *TokenList) TokenList(c *gin.Context, req *request.TokenList) int {
-	err := c.ShouldBindQuery(req)
-	if err == io.EOF {
-		return statecode.ParameterEmptyErr
-	} else if err != nil {
-		return statecode.CommonErrServerErr
+func (s *TokenList) TokenList(c *gin.Context, req *request.TokenList) *statecode.Error {
+	if err := c.ShouldBindQuery(req); err != nil {
+		return bindErr(err, "chain_id")
+	}
+	return nil
+}
+
+func (s *TokenList) TokenLogo(c *gin.Context, req *request.TokenLogo) *statecode.Error {
+	if err := c.ShouldBindUri(req); err != nil {
+		return bindErr(err, "chain_id")
+	}
+	req.Address = common.HexToAddress(req.Address).Hex()
+
+	// 预留 multipart 头部的空间，超出时在解析阶段就中断读取
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxLogoBytes+64<<10)
+	header, err := c.FormFile("file")
+	if err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			return statecode.New(statecode.LogoTooLarge, statecode.Field("file", statecode.ReasonTooLarge))
+		}
+		return statecode.New(statecode.ParameterEmptyErr, statecode.Field("file", statecode.ReasonRequired))
+	}
+	if header.Size > MaxLogoBytes {
+		return statecode.New(statecode.LogoTooLarge, statecode.Field("file", statecode.ReasonTooLarge))
+	}
+	file, err := header.Open()
+	if err != nil {
+		return statecode.Wrap(statecode.ParameterErr, err)
+	}
+	defer func() { _ = file.Close() }()
+	req.Logo, err = io.ReadAll(io.LimitReader(file, MaxLogoBytes+1))
+	if err != nil {
+		return statecode.Wrap(statecode.ParameterErr, err)
+	}
+	if len(req.Logo) > MaxLogoBytes {
+		return statecode.New(statecode.LogoTooLarge, statecode.Field("file", statecode.ReasonTooLarge))
 	}
-	if req.ChainId != 97 && req.ChainId != 56 {
-		return statecode.ChainIdErr
+	if len(req.Logo) == 0 {
+		return statecode.New(statecode.ParameterEmptyErr, statecode.Field("file", statecode.ReasonRequired))
 	}
-	return statecode.CommonSuccess
+	return nil
 }
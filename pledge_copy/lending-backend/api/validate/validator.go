@@ -1,12 +1,133 @@
 package validate
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/config"
 )
 
+var tokenSymbolPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,20}$`)
+
+// BindingValidator 向 gin 的校验引擎注册自定义规则，并以 json/form/uri 标签名作为错误字段名
 func BindingValidator() {
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		_ = v
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form", "uri"} {
+			name := strings.Split(field.Tag.Get(tag), ",")[0]
+			if name != "" && name != "-" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	_ = v.RegisterValidation("chain_id", validChainId)
+	_ = v.RegisterValidation("eth_address", validEthAddress)
+	_ = v.RegisterValidation("pool_state", validPoolState)
+	_ = v.RegisterValidation("token_symbol", validTokenSymbol)
+}
+
+// validChainId 只接受配置中的测试网与主网链 ID
+func validChainId(fl validator.FieldLevel) bool {
+	id := strconv.FormatInt(fl.Field().Int(), 10)
+	return id == config.Config.TestNet.ChainId || id == config.Config.MainNet.ChainId
+}
+
+func validEthAddress(fl validator.FieldLevel) bool {
+	return common.IsHexAddress(strings.TrimSpace(fl.Field().String()))
+}
+
+// validPoolState 对应合约 PoolState：MATCH、EXECUTION、FINISH、LIQUIDATION、UNDONE
+func validPoolState(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case "0", "1", "2", "3", "4":
+		return true
+	}
+	return false
+}
+
+func validTokenSymbol(fl validator.FieldLevel) bool {
+	return tokenSymbolPattern.MatchString(fl.Field().String())
+}
+
+// reasons 校验规则对应的字段错误原因
+var reasons = map[string]string{
+	"required":     statecode.ReasonRequired,
+	"chain_id":     statecode.ReasonUnsupported,
+	"pool_state":   statecode.ReasonUnsupported,
+	"oneof":        statecode.ReasonUnsupported,
+	"min":          statecode.ReasonOutOfRange,
+	"max":          statecode.ReasonOutOfRange,
+	"gte":          statecode.ReasonOutOfRange,
+	"lte":          statecode.ReasonOutOfRange,
+	"eth_address":  statecode.ReasonInvalid,
+	"token_symbol": statecode.ReasonInvalid,
+}
+
+// bindErr 把绑定与校验错误转换为带字段明细的 400 错误；field 为无法从错误中得知字段名时（如 query 中的非数字）使用的字段
+func bindErr(err error, field ...string) *statecode.Error {
+	if err == io.EOF {
+		return statecode.New(statecode.ParameterEmptyErr)
+	}
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]statecode.FieldError, 0, len(validationErrs))
+		for _, e := range validationErrs {
+			reason, ok := reasons[e.Tag()]
+			if !ok {
+				reason = statecode.ReasonInvalid
+			}
+			fields = append(fields, statecode.Field(e.Field(), reason))
+		}
+		return statecode.New(errCode(fields[0]), fields...)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return statecode.New(errCode(statecode.Field(typeErr.Field, statecode.ReasonInvalid)), statecode.Field(typeErr.Field, statecode.ReasonInvalid))
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return statecode.New(statecode.ParameterErr, statecode.Field("body", statecode.ReasonInvalid))
+	}
+	if len(field) > 0 {
+		f := statecode.Field(field[0], statecode.ReasonInvalid)
+		return statecode.New(errCode(f), f)
+	}
+	return statecode.Wrap(statecode.ParameterErr, err)
+}
+
+// errCode 沿用已有错误码：链 ID 与地址错误有单独的错误码，其余为参数错误
+func errCode(f statecode.FieldError) int {
+	switch {
+	case f.Field == "chain_id" && f.Reason == statecode.ReasonRequired:
+		return statecode.ChainIdEmpty
+	case f.Field == "chain_id":
+		return statecode.ChainIdErr
+	case f.Reason == statecode.ReasonInvalid && (f.Field == "address" || f.Field == "seller"):
+		return statecode.AddressErr
+	}
+	return statecode.ParameterErr
+}
+
+// pagination 未传分页参数时取默认值
+func pagination(page, pageSize *int) {
+	if *page == 0 {
+		*page = 1
+	}
+	if *pageSize == 0 {
+		*pageSize = 10
 	}
 }
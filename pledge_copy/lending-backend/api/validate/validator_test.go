@@ -0,0 +1,261 @@
+package validate
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"lending-copy/api/common/statecode"
+	"lending-copy/api/models/request"
+	"lending-copy/config"
+)
+
+const testAddress = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	config.Config = &config.Conf{
+		TestNet: config.NetConfig{ChainId: "97"},
+		MainNet: config.NetConfig{ChainId: "56"},
+	}
+	BindingValidator()
+	os.Exit(m.Run())
+}
+
+// result 期望的错误码与出错字段（字段:原因）；code 为 0 表示校验通过
+type result struct {
+	code   int
+	fields []string
+}
+
+func outcome(err *statecode.Error) result {
+	if err == nil {
+		return result{}
+	}
+	r := result{code: err.Code}
+	for _, f := range err.Fields {
+		r.fields = append(r.fields, f.Field+":"+f.Reason)
+	}
+	return r
+}
+
+func newContext(method, target string, body []byte, params gin.Params) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(method, target, bytes.NewReader(body))
+	c.Params = params
+	return c
+}
+
+func check(t *testing.T, name string, got *statecode.Error, want result) {
+	t.Helper()
+	if g := outcome(got); !reflect.DeepEqual(g, want) {
+		t.Errorf("%s: got %+v, want %+v", name, g, want)
+	}
+}
+
+func TestPoolInfo(t *testing.T) {
+	cases := []struct {
+		query string
+		want  result
+	}{
+		{"chain_id=97", result{}},
+		{"chain_id=56", result{}},
+		{"", result{statecode.ChainIdEmpty, []string{"chain_id:required"}}},
+		{"chain_id=1", result{statecode.ChainIdErr, []string{"chain_id:unsupported"}}},
+		{"chain_id=abc", result{statecode.ChainIdErr, []string{"chain_id:invalid"}}},
+	}
+	for _, tc := range cases {
+		c := newContext(http.MethodGet, "/?"+tc.query, nil, nil)
+		check(t, "PoolBaseInfo "+tc.query, NewPoolBaseInfo().PoolBaseInfo(c, &request.PoolBaseInfo{}), tc.want)
+		c = newContext(http.MethodGet, "/?"+tc.query, nil, nil)
+		check(t, "PoolDataInfo "+tc.query, NewPoolDataInfo().PoolDataInfo(c, &request.PoolDataInfo{}), tc.want)
+	}
+}
+
+func TestTokenList(t *testing.T) {
+	cases := []struct {
+		query string
+		want  result
+	}{
+		{"", result{}},
+		{"chain_id=97", result{}},
+		{"chain_id=1", result{statecode.ChainIdErr, []string{"chain_id:unsupported"}}},
+		{"chain_id=x", result{statecode.ChainIdErr, []string{"chain_id:invalid"}}},
+	}
+	for _, tc := range cases {
+		c := newContext(http.MethodGet, "/?"+tc.query, nil, nil)
+		check(t, "TokenList "+tc.query, NewTokenList().TokenList(c, &request.TokenList{}), tc.want)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	cases := []struct {
+		body string
+		want result
+	}{
+		{`{"chain_id":97}`, result{}},
+		{`{"chain_id":56,"page":2,"page_size":100,"lend_token_symbol":"USDC.e","state":"4"}`, result{}},
+		{``, result{statecode.ParameterEmptyErr, nil}},
+		{`{"chain_id":`, result{statecode.ParameterErr, []string{"body:invalid"}}},
+		{`{chain_id}`, result{statecode.ParameterErr, []string{"body:invalid"}}},
+		{`{"chain_id":"97"}`, result{statecode.ChainIdErr, []string{"chain_id:invalid"}}},
+		{`{}`, result{statecode.ChainIdEmpty, []string{"chain_id:required"}}},
+		{`{"chain_id":1}`, result{statecode.ChainIdErr, []string{"chain_id:unsupported"}}},
+		{`{"chain_id":97,"page":-1,"page_size":101}`, result{statecode.ParameterErr, []string{"page:out_of_range", "page_size:out_of_range"}}},
+		{`{"chain_id":97,"state":"5"}`, result{statecode.ParameterErr, []string{"state:unsupported"}}},
+		{`{"chain_id":97,"lend_token_symbol":"x' or '1'='1"}`, result{statecode.ParameterErr, []string{"lend_token_symbol:invalid"}}},
+	}
+	for _, tc := range cases {
+		c := newContext(http.MethodPost, "/", []byte(tc.body), nil)
+		c.Request.Header.Set("Content-Type", "application/json")
+		req := request.Search{}
+		check(t, "Search "+tc.body, NewSearch().Search(c, &req), tc.want)
+		if tc.want.code == 0 && (req.Page < 1 || req.PageSize < 1) {
+			t.Errorf("Search %s: pagination defaults not applied: %+v", tc.body, req)
+		}
+	}
+}
+
+func TestAuctionList(t *testing.T) {
+	cases := []struct {
+		query string
+		want  result
+	}{
+		{"", result{}},
+		{"status=active&seller=" + testAddress + "&page=1&page_size=10", result{}},
+		{"status=open", result{statecode.ParameterErr, []string{"status:unsupported"}}},
+		{"seller=0x123", result{statecode.AddressErr, []string{"seller:invalid"}}},
+		{"page=0&page_size=500", result{statecode.ParameterErr, []string{"page_size:out_of_range"}}},
+		{"page=-3", result{statecode.ParameterErr, []string{"page:out_of_range"}}},
+	}
+	for _, tc := range cases {
+		c := newContext(http.MethodGet, "/?"+tc.query, nil, nil)
+		req := request.AuctionList{}
+		check(t, "AuctionList "+tc.query, NewAuction().AuctionList(c, &req), tc.want)
+	}
+
+	c := newContext(http.MethodGet, "/?seller="+testAddress, nil, nil)
+	req := request.AuctionList{}
+	if err := NewAuction().AuctionList(c, &req); err != nil || req.Seller != "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" || req.Page != 1 || req.PageSize != 10 {
+		t.Errorf("AuctionList normalized = %+v, %v", req, err)
+	}
+}
+
+func TestAuctionBids(t *testing.T) {
+	cases := []struct {
+		id    string
+		query string
+		want  result
+	}{
+		{"0", "", result{}},
+		{"12", "page=2", result{}},
+		{"-1", "", result{statecode.ParameterErr, []string{"id:out_of_range"}}},
+		{"abc", "", result{statecode.ParameterErr, []string{"id:invalid"}}},
+		{"1", "page_size=1000", result{statecode.ParameterErr, []string{"page_size:out_of_range"}}},
+	}
+	for _, tc := range cases {
+		c := newContext(http.MethodGet, "/?"+tc.query, nil, gin.Params{{Key: "id", Value: tc.id}})
+		check(t, "AuctionBids "+tc.id+"?"+tc.query, NewAuction().AuctionBids(c, &request.AuctionBids{}), tc.want)
+	}
+}
+
+func TestUserRefunds(t *testing.T) {
+	cases := []struct {
+		address string
+		query   string
+		want    result
+	}{
+		{testAddress, "", result{}},
+		{"not-an-address", "", result{statecode.AddressErr, []string{"address:invalid"}}},
+		{testAddress, "page=x", result{statecode.ParameterErr, nil}},
+	}
+	for _, tc := range cases {
+		c := newContext(http.MethodGet, "/?"+tc.query, nil, gin.Params{{Key: "address", Value: tc.address}})
+		check(t, "UserRefunds "+tc.address+"?"+tc.query, NewAuction().UserRefunds(c, &request.UserRefunds{}), tc.want)
+	}
+}
+
+func TestTokenLogo(t *testing.T) {
+	upload := func(field string, size int) ([]byte, string) {
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		if field != "" {
+			part, _ := w.CreateFormFile(field, "logo.png")
+			_, _ = part.Write(bytes.Repeat([]byte{0x89}, size))
+		}
+		_ = w.Close()
+		return buf.Bytes(), w.FormDataContentType()
+	}
+	cases := []struct {
+		name    string
+		chainId string
+		address string
+		field   string
+		size    int
+		want    result
+	}{
+		{"ok", "97", testAddress, "file", 1024, result{}},
+		{"bad chain", "1", testAddress, "file", 1024, result{statecode.ChainIdErr, []string{"chain_id:unsupported"}}},
+		{"bad address", "97", "0xzz", "file", 1024, result{statecode.AddressErr, []string{"address:invalid"}}},
+		{"missing file", "97", testAddress, "", 0, result{statecode.ParameterEmptyErr, []string{"file:required"}}},
+		{"empty file", "97", testAddress, "file", 0, result{statecode.ParameterEmptyErr, []string{"file:required"}}},
+		{"too large", "97", testAddress, "file", MaxLogoBytes + 1, result{statecode.LogoTooLarge, []string{"file:too_large"}}},
+		{"body too large", "97", testAddress, "file", 2 * MaxLogoBytes, result{statecode.LogoTooLarge, []string{"file:too_large"}}},
+	}
+	for _, tc := range cases {
+		body, contentType := upload(tc.field, tc.size)
+		c := newContext(http.MethodPost, "/", body, gin.Params{{Key: "chain_id", Value: tc.chainId}, {Key: "address", Value: tc.address}})
+		c.Request.Header.Set("Content-Type", contentType)
+		check(t, "TokenLogo "+tc.name, NewTokenList().TokenLogo(c, &request.TokenLogo{}), tc.want)
+	}
+}
+
+func TestJobRuns(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  result
+	}{
+		{"pool_sync", "", result{}},
+		{"auction_sync", "page=3&page_size=100", result{}},
+		{"", "", result{statecode.ParameterErr, []string{"name:required"}}},
+		{"pool_sync", "page=0&page_size=0", result{}},
+		{"pool_sync", "page=-1", result{statecode.ParameterErr, []string{"page:out_of_range"}}},
+		{"pool_sync", "page_size=101", result{statecode.ParameterErr, []string{"page_size:out_of_range"}}},
+		{"pool_sync", "page=x", result{statecode.ParameterErr, nil}},
+	}
+	for _, tc := range cases {
+		c := newContext(http.MethodGet, "/?"+tc.query, nil, gin.Params{{Key: "name", Value: tc.name}})
+		check(t, "JobRuns "+tc.name+"?"+tc.query, NewJob().JobRuns(c, &request.JobRuns{}), tc.want)
+	}
+
+	c := newContext(http.MethodGet, "/", nil, gin.Params{{Key: "name", Value: "pool_sync"}})
+	req := request.JobRuns{}
+	if err := NewJob().JobRuns(c, &req); err != nil || req.Name != "pool_sync" || req.Page != 1 || req.PageSize != 10 {
+		t.Errorf("JobRuns defaults = %+v, %v", req, err)
+	}
+}
+
+func TestJobTrigger(t *testing.T) {
+	cases := []struct {
+		name string
+		want result
+	}{
+		{"pool_sync", result{}},
+		{"", result{statecode.ParameterErr, []string{"name:required"}}},
+	}
+	for _, tc := range cases {
+		c := newContext(http.MethodPost, "/", nil, gin.Params{{Key: "name", Value: tc.name}})
+		req := request.JobTrigger{}
+		check(t, "JobTrigger "+tc.name, NewJob().JobTrigger(c, &req), tc.want)
+		if req.Name != tc.name {
+			t.Errorf("JobTrigger name = %q, want %q", req.Name, tc.name)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package client 借贷 API 的类型化 Go 客户端。类型与接口方法在 client_gen.go 中，
+// 由 api/openapi 根据 OpenAPI 文档生成，修改接口后执行 go generate ./api/openapi 重新生成
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Client BaseURL 为服务地址，如 https://api.example.com；APIKey 非空时随每个请求发送 X-API-Key，
+// AdminToken 用于管理接口，Language 为 Accept-Language（en 或 zh），决定错误文案的语言
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	APIKey     string
+	AdminToken string
+	Language   string
+}
+
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// Error 非 2xx 响应或统一响应结构中 code 不为 0；Code、Message、Errors 取自统一响应结构，
+// 响应不是统一响应结构时（如 304、探活接口的 503）为零值，原始内容见 Body
+type Error struct {
+	StatusCode int
+	Code       int
+	Message    string
+	Errors     []FieldError
+	Body       []byte
+}
+
+func (e *Error) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("lending api: http %d, code %d: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("lending api: http %d", e.StatusCode)
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	header      http.Header
+	body        []byte
+	contentType string
+	admin       bool
+}
+
+func (c *Client) newRequest(method, path string) *request {
+	return &request{method: method, path: path, query: url.Values{}, header: http.Header{}}
+}
+
+// setQuery 非必填参数为零值时不发送
+func (r *request) setQuery(name string, v interface{}, required bool) {
+	if !required && reflect.ValueOf(v).IsZero() {
+		return
+	}
+	r.query.Set(name, fmt.Sprint(v))
+}
+
+func (r *request) setHeader(name, v string) {
+	if v != "" {
+		r.header.Set(name, v)
+	}
+}
+
+func (r *request) json(v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	r.body, r.contentType = body, "application/json"
+	return nil
+}
+
+func (r *request) multipart(field string, data []byte) error {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	part, err := w.CreateFormFile(field, field)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	r.body, r.contentType = buf.Bytes(), w.FormDataContentType()
+	return nil
+}
+
+func pathParam(v interface{}) string {
+	return url.PathEscape(fmt.Sprint(v))
+}
+
+// do 发送请求并解析响应；envelope 为 true 时响应为统一响应结构，out 接收其中的 data，out 为 *[]byte 时接收原始内容
+func (c *Client) do(ctx context.Context, r *request, envelope bool, out interface{}) error {
+	u := c.BaseURL + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, u, bytes.NewReader(r.body))
+	if err != nil {
+		return err
+	}
+	req.Header = r.header
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	if c.APIKey != "" {
+		req.Header.Set("X-API-Key", c.APIKey)
+	}
+	if r.admin && c.AdminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AdminToken)
+	}
+	if c.Language != "" {
+		req.Header.Set("Accept-Language", c.Language)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var res struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+		Errors  []FieldError    `json:"errors"`
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &Error{StatusCode: resp.StatusCode, Body: body}
+		if json.Unmarshal(body, &res) == nil {
+			apiErr.Code, apiErr.Message, apiErr.Errors = res.Code, res.Message, res.Errors
+		}
+		return apiErr
+	}
+	if raw, ok := out.(*[]byte); ok {
+		*raw = body
+		return nil
+	}
+	if !envelope {
+		return json.Unmarshal(body, out)
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return &Error{StatusCode: resp.StatusCode, Code: res.Code, Message: res.Message, Errors: res.Errors, Body: body}
+	}
+	return json.Unmarshal(res.Data, out)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.EscapedPath() {
+		case "/api/v1/poolBaseInfo":
+			if r.URL.RawQuery != "chain_id=97" || r.Header.Get("X-API-Key") != "key" || r.Header.Get("Authorization") != "" {
+				t.Errorf("pool base info request = %s %v", r.URL, r.Header)
+			}
+			_, _ = io.WriteString(w, `{"code":0,"message":"success","data":[{"index":0,"pool_data":{"pool_id":1,"autoLiquidateThreshold":"0.2","borrowTokenInfo":{"tokenName":"BUSD"}}}]}`)
+		case "/api/v1/admin/jobs/pool%20sync/run":
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer admin" {
+				t.Errorf("run job request = %s %v", r.Method, r.Header)
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"code":10015,"message":"job not exist","data":null}`)
+		case "/api/v1/admin/tokens/97/0xabc/logo":
+			if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+				t.Errorf("upload content type = %s", r.Header.Get("Content-Type"))
+			}
+			if _, _, err := r.FormFile("file"); err != nil {
+				t.Errorf("upload file: %v", err)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, `{"code":0,"message":"success","data":{"chain_id":97,"logoURI":"http://x/logo.png"}}`)
+		case "/healthz":
+			_, _ = io.WriteString(w, `{"status":"ok","service":"lending-backend"}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL + "/")
+	c.APIKey, c.AdminToken = "key", "admin"
+	ctx := context.Background()
+
+	pools, err := c.PoolBaseInfo(ctx, PoolBaseInfoParams{ChainID: 97})
+	if err != nil || len(pools) != 1 || pools[0].PoolData.PoolID != 1 || pools[0].PoolData.BorrowTokenInfo.TokenName != "BUSD" {
+		t.Fatalf("pool base info = %+v, %v", pools, err)
+	}
+
+	_, err = c.RunJob(ctx, "pool sync")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Code != 10015 {
+		t.Fatalf("run job err = %v", err)
+	}
+
+	logo, err := c.UploadTokenLogo(ctx, 97, "0xabc", []byte("png"))
+	if err != nil || logo.LogoURI != "http://x/logo.png" {
+		t.Fatalf("upload = %+v, %v", logo, err)
+	}
+
+	health, err := c.Healthz(ctx)
+	if err != nil || health.Status != "ok" {
+		t.Fatalf("healthz = %+v, %v", health, err)
+	}
+}
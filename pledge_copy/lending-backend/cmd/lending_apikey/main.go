@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"lending-copy/api/models"
+	"lending-copy/api/services"
+	"lending-copy/config"
+	"lending-copy/db"
+)
+
+const usage = `usage: lending_apikey [-config path] <command> [flags]
+
+commands:
+  create -name <name> [-rate <per minute>] [-burst <n>]   生成新 key，明文只输出这一次
+  list                                                    列出全部 key
+  revoke -id <id>                                         吊销 key
+  quota -id <id> -rate <per minute> -burst <n>            修改配额，0 表示使用 rate_limit.key_rate / key_burst
+
+吊销与配额修改在 API 进程中最迟 1 分钟后生效`
+
+func main() {
+	configPath := flag.String("config", config.DefaultPath(), "path to config.toml")
+	flag.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := config.Init(*configPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	db.InitMysql()
+	defer func() { _ = db.CloseMysql(context.Background()) }()
+	models.InitTable()
+
+	if err := run(context.Background(), flag.Arg(0), flag.Args()[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cmd string, args []string) error {
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	name := fs.String("name", "", "key name, e.g. the partner it is issued to")
+	id := fs.Int("id", 0, "key id")
+	rate := fs.Int("rate", 0, "tokens added per minute, 0 for rate_limit.key_rate")
+	burst := fs.Int("burst", 0, "bucket size, 0 for rate_limit.key_burst")
+	_ = fs.Parse(args)
+	if *rate < 0 || *burst < 0 {
+		return fmt.Errorf("rate and burst must not be negative")
+	}
+
+	keys := models.NewApiKey()
+	switch cmd {
+	case "create":
+		if *name == "" {
+			return fmt.Errorf("create: -name is required")
+		}
+		raw, prefix, hash, err := services.NewApiKey().Generate()
+		if err != nil {
+			return err
+		}
+		key := &models.ApiKey{Name: *name, Prefix: prefix, KeyHash: hash, RatePerMinute: *rate, Burst: *burst}
+		if err := keys.Create(ctx, key); err != nil {
+			return err
+		}
+		fmt.Printf("id: %d\nname: %s\nkey: %s\n", key.Id, key.Name, raw)
+		return nil
+	case "list":
+		rows, err := keys.List(ctx)
+		if err != nil {
+			return err
+		}
+		conf := config.Config.RateLimit
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tPREFIX\tRATE/MIN\tBURST\tCREATED\tREVOKED")
+		for _, k := range rows {
+			r, b := k.Quota(conf)
+			revoked := "-"
+			if k.RevokedAt != 0 {
+				revoked = time.Unix(k.RevokedAt, 0).Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%s\t%s\n", k.Id, k.Name, k.Prefix, r, b, k.CreatedAt, revoked)
+		}
+		return w.Flush()
+	case "revoke":
+		if err := keys.Revoke(ctx, *id, time.Now().Unix()); err != nil {
+			return notFound(*id, err)
+		}
+		fmt.Printf("revoked %d\n", *id)
+		return nil
+	case "quota":
+		if err := keys.SetQuota(ctx, *id, *rate, *burst); err != nil {
+			return notFound(*id, err)
+		}
+		fmt.Printf("updated %d\n", *id)
+		return nil
+	}
+	return fmt.Errorf("unknown command %q\n%s", cmd, usage)
+}
+
+func notFound(id int, err error) error {
+	if models.IsNotFound(err) {
+		return fmt.Errorf("no active key with id %d", id)
+	}
+	return err
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"lending-copy/api/openapi"
+)
+
+// 生成 OpenAPI 文档与类型化客户端，由 api/openapi 中的 go:generate 调用
+func main() {
+	specPath := flag.String("spec", "api/openapi/openapi.json", "output path of the OpenAPI document")
+	clientPath := flag.String("client", "client/client_gen.go", "output path of the generated client")
+	flag.Parse()
+
+	doc := openapi.Build(openapi.SpecVersion)
+	spec, err := openapi.Marshal(doc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	src, err := openapi.GenerateClient(doc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*specPath, spec, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*clientPath, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
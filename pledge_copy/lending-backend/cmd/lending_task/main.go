@@ -1,14 +1,70 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"lending-copy/config"
 	"lending-copy/db"
+	"lending-copy/lifecycle"
+	"lending-copy/log"
+	"lending-copy/metrics"
 	"lending-copy/schedule/models"
 	"lending-copy/schedule/tasks"
+	"lending-copy/tracing"
 )
 
 func main() {
+	configPath := flag.String("config", config.DefaultPath(), "path to config.toml")
+	flag.Parse()
+	if err := config.Init(*configPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := log.Init(config.Config.Log.File, config.Config.Log.Level); err != nil {
+		fmt.Fprintln(os.Stderr, "init log:", err)
+		os.Exit(1)
+	}
+	config.OnReload(func(old, cur *config.Conf) {
+		if err := log.SetLevel(cur.Log.Level); err != nil {
+			log.Logger.Sugar().Error("config reload log level ", err)
+		}
+	})
+	// 关闭顺序与注册顺序相反：最后刷新日志
+	lc := lifecycle.New(config.Config.Shutdown.Timeout.Duration)
+	lc.OnStop("log", func(context.Context) error { return log.Sync() })
+	go config.Watch(lc.Context(), *configPath)
+	shutdownTracing, err := tracing.Init(config.Config.Tracing, "lending-task")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "init tracing:", err)
+		os.Exit(1)
+	}
+	lc.OnStop("tracing", shutdownTracing)
+	metricsServer := metrics.Server(":" + config.Config.Metrics.Port)
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Logger.Sugar().Error("metrics server ", err)
+		}
+	}()
+	lc.OnStop("metrics", metricsServer.Shutdown)
+
 	db.InitMysql()
+	lc.OnStop("mysql", db.CloseMysql)
 	db.InitRedis()
+	lc.OnStop("redis", db.CloseRedis)
 	models.InitTable()
-	tasks.Task()
+	scheduler := tasks.Start(lc.Context())
+	// 先取消进行中的同步并等待其停在安全点，再关闭连接池
+	lc.OnStop("scheduler", scheduler.Shutdown)
+
+	<-lc.Context().Done()
+	log.Logger.Info("shutting down")
+	if err := lc.Wait(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -5,23 +5,65 @@ var Config *Conf
 type Conf struct {
 	Mysql     MysqlConfig
 	Redis     RedisConfig
-	TestNet   NetConfig
-	MainNet   NetConfig
+	TestNet   NetConfig `toml:"test_net"`
+	MainNet   NetConfig `toml:"main_net"`
 	Threshold ThresholdConfig
+	Schedule  ScheduleConfig
+	Jobs      JobsConfig
+	Auction   AuctionConfig
+	Proxies   []ProxyConfig `toml:"proxies"`
+	Storage   StorageConfig
+	Admin     AdminConfig
+	RateLimit RateLimitConfig `toml:"rate_limit"`
+	Cors      CorsConfig
+	Log       LogConfig
+	Metrics   MetricsConfig
+	Tracing   TracingConfig
+	Health    HealthConfig
+	Shutdown  ShutdownConfig
 	Env       EnvConfig
 }
 
+// EnvConfig trusted_proxies 为可信的反向代理地址或网段，只有来自它们的请求才按 X-Forwarded-For 取客户端 IP
 type EnvConfig struct {
-	Port       string `toml:"port"`
-	Version    string `toml:"version"`
-	Protocol   string `toml:"protocol"`
-	DomainName string `toml:"domain_name"`
+	Port           string   `toml:"port"`
+	Version        string   `toml:"version"`
+	Protocol       string   `toml:"protocol"`
+	DomainName     string   `toml:"domain_name"`
+	TrustedProxies []string `toml:"trusted_proxies"`
 }
 
 type ThresholdConfig struct {
 	LendingPoolNativeThreshold string `toml:"lending_pool_native_threshold"`
 }
 
+// ScheduleConfig 定时任务执行间隔，修改后热更新生效；watch_interval 为配置文件变更检测间隔
+type ScheduleConfig struct {
+	PoolSyncInterval       Duration `toml:"pool_sync_interval"`
+	BalanceMonitorInterval Duration `toml:"balance_monitor_interval"`
+	AuctionSyncInterval    Duration `toml:"auction_sync_interval"`
+	ProxyMonitorInterval   Duration `toml:"proxy_monitor_interval"`
+	WatchInterval          Duration `toml:"watch_interval"`
+}
+
+// JobsConfig 各定时任务的调度策略，修改后热更新生效
+type JobsConfig struct {
+	PoolSync       JobConfig `toml:"pool_sync"`
+	BalanceMonitor JobConfig `toml:"balance_monitor"`
+	AuctionSync    JobConfig `toml:"auction_sync"`
+	ProxyMonitor   JobConfig `toml:"proxy_monitor"`
+}
+
+// JobConfig cron 为标准 5 段表达式或 @every、@hourly 等描述符，为空时按 [schedule] 中的间隔执行；
+// 单次执行超过 timeout 即取消；每次触发随机延后 [0, jitter)；
+// 上一次还没结束时 overlap = "skip" 跳过本次，"queue" 在结束后补跑一次（多次触发合并为一次）
+type JobConfig struct {
+	Cron    string   `toml:"cron"`
+	Timeout Duration `toml:"timeout"`
+	Jitter  Duration `toml:"jitter"`
+	Overlap string   `toml:"overlap"`
+}
+
 type MysqlConfig struct {
 	Address      string `toml:"address"`
 	Port         string `toml:"port"`
@@ -39,6 +81,80 @@ type NetConfig struct {
 	LendingPoolAddr string `toml:"lending_pool_addr"`
 }
 
+// AuctionConfig nft-auction 拍卖合约索引配置
+type AuctionConfig struct {
+	ChainId       string `toml:"chain_id"`
+	NetUrl        string `toml:"net_url"`
+	AuctionAddr   string `toml:"auction_addr"`
+	StartBlock    uint64 `toml:"start_block"`
+	Confirmations uint64 `toml:"confirmations"`
+	BlockChunk    uint64 `toml:"block_chunk"`
+}
+
+// ProxyConfig 需要监控升级的 UUPS 代理
+type ProxyConfig struct {
+	Name                   string   `toml:"name"`
+	ChainId                string   `toml:"chain_id"`
+	NetUrl                 string   `toml:"net_url"`
+	Address                string   `toml:"address"`
+	StartBlock             uint64   `toml:"start_block"`
+	Confirmations          uint64   `toml:"confirmations"`
+	AllowedImplementations []string `toml:"allowed_implementations"`
+}
+
+// StorageConfig 上传文件存储，driver 目前只支持 local；static_dir 为 /storage/ 对应的目录
+type StorageConfig struct {
+	Driver    string `toml:"driver"`
+	StaticDir string `toml:"static_dir"`
+}
+
+// LogConfig 日志文件与级别
+type LogConfig struct {
+	File  string `toml:"file"`
+	Level string `toml:"level"`
+}
+
+// MetricsConfig 定时任务进程单独监听的 /metrics 端口，API 进程直接在 env.port 上暴露 /metrics
+type MetricsConfig struct {
+	Port string `toml:"port"`
+}
+
+// TracingConfig OpenTelemetry 链路追踪；exporter 为 none 时不采集，stdout 打印到标准输出，otlp 以 OTLP/HTTP 上报到 endpoint
+type TracingConfig struct {
+	Exporter    string  `toml:"exporter"`
+	Endpoint    string  `toml:"endpoint"`
+	Insecure    bool    `toml:"insecure"`
+	SampleRatio float64 `toml:"sample_ratio"`
+}
+
+// HealthConfig 同步数据超过 max_sync_age 未成功刷新时 /status 返回 503，修改后热更新生效
+type HealthConfig struct {
+	MaxSyncAge Duration `toml:"max_sync_age"`
+}
+
+// ShutdownConfig 收到 SIGTERM 后等待 HTTP 请求处理完、定时任务停下并关闭连接池的总时长
+type ShutdownConfig struct {
+	Timeout Duration `toml:"timeout"`
+}
+
+// RateLimitConfig 公共接口令牌桶限流，修改后热更新生效（backend 除外）：未带 API key 的请求按客户端 IP 计数，
+// 带 key 的按 key 计数，key 未单独设置配额时使用 key_rate / key_burst；rate 为每分钟补充的令牌数，burst 为桶容量；
+// backend 为 memory 时各实例单独计数，redis 时多实例共享；search_cost 为 /pool/search 一次消耗的令牌数
+type RateLimitConfig struct {
+	Enabled    bool   `toml:"enabled"`
+	Backend    string `toml:"backend"`
+	IpRate     int    `toml:"ip_rate"`
+	IpBurst    int    `toml:"ip_burst"`
+	KeyRate    int    `toml:"key_rate"`
+	KeyBurst   int    `toml:"key_burst"`
+	SearchCost int    `toml:"search_cost"`
+}
+
+// AdminConfig 管理接口鉴权，token 为空时管理接口不可用
+type AdminConfig struct {
+	Token string `toml:"token"`
+}
+
 type RedisConfig struct {
 	Address     string `toml:"address"`
 	Port        string `toml:"port"`
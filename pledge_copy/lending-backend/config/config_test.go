@@ -0,0 +1,137 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadRepoConfig(t *testing.T) {
+	conf, err := Load("config.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.TestNet.ChainId != "97" || conf.MainNet.ChainId != "56" {
+		t.Fatalf("net chain ids = %q, %q", conf.TestNet.ChainId, conf.MainNet.ChainId)
+	}
+	if len(conf.Proxies) != 2 || conf.Storage.StaticDir != "api/static" || conf.Log.Level != "info" {
+		t.Fatalf("unexpected conf: %+v", conf)
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	conf, err := Load("config.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{
+		"LENDING_MYSQL_PASSWORD":                          "s3cret",
+		"LENDING_MYSQL_MAX_OPEN_CONNS":                    "7",
+		"LENDING_TEST_NET_CHAIN_ID":                       "5",
+		"LENDING_AUCTION_START_BLOCK":                     "123",
+		"LENDING_PROXIES_1_ADDRESS":                       "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"LENDING_PROXIES_1_ALLOWED_IMPLEMENTATIONS":       "0x1, 0x2 ,",
+		"LENDING_ADMIN_TOKEN":                             "admin",
+		"LENDING_PROXIES_9_ADDRESS":                       "ignored",
+		"LENDING_MYSQL_PASSWORD_EXTRA":                    "ignored",
+		"LENDING_THRESHOLD_LENDING_POOL_NATIVE_THRESHOLD": "1",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	if err := applyEnv(conf, lookup); err != nil {
+		t.Fatal(err)
+	}
+	if conf.Mysql.Password != "s3cret" || conf.Mysql.MaxOpenConns != 7 || conf.TestNet.ChainId != "5" ||
+		conf.Auction.StartBlock != 123 || conf.Admin.Token != "admin" || conf.Threshold.LendingPoolNativeThreshold != "1" {
+		t.Fatalf("overrides not applied: %+v", conf)
+	}
+	if conf.Proxies[1].Address != "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" ||
+		!reflect.DeepEqual(conf.Proxies[1].AllowedImplementations, []string{"0x1", "0x2"}) {
+		t.Fatalf("proxy overrides not applied: %+v", conf.Proxies[1])
+	}
+
+	env = map[string]string{"LENDING_REDIS_DB": "one"}
+	if err := applyEnv(conf, lookup); err == nil || !strings.Contains(err.Error(), "LENDING_REDIS_DB") {
+		t.Fatalf("expected error naming the variable, got %v", err)
+	}
+}
+
+func TestLoadValidation(t *testing.T) {
+	data, err := os.ReadFile("config.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name    string
+		replace [2]string
+		want    string
+	}{
+		{"bad port", [2]string{`port = "3306"`, `port = "mysql"`}, `mysql.port: must be a port number, got "mysql"`},
+		{"bad rpc", [2]string{`net_url = "https://bsc-dataseed.binance.org"`, `net_url = "bsc-dataseed"`}, `main_net.net_url: must be an http(s) or ws(s) url`},
+		{"bad address", [2]string{`lending_pool_addr = "0x0000000000000000000000000000000000000000"`, `lending_pool_addr = "0x12"`}, `test_net.lending_pool_addr: must be a hex address`},
+		{"bad level", [2]string{`level = "info"`, `level = "trace"`}, `log.level: must be one of`},
+		{"bad driver", [2]string{`driver = "local"`, `driver = "s3"`}, `storage.driver: unsupported driver "s3"`},
+		{"unknown key", [2]string{`[env]`, "[env]\nprot = \"http\""}, `unknown key env.prot`},
+		{"bad cron", [2]string{`[jobs.pool_sync]`, "[jobs.pool_sync]\ncron = \"*/5 * *\""}, `jobs.pool_sync.cron: invalid cron expression`},
+		{"bad overlap", [2]string{`overlap = "skip"`, `overlap = "wait"`}, `jobs.pool_sync.overlap: must be skip or queue`},
+		{"bad rate limit backend", [2]string{`backend = "memory"`, `backend = "etcd"`}, `rate_limit.backend: must be memory or redis`},
+		{"bad trusted proxy", [2]string{`trusted_proxies = []`, `trusted_proxies = ["10.0.0.0/33"]`}, `env.trusted_proxies[0]: must be an IP or CIDR`},
+		{"cors wildcard with credentials", [2]string{`allow_credentials = false`, `allow_credentials = true`}, `cors.allowed_origins: "*" cannot be used with allow_credentials`},
+		{"bad cors origin", [2]string{`allowed_origins = ["http://localhost:3000"]`, `allowed_origins = ["https://admin.*.example.com/"]`}, `cors.routes[path=/api/v1/admin].allowed_origins: "https://admin.*.example.com/" must be scheme://host[:port]`},
+		{"type mismatch", [2]string{`db = 1`, `db = "one"`}, `read config`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := strings.Replace(string(data), tc.replace[0], tc.replace[1], 1)
+			if content == string(data) {
+				t.Fatalf("replacement %q not found", tc.replace[0])
+			}
+			path := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("Load err = %v, want %q", err, tc.want)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestCorsPolicies(t *testing.T) {
+	conf, err := Load("config.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.Cors.Routes = append(conf.Cors.Routes, CorsRoute{Path: "/api/v1/admin/jobs", AllowedOrigins: []string{"https://ops.example.com"}, AllowedMethods: []string{"GET"}})
+	env := map[string]string{"LENDING_CORS_ROUTES_1_ALLOW_CREDENTIALS": "true", "LENDING_CORS_MAX_AGE": "1h"}
+	if err := applyEnv(conf, func(name string) (string, bool) { v, ok := env[name]; return v, ok }); err != nil {
+		t.Fatal(err)
+	}
+
+	policies := conf.Cors.Policies()
+	if len(policies) != 3 {
+		t.Fatalf("policies = %+v", policies)
+	}
+	jobs, admin, def := policies[0], policies[1], policies[2]
+	if jobs.Path != "/api/v1/admin/jobs" || !jobs.AllowCredentials || !reflect.DeepEqual(jobs.AllowedMethods, []string{"GET"}) ||
+		!reflect.DeepEqual(jobs.ExposedHeaders, def.ExposedHeaders) || jobs.MaxAge != time.Hour {
+		t.Fatalf("jobs policy = %+v", jobs)
+	}
+	if admin.Path != "/api/v1/admin" || !admin.AllowCredentials || !reflect.DeepEqual(admin.AllowedOrigins, []string{"http://localhost:3000"}) ||
+		!reflect.DeepEqual(admin.AllowedHeaders, def.AllowedHeaders) {
+		t.Fatalf("admin policy = %+v", admin)
+	}
+	if def.Path != "" || def.AllowCredentials || def.MaxAge != time.Hour {
+		t.Fatalf("default policy = %+v", def)
+	}
+}
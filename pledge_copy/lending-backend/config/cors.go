@@ -0,0 +1,152 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CorsConfig 跨域策略；allowed_origins 中 "*" 表示任意来源（不能与 allow_credentials 同时使用），
+// "https://*.example.com" 匹配 example.com 的任意子域名。routes 按路径前缀覆盖，最长前缀优先，未填写的字段沿用外层配置
+type CorsConfig struct {
+	AllowedOrigins   []string    `toml:"allowed_origins"`
+	AllowedMethods   []string    `toml:"allowed_methods"`
+	AllowedHeaders   []string    `toml:"allowed_headers"`
+	ExposedHeaders   []string    `toml:"exposed_headers"`
+	AllowCredentials bool        `toml:"allow_credentials"`
+	MaxAge           Duration    `toml:"max_age"`
+	Routes           []CorsRoute `toml:"routes"`
+}
+
+// CorsRoute 某个路径前缀下的跨域策略覆盖；allow_credentials 不填写时沿用外层配置
+type CorsRoute struct {
+	Path             string   `toml:"path"`
+	AllowedOrigins   []string `toml:"allowed_origins"`
+	AllowedMethods   []string `toml:"allowed_methods"`
+	AllowedHeaders   []string `toml:"allowed_headers"`
+	ExposedHeaders   []string `toml:"exposed_headers"`
+	AllowCredentials *bool    `toml:"allow_credentials"`
+	MaxAge           Duration `toml:"max_age"`
+}
+
+// CorsPolicy 合并外层配置后某个路径前缀实际生效的策略，Path 为空表示默认策略
+type CorsPolicy struct {
+	Path             string
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// Policies 默认策略与各路径的覆盖合并后的结果，按路径前缀从长到短排列，默认策略在最后
+func (c CorsConfig) Policies() []CorsPolicy {
+	policies := make([]CorsPolicy, 0, len(c.Routes)+1)
+	for _, r := range c.Routes {
+		p := CorsPolicy{
+			Path:             r.Path,
+			AllowedOrigins:   inherit(r.AllowedOrigins, c.AllowedOrigins),
+			AllowedMethods:   inherit(r.AllowedMethods, c.AllowedMethods),
+			AllowedHeaders:   inherit(r.AllowedHeaders, c.AllowedHeaders),
+			ExposedHeaders:   inherit(r.ExposedHeaders, c.ExposedHeaders),
+			AllowCredentials: c.AllowCredentials,
+			MaxAge:           c.MaxAge.Duration,
+		}
+		if r.AllowCredentials != nil {
+			p.AllowCredentials = *r.AllowCredentials
+		}
+		if r.MaxAge.Duration != 0 {
+			p.MaxAge = r.MaxAge.Duration
+		}
+		policies = append(policies, p)
+	}
+	sort.SliceStable(policies, func(i, j int) bool { return len(policies[i].Path) > len(policies[j].Path) })
+	return append(policies, CorsPolicy{
+		AllowedOrigins:   c.AllowedOrigins,
+		AllowedMethods:   c.AllowedMethods,
+		AllowedHeaders:   c.AllowedHeaders,
+		ExposedHeaders:   c.ExposedHeaders,
+		AllowCredentials: c.AllowCredentials,
+		MaxAge:           c.MaxAge.Duration,
+	})
+}
+
+func inherit(v, parent []string) []string {
+	if len(v) == 0 {
+		return parent
+	}
+	return v
+}
+
+func (c *CorsConfig) setDefaults() {
+	if len(c.AllowedOrigins) == 0 {
+		c.AllowedOrigins = []string{"*"}
+	}
+	if len(c.AllowedMethods) == 0 {
+		c.AllowedMethods = []string{"GET", "POST", "OPTIONS"}
+	}
+	if len(c.AllowedHeaders) == 0 {
+		c.AllowedHeaders = []string{"Content-Type", "Authorization", "If-None-Match", "X-API-Key"}
+	}
+	if len(c.ExposedHeaders) == 0 {
+		c.ExposedHeaders = []string{"ETag", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"}
+	}
+	if c.MaxAge.Duration == 0 {
+		c.MaxAge.Duration = 10 * time.Minute
+	}
+}
+
+// validate 返回 "键: 问题" 形式的列表
+func (c CorsConfig) validate() []string {
+	var problems []string
+	paths := map[string]bool{}
+	for i, r := range c.Routes {
+		key := fmt.Sprintf("cors.routes[%d]", i)
+		if !strings.HasPrefix(r.Path, "/") {
+			problems = append(problems, fmt.Sprintf("%s.path: must start with /, got %q", key, r.Path))
+		}
+		if paths[r.Path] {
+			problems = append(problems, fmt.Sprintf("%s.path: duplicate path %q", key, r.Path))
+		}
+		paths[r.Path] = true
+		if r.MaxAge.Duration < 0 || r.MaxAge.Duration%time.Second != 0 {
+			problems = append(problems, fmt.Sprintf("%s.max_age: must be a whole number of seconds, got %s", key, r.MaxAge))
+		}
+	}
+	if c.MaxAge.Duration < 0 || c.MaxAge.Duration%time.Second != 0 {
+		problems = append(problems, fmt.Sprintf("cors.max_age: must be a whole number of seconds, got %s", c.MaxAge))
+	}
+	for _, p := range c.Policies() {
+		key := "cors"
+		if p.Path != "" {
+			key = fmt.Sprintf("cors.routes[path=%s]", p.Path)
+		}
+		for _, origin := range p.AllowedOrigins {
+			if origin == "*" {
+				if p.AllowCredentials {
+					problems = append(problems, key+`.allowed_origins: "*" cannot be used with allow_credentials`)
+				}
+				continue
+			}
+			if err := checkOrigin(origin); err != nil {
+				problems = append(problems, fmt.Sprintf("%s.allowed_origins: %q %v", key, origin, err))
+			}
+		}
+	}
+	return problems
+}
+
+// checkOrigin 来源必须是 scheme://host[:port]，通配符只能作为主机名最左侧的一段 "*."
+func checkOrigin(origin string) error {
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	if err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" || u.RawQuery != "" || u.User != nil {
+		return fmt.Errorf("must be scheme://host[:port]")
+	}
+	if strings.Contains(u.Host, "*") {
+		return fmt.Errorf(`wildcard is only allowed as a leading "*."`)
+	}
+	return nil
+}
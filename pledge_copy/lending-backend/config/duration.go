@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// Duration toml 与环境变量中以 "90s"、"2m" 形式书写的时间间隔
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
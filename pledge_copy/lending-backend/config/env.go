@@ -0,0 +1,118 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix 环境变量前缀；变量名为 toml 键路径转大写，以 _ 连接，如 mysql.password 对应 LENDING_MYSQL_PASSWORD，
+// 数组表按下标展开，如 LENDING_PROXIES_0_ADDRESS；字符串数组以逗号分隔
+const EnvPrefix = "LENDING_"
+
+func applyEnv(conf *Conf, lookup func(string) (string, bool)) error {
+	return applyEnvValue(reflect.ValueOf(conf).Elem(), strings.TrimSuffix(EnvPrefix, "_"), lookup)
+}
+
+func applyEnvValue(v reflect.Value, name string, lookup func(string) (string, bool)) error {
+	if _, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return setEnvValue(v, name, lookup)
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if err := applyEnvValue(v.Field(i), name+"_"+strings.ToUpper(tomlKey(t.Field(i))), lookup); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Struct {
+			for i := 0; i < v.Len(); i++ {
+				if err := applyEnvValue(v.Index(i), name+"_"+strconv.Itoa(i), lookup); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	return setEnvValue(v, name, lookup)
+}
+
+func setEnvValue(v reflect.Value, name string, lookup func(string) (string, bool)) error {
+	raw, ok := lookup(name)
+	if !ok {
+		return nil
+	}
+	if err := setValue(v, raw); err != nil {
+		return fmt.Errorf("env %s: %w", name, err)
+	}
+	return nil
+}
+
+func setValue(v reflect.Value, raw string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", raw)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(raw), 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", raw)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	case reflect.Ptr:
+		// 指针字段用于区分未填写与零值，设置时分配新值
+		elem := reflect.New(v.Type().Elem())
+		if err := setValue(elem.Elem(), raw); err != nil {
+			return err
+		}
+		v.Set(elem)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// tomlKey 与 BurntSushi/toml 一致：有 toml 标签用标签，否则用字段名（解码时大小写不敏感）
+func tomlKey(f reflect.StructField) string {
+	if tag := strings.Split(f.Tag.Get("toml"), ",")[0]; tag != "" {
+		return tag
+	}
+	return f.Name
+}
@@ -1,29 +0,0 @@
-package config
-
-import (
-	"path"
-	"path/filepath"
-	"runtime"
-
-	"github.com/BurntSushi/toml"
-)
-
-func init() {
-	currentAbPath := getCurrentAbPathByCaller()
-	tomlFile, err := filepath.Abs(currentAbPath + "/config.toml")
-	if err != nil {
-		panic("read toml file err: " + err.Error())
-	}
-	if _, err := toml.DecodeFile(tomlFile, &Config); err != nil {
-		panic("read toml file err: " + err.Error())
-	}
-}
-
-func getCurrentAbPathByCaller() string {
-	var abPath string
-	_, filename, _, ok := runtime.Caller(0)
-	if ok {
-		abPath = path.Dir(filename)
-	}
-	return abPath
-}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// DefaultPath 未指定 -config 时使用 LENDING_CONFIG，再退回工作目录下的 config/config.toml
+func DefaultPath() string {
+	if path := os.Getenv("LENDING_CONFIG"); path != "" {
+		return path
+	}
+	return "config/config.toml"
+}
+
+// Load 读取 toml 文件，再以 LENDING_* 环境变量覆盖、补默认值并校验；相对路径均相对于工作目录
+func Load(path string) (*Conf, error) {
+	conf := &Conf{}
+	meta, err := toml.DecodeFile(path, conf)
+	if err != nil {
+		return nil, fmt.Errorf("read config %s: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("read config %s: unknown key %s", path, undecoded[0])
+	}
+	if err := applyEnv(conf, os.LookupEnv); err != nil {
+		return nil, err
+	}
+	conf.setDefaults()
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// Init 加载配置并设置全局 Config，进程入口在使用其他包之前调用
+func Init(path string) error {
+	digest := fileDigest(path)
+	conf, err := Load(path)
+	if err != nil {
+		return err
+	}
+	reloadMu.Lock()
+	loadedDigest = digest
+	reloadMu.Unlock()
+	Config = conf
+	current.Store(conf)
+	return nil
+}
+
+func (c *Conf) setDefaults() {
+	for _, d := range []struct {
+		field *Duration
+		value time.Duration
+	}{
+		{&c.Schedule.PoolSyncInterval, 2 * time.Minute},
+		{&c.Schedule.BalanceMonitorInterval, 30 * time.Minute},
+		{&c.Schedule.AuctionSyncInterval, time.Minute},
+		{&c.Schedule.ProxyMonitorInterval, 5 * time.Minute},
+		{&c.Schedule.WatchInterval, 5 * time.Second},
+		{&c.Health.MaxSyncAge, 10 * time.Minute},
+		{&c.Shutdown.Timeout, 30 * time.Second},
+	} {
+		if d.field.Duration == 0 {
+			d.field.Duration = d.value
+		}
+	}
+	for _, j := range []*JobConfig{&c.Jobs.PoolSync, &c.Jobs.BalanceMonitor, &c.Jobs.AuctionSync, &c.Jobs.ProxyMonitor} {
+		if j.Timeout.Duration == 0 {
+			j.Timeout.Duration = 10 * time.Minute
+		}
+		if j.Overlap == "" {
+			j.Overlap = "skip"
+		}
+	}
+	if c.Storage.Driver == "" {
+		c.Storage.Driver = "local"
+	}
+	if c.Storage.StaticDir == "" {
+		c.Storage.StaticDir = "api/static"
+	}
+	if c.Log.File == "" {
+		c.Log.File = "log/logs/log.log"
+	}
+	if c.Log.Level == "" {
+		c.Log.Level = "info"
+	}
+	c.Cors.setDefaults()
+	if c.RateLimit.Backend == "" {
+		c.RateLimit.Backend = "memory"
+	}
+	for _, d := range []struct {
+		field *int
+		value int
+	}{
+		{&c.RateLimit.IpRate, 120},
+		{&c.RateLimit.IpBurst, 60},
+		{&c.RateLimit.KeyRate, 1200},
+		{&c.RateLimit.KeyBurst, 200},
+		{&c.RateLimit.SearchCost, 5},
+	} {
+		if *d.field == 0 {
+			*d.field = d.value
+		}
+	}
+	if c.Metrics.Port == "" {
+		c.Metrics.Port = "9102"
+	}
+	if c.Tracing.Exporter == "" {
+		c.Tracing.Exporter = "none"
+	}
+	if c.Tracing.Endpoint == "" {
+		c.Tracing.Endpoint = "localhost:4318"
+	}
+	if c.Tracing.SampleRatio == 0 {
+		c.Tracing.SampleRatio = 1
+	}
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"lending-copy/log"
+)
+
+var (
+	current     atomic.Value
+	reloadMu    sync.Mutex
+	subscribers []func(old, cur *Conf)
+	// loadedDigest Init 时读取的文件内容摘要，Watch 以此为基准，启动后到开始监听之间的修改也能被发现
+	loadedDigest [sha256.Size]byte
+)
+
+// Current 返回最新生效的配置；阈值、定时间隔、日志级别、RPC 地址与代理列表等可热更新的字段应从这里读取，
+// Config 为启动时的配置，其余字段只在启动时生效
+func Current() *Conf {
+	if conf, ok := current.Load().(*Conf); ok {
+		return conf
+	}
+	return Config
+}
+
+// OnReload 注册热更新回调，在新配置生效后调用
+func OnReload(fn func(old, cur *Conf)) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	subscribers = append(subscribers, fn)
+}
+
+// Change 一项配置变化，密钥类配置的值已脱敏
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+func (c Change) String() string {
+	return c.Key + ": " + c.Old + " -> " + c.New
+}
+
+// Reload 重新读取配置文件；可热更新的字段立即生效，其他字段的变化只记录日志，需要重启才会生效。
+// 新配置不合法时保留当前配置并返回错误
+func Reload(path string) (applied, ignored []Change, err error) {
+	fresh, err := Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	old := Current()
+	merged := mergeReloadable(old, fresh)
+	applied = Diff(old, merged)
+	ignored = Diff(merged, fresh)
+	if len(applied) == 0 {
+		return nil, ignored, nil
+	}
+	current.Store(merged)
+	for _, fn := range subscribers {
+		fn(old, merged)
+	}
+	return applied, ignored, nil
+}
+
+// mergeReloadable 在旧配置上套用新配置中可热更新的字段
+func mergeReloadable(old, fresh *Conf) *Conf {
+	merged := *old
+	merged.Threshold = fresh.Threshold
+	merged.Schedule = fresh.Schedule
+	merged.Schedule.WatchInterval = old.Schedule.WatchInterval
+	merged.Jobs = fresh.Jobs
+	merged.Log.Level = fresh.Log.Level
+	merged.TestNet.NetUrl = fresh.TestNet.NetUrl
+	merged.MainNet.NetUrl = fresh.MainNet.NetUrl
+	merged.Auction.NetUrl = fresh.Auction.NetUrl
+	merged.Auction.Confirmations = fresh.Auction.Confirmations
+	merged.Auction.BlockChunk = fresh.Auction.BlockChunk
+	merged.Proxies = fresh.Proxies
+	merged.Health = fresh.Health
+	merged.RateLimit = fresh.RateLimit
+	merged.RateLimit.Backend = old.RateLimit.Backend
+	return &merged
+}
+
+// Diff 按 toml 键路径列出两份配置的差异
+func Diff(old, cur *Conf) []Change {
+	var changes []Change
+	diffValue(reflect.ValueOf(*old), reflect.ValueOf(*cur), "", &changes)
+	return changes
+}
+
+func diffValue(a, b reflect.Value, key string, changes *[]Change) {
+	if _, ok := a.Interface().(fmt.Stringer); !ok && a.Kind() == reflect.Struct {
+		for i := 0; i < a.NumField(); i++ {
+			name := tomlKey(a.Type().Field(i))
+			if key != "" {
+				name = key + "." + name
+			}
+			diffValue(a.Field(i), b.Field(i), strings.ToLower(name), changes)
+		}
+		return
+	}
+	if a.Kind() == reflect.Slice && a.Type().Elem().Kind() == reflect.Struct {
+		for i := 0; i < a.Len() || i < b.Len(); i++ {
+			name := fmt.Sprintf("%s[%d]", key, i)
+			switch {
+			case i >= a.Len():
+				*changes = append(*changes, Change{Key: name, Old: "<none>", New: "added"})
+			case i >= b.Len():
+				*changes = append(*changes, Change{Key: name, Old: "present", New: "<removed>"})
+			default:
+				diffValue(a.Index(i), b.Index(i), name, changes)
+			}
+		}
+		return
+	}
+	if reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return
+	}
+	*changes = append(*changes, Change{Key: key, Old: display(key, a), New: display(key, b)})
+}
+
+func display(key string, v reflect.Value) string {
+	if strings.HasSuffix(key, "password") || strings.HasSuffix(key, "token") {
+		return "******"
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "<unset>"
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// Watch 轮询配置文件内容并监听 SIGHUP，任一触发即调用 Reload 并记录变化；ctx 结束时返回
+func Watch(ctx context.Context, path string) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(Current().Schedule.WatchInterval.Duration)
+	defer ticker.Stop()
+	reloadMu.Lock()
+	last := loadedDigest
+	reloadMu.Unlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			log.Logger.Sugar().Info("config reload: SIGHUP received")
+		case <-ticker.C:
+			digest := fileDigest(path)
+			if digest == last {
+				continue
+			}
+			last = digest
+			log.Logger.Sugar().Info("config reload: ", path, " changed")
+		}
+		reloadAndLog(path)
+	}
+}
+
+func reloadAndLog(path string) {
+	applied, ignored, err := Reload(path)
+	if err != nil {
+		log.Logger.Sugar().Error("config reload failed, keep current config: ", err)
+		return
+	}
+	for _, c := range applied {
+		log.Logger.Sugar().Info("config reload applied ", c)
+	}
+	for _, c := range ignored {
+		log.Logger.Sugar().Warn("config reload ignored, restart required: ", c)
+	}
+	if len(applied) == 0 && len(ignored) == 0 {
+		log.Logger.Sugar().Info("config reload: no changes")
+	}
+}
+
+// fileDigest 读取失败时返回空值，文件恢复后会再次触发重载
+func fileDigest(path string) [sha256.Size]byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}
+	}
+	return sha256.Sum256(data)
+}
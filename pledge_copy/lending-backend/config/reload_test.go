@@ -0,0 +1,160 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig 以仓库中的 config.toml 为模板，按 replacements 逐对替换后写入临时文件
+func writeConfig(t *testing.T, path string, replacements ...string) {
+	t.Helper()
+	data, err := os.ReadFile("config.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for i := 0; i < len(replacements); i += 2 {
+		if !strings.Contains(content, replacements[i]) {
+			t.Fatalf("replacement %q not found", replacements[i])
+		}
+		content = strings.Replace(content, replacements[i], replacements[i+1], 1)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func resetReload(t *testing.T, path string) {
+	t.Helper()
+	if err := Init(path); err != nil {
+		t.Fatal(err)
+	}
+	reloadMu.Lock()
+	subscribers = nil
+	reloadMu.Unlock()
+}
+
+func keys(changes []Change) []string {
+	var out []string
+	for _, c := range changes {
+		out = append(out, c.Key)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	writeConfig(t, path)
+	resetReload(t, path)
+	startup := Config
+
+	var calls int
+	var seen *Conf
+	OnReload(func(old, cur *Conf) {
+		calls++
+		seen = cur
+		if old != startup {
+			t.Errorf("old config is not the previous one")
+		}
+	})
+
+	writeConfig(t, path,
+		`lending_pool_native_threshold = "10000000000000000"`, `lending_pool_native_threshold = "5"`,
+		`pool_sync_interval = "2m"`, `pool_sync_interval = "30s"`,
+		`level = "info"`, `level = "debug"`,
+		`net_url = "https://bsc-dataseed.binance.org"`, `net_url = "https://bsc-dataseed1.binance.org"`,
+		`password = "your_password"`, `password = "changed"`,
+		`port = "8081"`, `port = "9000"`,
+	)
+	applied, ignored, err := Reload(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"log.level", "main_net.net_url", "schedule.pool_sync_interval", "threshold.lending_pool_native_threshold"}
+	if got := keys(applied); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("applied = %v, want %v", got, want)
+	}
+	if got := keys(ignored); strings.Join(got, ",") != "env.port,mysql.password" {
+		t.Fatalf("ignored = %v", got)
+	}
+	for _, c := range ignored {
+		if c.Key == "mysql.password" && (c.Old != "******" || c.New != "******") {
+			t.Fatalf("password not redacted: %s", c)
+		}
+	}
+	for _, c := range applied {
+		if c.Key == "schedule.pool_sync_interval" && c.String() != "schedule.pool_sync_interval: 2m0s -> 30s" {
+			t.Fatalf("change = %s", c)
+		}
+	}
+
+	cur := Current()
+	if calls != 1 || seen != cur {
+		t.Fatalf("subscriber calls = %d", calls)
+	}
+	if cur.Threshold.LendingPoolNativeThreshold != "5" || cur.Schedule.PoolSyncInterval.Duration != 30*time.Second || cur.Log.Level != "debug" {
+		t.Fatalf("safe fields not applied: %+v", cur)
+	}
+	if cur.Env.Port != "8081" || cur.Mysql.Password != "your_password" {
+		t.Fatalf("restart-only fields changed live: port=%s", cur.Env.Port)
+	}
+	if Config != startup || Config.Threshold.LendingPoolNativeThreshold != "10000000000000000" {
+		t.Fatal("startup config must not be modified")
+	}
+
+	// 再次重载没有新变化时不通知订阅者
+	if applied, _, err := Reload(path); err != nil || len(applied) != 0 || calls != 1 {
+		t.Fatalf("second reload applied = %v, calls = %d, err = %v", applied, calls, err)
+	}
+
+	writeConfig(t, path, `level = "info"`, `level = "loud"`)
+	if _, _, err := Reload(path); err == nil || !strings.Contains(err.Error(), "log.level") {
+		t.Fatalf("invalid reload err = %v", err)
+	}
+	if Current() != cur {
+		t.Fatal("invalid config must keep the current one")
+	}
+}
+
+func TestWatchFileChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	writeConfig(t, path, `watch_interval = "5s"`, `watch_interval = "1s"`)
+	resetReload(t, path)
+
+	reloaded := make(chan *Conf, 1)
+	OnReload(func(old, cur *Conf) { reloaded <- cur })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go Watch(ctx, path)
+
+	writeConfig(t, path, `watch_interval = "5s"`, `watch_interval = "1s"`, `proxy_monitor_interval = "5m"`, `proxy_monitor_interval = "10m"`)
+	select {
+	case cur := <-reloaded:
+		if cur.Schedule.ProxyMonitorInterval.Duration != 10*time.Minute {
+			t.Fatalf("proxy_monitor_interval = %s", cur.Schedule.ProxyMonitorInterval)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("config change not picked up")
+	}
+}
+
+func TestDurationEnv(t *testing.T) {
+	conf := &Conf{}
+	env := map[string]string{"LENDING_SCHEDULE_AUCTION_SYNC_INTERVAL": "45s"}
+	if err := applyEnv(conf, func(name string) (string, bool) { v, ok := env[name]; return v, ok }); err != nil {
+		t.Fatal(err)
+	}
+	if conf.Schedule.AuctionSyncInterval.Duration != 45*time.Second {
+		t.Fatalf("auction_sync_interval = %s", conf.Schedule.AuctionSyncInterval)
+	}
+	env["LENDING_SCHEDULE_AUCTION_SYNC_INTERVAL"] = "soon"
+	if err := applyEnv(conf, func(name string) (string, bool) { v, ok := env[name]; return v, ok }); err == nil {
+		t.Fatal("expected error for invalid duration")
+	}
+}
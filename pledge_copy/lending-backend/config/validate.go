@@ -0,0 +1,171 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/robfig/cron/v3"
+)
+
+// Validate 一次性报告所有不合法的配置项
+func (c *Conf) Validate() error {
+	var problems []string
+	check := func(ok bool, key, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, key+": "+fmt.Sprintf(format, args...))
+		}
+	}
+	required := func(value, key string) {
+		check(strings.TrimSpace(value) != "", key, "required")
+	}
+	port := func(value, key string) {
+		n, err := strconv.Atoi(value)
+		check(err == nil && n > 0 && n < 65536, key, "must be a port number, got %q", value)
+	}
+	chainId := func(value, key string) {
+		n, err := strconv.ParseUint(value, 10, 64)
+		check(err == nil && n > 0, key, "must be a positive integer, got %q", value)
+	}
+	rpcURL := func(value, key string) {
+		u, err := url.Parse(value)
+		ok := err == nil && u.Host != ""
+		if ok {
+			switch u.Scheme {
+			case "http", "https", "ws", "wss":
+			default:
+				ok = false
+			}
+		}
+		check(ok, key, "must be an http(s) or ws(s) url, got %q", value)
+	}
+	address := func(value, key string) {
+		check(common.IsHexAddress(value), key, "must be a hex address, got %q", value)
+	}
+	isZero := func(value string) bool {
+		return common.HexToAddress(value) == (common.Address{})
+	}
+
+	required(c.Mysql.Address, "mysql.address")
+	port(c.Mysql.Port, "mysql.port")
+	required(c.Mysql.DbName, "mysql.db_name")
+	required(c.Mysql.UserName, "mysql.user_name")
+	required(c.Redis.Address, "redis.address")
+	port(c.Redis.Port, "redis.port")
+	check(c.Redis.Db >= 0, "redis.db", "must not be negative")
+
+	for _, net := range []struct {
+		key  string
+		conf NetConfig
+	}{{"test_net", c.TestNet}, {"main_net", c.MainNet}} {
+		chainId(net.conf.ChainId, net.key+".chain_id")
+		rpcURL(net.conf.NetUrl, net.key+".net_url")
+		address(net.conf.LendingPoolAddr, net.key+".lending_pool_addr")
+	}
+	if v := c.Threshold.LendingPoolNativeThreshold; v != "" {
+		n, ok := new(big.Int).SetString(v, 10)
+		check(ok && n.Sign() >= 0, "threshold.lending_pool_native_threshold", "must be a non-negative integer in wei, got %q", v)
+	}
+
+	address(c.Auction.AuctionAddr, "auction.auction_addr")
+	if common.IsHexAddress(c.Auction.AuctionAddr) && !isZero(c.Auction.AuctionAddr) {
+		chainId(c.Auction.ChainId, "auction.chain_id")
+		rpcURL(c.Auction.NetUrl, "auction.net_url")
+	}
+	names := map[string]bool{}
+	for i, p := range c.Proxies {
+		key := fmt.Sprintf("proxies[%d]", i)
+		required(p.Name, key+".name")
+		check(!names[p.Name], key+".name", "duplicate name %q", p.Name)
+		names[p.Name] = true
+		address(p.Address, key+".address")
+		if common.IsHexAddress(p.Address) && !isZero(p.Address) {
+			chainId(p.ChainId, key+".chain_id")
+			rpcURL(p.NetUrl, key+".net_url")
+		}
+		for j, impl := range p.AllowedImplementations {
+			address(impl, fmt.Sprintf("%s.allowed_implementations[%d]", key, j))
+		}
+	}
+
+	for _, d := range []struct {
+		key   string
+		value Duration
+	}{
+		{"schedule.pool_sync_interval", c.Schedule.PoolSyncInterval},
+		{"schedule.balance_monitor_interval", c.Schedule.BalanceMonitorInterval},
+		{"schedule.auction_sync_interval", c.Schedule.AuctionSyncInterval},
+		{"schedule.proxy_monitor_interval", c.Schedule.ProxyMonitorInterval},
+		{"schedule.watch_interval", c.Schedule.WatchInterval},
+		{"health.max_sync_age", c.Health.MaxSyncAge},
+		{"shutdown.timeout", c.Shutdown.Timeout},
+	} {
+		check(d.value.Duration >= time.Second && d.value.Duration%time.Second == 0, d.key, "must be a whole number of seconds, at least 1s, got %s", d.value)
+	}
+	for _, j := range []struct {
+		key  string
+		conf JobConfig
+	}{
+		{"jobs.pool_sync", c.Jobs.PoolSync},
+		{"jobs.balance_monitor", c.Jobs.BalanceMonitor},
+		{"jobs.auction_sync", c.Jobs.AuctionSync},
+		{"jobs.proxy_monitor", c.Jobs.ProxyMonitor},
+	} {
+		if j.conf.Cron != "" {
+			_, err := cron.ParseStandard(j.conf.Cron)
+			check(err == nil, j.key+".cron", "invalid cron expression %q: %v", j.conf.Cron, err)
+		}
+		check(j.conf.Timeout.Duration >= time.Second && j.conf.Timeout.Duration%time.Second == 0, j.key+".timeout", "must be a whole number of seconds, at least 1s, got %s", j.conf.Timeout)
+		check(j.conf.Jitter.Duration >= 0 && j.conf.Jitter.Duration%time.Second == 0, j.key+".jitter", "must be a whole number of seconds, got %s", j.conf.Jitter)
+		check(j.conf.Overlap == "skip" || j.conf.Overlap == "queue", j.key+".overlap", "must be skip or queue, got %q", j.conf.Overlap)
+	}
+	check(c.Storage.Driver == "local", "storage.driver", "unsupported driver %q", c.Storage.Driver)
+	required(c.Log.File, "log.file")
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		check(false, "log.level", "must be one of debug, info, warn, error, got %q", c.Log.Level)
+	}
+	check(c.RateLimit.Backend == "memory" || c.RateLimit.Backend == "redis", "rate_limit.backend", "must be memory or redis, got %q", c.RateLimit.Backend)
+	for _, n := range []struct {
+		key   string
+		value int
+	}{
+		{"rate_limit.ip_rate", c.RateLimit.IpRate},
+		{"rate_limit.ip_burst", c.RateLimit.IpBurst},
+		{"rate_limit.key_rate", c.RateLimit.KeyRate},
+		{"rate_limit.key_burst", c.RateLimit.KeyBurst},
+		{"rate_limit.search_cost", c.RateLimit.SearchCost},
+	} {
+		check(n.value > 0, n.key, "must be positive, got %d", n.value)
+	}
+	check(c.RateLimit.SearchCost <= c.RateLimit.IpBurst, "rate_limit.search_cost", "must not exceed ip_burst %d, got %d", c.RateLimit.IpBurst, c.RateLimit.SearchCost)
+	for i, p := range c.Env.TrustedProxies {
+		_, _, cidrErr := net.ParseCIDR(p)
+		check(cidrErr == nil || net.ParseIP(p) != nil, fmt.Sprintf("env.trusted_proxies[%d]", i), "must be an IP or CIDR, got %q", p)
+	}
+	problems = append(problems, c.Cors.validate()...)
+	port(c.Metrics.Port, "metrics.port")
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		required(c.Tracing.Endpoint, "tracing.endpoint")
+	default:
+		check(false, "tracing.exporter", "must be one of none, stdout, otlp, got %q", c.Tracing.Exporter)
+	}
+	check(c.Tracing.SampleRatio > 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be in (0, 1], got %v", c.Tracing.SampleRatio)
+	port(c.Env.Port, "env.port")
+	required(c.Env.Version, "env.version")
+	check(c.Env.Protocol == "http" || c.Env.Protocol == "https", "env.protocol", "must be http or https, got %q", c.Env.Protocol)
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New("invalid config:\n  - " + strings.Join(problems, "\n  - "))
+}
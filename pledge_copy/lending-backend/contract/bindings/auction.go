@@ -0,0 +1,208 @@
+package bindings
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"lending-copy/metrics"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+//go:embed auction.json
+var auctionABIJSON string
+
+//go:embed aggregator_v3.json
+var aggregatorABIJSON string
+
+var (
+	auctionABI    abi.ABI
+	aggregatorABI abi.ABI
+)
+
+func init() {
+	var err error
+	auctionABI, err = abi.JSON(strings.NewReader(auctionABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	aggregatorABI, err = abi.JSON(strings.NewReader(aggregatorABIJSON))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// 拍卖合约事件名，与 AuctionUpgradeable.sol 一致
+const (
+	EventAuctionCreated  = "AuctionCreated"
+	EventBidPlaced       = "BidPlaced"
+	EventAuctionEnded    = "AuctionEnded"
+	EventRefundQueued    = "RefundQueued"
+	EventRefundWithdrawn = "RefundWithdrawn"
+)
+
+// AuctionEvent 拍卖合约事件解码结果，只填充对应事件包含的字段
+type AuctionEvent struct {
+	Name        string
+	BlockNumber uint64
+	TxHash      common.Hash
+	LogIndex    uint
+
+	AuctionId   *big.Int
+	Seller      common.Address
+	NftAddress  common.Address
+	TokenId     *big.Int
+	EndTime     *big.Int
+	Bidder      common.Address
+	BidToken    common.Address
+	Winner      common.Address
+	RefundToken common.Address
+	Amount      *big.Int
+	UsdValue    *big.Int
+}
+
+// AuctionClient 拍卖合约的只读封装：扫事件、读喂价
+type AuctionClient struct {
+	Eth      *ethclient.Client
+	Contract common.Address
+	Close    func()
+}
+
+func DialAuction(networkURL string, contractHex string) (*AuctionClient, error) {
+	c, err := metrics.DialEth(networkURL)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsHexAddress(contractHex) {
+		c.Close()
+		return nil, fmt.Errorf("invalid contract address")
+	}
+	return &AuctionClient{Eth: c, Contract: common.HexToAddress(contractHex), Close: func() { c.Close() }}, nil
+}
+
+// FilterEvents 拉取 [from, to] 区块内的全部拍卖事件，按区块与日志顺序返回
+func (c *AuctionClient) FilterEvents(ctx context.Context, from, to uint64) ([]AuctionEvent, error) {
+	var ids []common.Hash
+	for _, name := range []string{EventAuctionCreated, EventBidPlaced, EventAuctionEnded, EventRefundQueued, EventRefundWithdrawn} {
+		ids = append(ids, auctionABI.Events[name].ID)
+	}
+	logs, err := c.Eth.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{c.Contract},
+		Topics:    [][]common.Hash{ids},
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]AuctionEvent, 0, len(logs))
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+		ev, err := decodeAuctionLog(l)
+		if err != nil {
+			return nil, fmt.Errorf("decode log %s#%d: %w", l.TxHash.Hex(), l.Index, err)
+		}
+		events = append(events, *ev)
+	}
+	return events, nil
+}
+
+func decodeAuctionLog(l types.Log) (*AuctionEvent, error) {
+	if len(l.Topics) == 0 {
+		return nil, errors.New("log without topics")
+	}
+	event, err := auctionABI.EventByID(l.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	values := map[string]interface{}{}
+	if err := abi.ParseTopicsIntoMap(values, indexed, l.Topics[1:]); err != nil {
+		return nil, err
+	}
+	if err := auctionABI.UnpackIntoMap(values, event.Name, l.Data); err != nil {
+		return nil, err
+	}
+
+	ev := &AuctionEvent{Name: event.Name, BlockNumber: l.BlockNumber, TxHash: l.TxHash, LogIndex: l.Index}
+	ev.AuctionId, _ = values["auctionId"].(*big.Int)
+	ev.Seller, _ = values["seller"].(common.Address)
+	ev.NftAddress, _ = values["nftAddress"].(common.Address)
+	ev.TokenId, _ = values["tokenId"].(*big.Int)
+	ev.EndTime, _ = values["endTime"].(*big.Int)
+	ev.Bidder, _ = values["bidder"].(common.Address)
+	ev.BidToken, _ = values["bidToken"].(common.Address)
+	ev.Winner, _ = values["winner"].(common.Address)
+	ev.RefundToken, _ = values["refundToken"].(common.Address)
+	ev.Amount, _ = values["amount"].(*big.Int)
+	ev.UsdValue, _ = values["usdValue"].(*big.Int)
+	return ev, nil
+}
+
+func (c *AuctionClient) callAt(ctx context.Context, to common.Address, parsed abi.ABI, block *big.Int, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.Eth.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, block)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Unpack(method, out)
+}
+
+// PriceFeed 返回 token 对应的喂价地址，零地址表示 ETH；block 为 nil 时读最新状态
+func (c *AuctionClient) PriceFeed(ctx context.Context, token common.Address, block *big.Int) (common.Address, error) {
+	var (
+		vals []interface{}
+		err  error
+	)
+	if token == (common.Address{}) {
+		vals, err = c.callAt(ctx, c.Contract, auctionABI, block, "ethUsdFeed")
+	} else {
+		vals, err = c.callAt(ctx, c.Contract, auctionABI, block, "tokenUsdFeeds", token)
+	}
+	if err != nil {
+		return common.Address{}, err
+	}
+	return vals[0].(common.Address), nil
+}
+
+// LatestAnswer 读取 Chainlink 聚合器的 answer 与 decimals
+func (c *AuctionClient) LatestAnswer(ctx context.Context, feed common.Address, block *big.Int) (*big.Int, uint8, error) {
+	vals, err := c.callAt(ctx, feed, aggregatorABI, block, "latestRoundData")
+	if err != nil {
+		return nil, 0, err
+	}
+	answer := vals[1].(*big.Int)
+	vals, err = c.callAt(ctx, feed, aggregatorABI, block, "decimals")
+	if err != nil {
+		return nil, 0, err
+	}
+	return answer, vals[0].(uint8), nil
+}
+
+// ToUsdValue 与合约 _toUsdValue 一致：amount * answer / 10^decimals
+func ToUsdValue(amount, answer *big.Int, decimals uint8) (*big.Int, error) {
+	if answer == nil || answer.Sign() <= 0 {
+		return nil, errors.New("invalid price")
+	}
+	base := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	usd := new(big.Int).Mul(amount, answer)
+	return usd.Div(usd, base), nil
+}
@@ -0,0 +1,49 @@
+package bindings
+
+import (
+	"math/big"
+	"testing"
+)
+
+// 期望值按合约 _toUsdValue 计算：(amount * uint256(answer)) / (10 ** feed.decimals())，整数除法向下取整
+func TestToUsdValue(t *testing.T) {
+	ether := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	mul := func(a *big.Int, b int64) *big.Int { return new(big.Int).Mul(a, big.NewInt(b)) }
+	cases := []struct {
+		name     string
+		amount   *big.Int
+		answer   *big.Int
+		decimals uint8
+		want     string
+	}{
+		// 与 nft-auction hardhat 测试同一组喂价：1 ETH = 2000 USD，代币 = 1 USD，均为 8 位小数
+		{"1 ETH @ 2000", ether, big.NewInt(2000e8), 8, mul(ether, 2000).String()},
+		{"2500 token @ 1", mul(ether, 2500), big.NewInt(1e8), 8, mul(ether, 2500).String()},
+		{"1500 token @ 1", mul(ether, 1500), big.NewInt(1e8), 8, mul(ether, 1500).String()},
+		{"zero amount", big.NewInt(0), big.NewInt(2000e8), 8, "0"},
+		{"zero decimals", big.NewInt(3), big.NewInt(7), 0, "21"},
+		// 1 wei * 2000.5 USD / 1e8 向下取整为 0
+		{"truncates", big.NewInt(1), big.NewInt(200050000000), 8, "2000"},
+		{"truncates to zero", big.NewInt(1), big.NewInt(99999999), 8, "0"},
+		{"18 decimals feed", ether, mul(ether, 3), 18, mul(ether, 3).String()},
+	}
+	for _, c := range cases {
+		got, err := ToUsdValue(c.amount, c.answer, c.decimals)
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if got.String() != c.want {
+			t.Errorf("%s: ToUsdValue = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+// 合约 require(answer > 0, "Invalid price")
+func TestToUsdValueInvalidPrice(t *testing.T) {
+	for _, answer := range []*big.Int{nil, big.NewInt(0), big.NewInt(-1)} {
+		if _, err := ToUsdValue(big.NewInt(1), answer, 8); err == nil {
+			t.Errorf("ToUsdValue(answer=%v) 期望返回错误", answer)
+		}
+	}
+}
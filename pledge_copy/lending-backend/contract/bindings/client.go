@@ -7,10 +7,16 @@ import (
 	"math/big"
 	"strings"
 
+	"lending-copy/metrics"
+	"lending-copy/tracing"
+
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:embed simple_lending.json
@@ -61,7 +67,7 @@ type Client struct {
 }
 
 func Dial(networkURL string, contractHex string) (*Client, error) {
-	c, err := ethclient.Dial(networkURL)
+	c, err := metrics.DialEth(networkURL)
 	if err != nil {
 		return nil, err
 	}
@@ -72,9 +78,27 @@ func Dial(networkURL string, contractHex string) (*Client, error) {
 	return &Client{Eth: c, Contract: common.HexToAddress(contractHex), Close: func() { c.Close() }}, nil
 }
 
+// call 每次 eth_call 记录一个 span，span 名带上按选择器解析出的合约方法名
 func (c *Client) call(ctx context.Context, data []byte) ([]byte, error) {
+	method := "unknown"
+	if len(data) >= 4 {
+		if m, err := parsed.MethodById(data[:4]); err == nil {
+			method = m.Name
+		}
+	}
+	ctx, span := tracing.Tracer().Start(ctx, "eth_call "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.RPCSystemKey.String("jsonrpc"),
+			semconv.RPCMethodKey.String("eth_call"),
+			attribute.String("contract.address", c.Contract.Hex()),
+			attribute.String("contract.method", method),
+		),
+	)
 	msg := ethereum.CallMsg{To: &c.Contract, Data: data}
-	return c.Eth.CallContract(ctx, msg, nil)
+	out, err := c.Eth.CallContract(ctx, msg, nil)
+	tracing.End(span, err)
+	return out, err
 }
 
 func (c *Client) LendFee(ctx context.Context) (*big.Int, error) {
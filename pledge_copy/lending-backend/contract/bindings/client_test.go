@@ -0,0 +1,63 @@
+package bindings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lending-copy/tracing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestCallSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		result := `"0x000000000000000000000000000000000000000000000000000000000000002a"`
+		if req.Method != "eth_call" {
+			result = `null`
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":` + result + `}`))
+	}))
+	defer srv.Close()
+
+	cli, err := Dial(srv.URL, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	ctx, root := tracing.Tracer().Start(context.Background(), "root")
+	fee, err := cli.LendFee(ctx)
+	root.End()
+	if err != nil || fee.Int64() != 42 {
+		t.Fatalf("LendFee = %v, %v", fee, err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	call := spans[0]
+	if call.Name != "eth_call lendFee" || call.Parent.SpanID() != spans[1].SpanContext.SpanID() {
+		t.Fatalf("call span = %s parent %v", call.Name, call.Parent.SpanID())
+	}
+	attrs := map[string]string{}
+	for _, kv := range call.Attributes {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["rpc.method"] != "eth_call" || attrs["contract.address"] != "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" {
+		t.Fatalf("call span attributes = %v", attrs)
+	}
+}
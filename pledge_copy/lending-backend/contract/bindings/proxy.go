@@ -0,0 +1,112 @@
+package bindings
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EIP-1967 存储槽：bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1) 等
+var (
+	ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	AdminSlot          = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+	UpgradedTopic      = crypto.Keccak256Hash([]byte("Upgraded(address)"))
+)
+
+const versionABIJSON = `[{"inputs":[],"name":"version","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"pure","type":"function"}]`
+
+var versionABI abi.ABI
+
+func init() {
+	var err error
+	versionABI, err = abi.JSON(strings.NewReader(versionABIJSON))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// UpgradedEvent ERC1967 Upgraded(address indexed implementation)
+type UpgradedEvent struct {
+	Implementation common.Address
+	BlockNumber    uint64
+	TxHash         common.Hash
+	LogIndex       uint
+}
+
+// StorageReader 读取合约存储槽，*ethclient.Client 与 simulated 后端均实现
+type StorageReader interface {
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// LogFilterer 按条件拉取日志，*ethclient.Client 与 simulated 后端均实现
+type LogFilterer interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// ProxySlots 读取代理的实现与管理员地址；UUPS 代理的 admin 槽通常为零地址
+func ProxySlots(ctx context.Context, c StorageReader, proxy common.Address, block *big.Int) (common.Address, common.Address, error) {
+	impl, err := c.StorageAt(ctx, proxy, ImplementationSlot, block)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+	admin, err := c.StorageAt(ctx, proxy, AdminSlot, block)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+	return common.BytesToAddress(impl), common.BytesToAddress(admin), nil
+}
+
+// ProxyVersion 通过代理调用 version()；实现合约没有该方法（如 V1）时 ok 为 false
+func ProxyVersion(ctx context.Context, c ethereum.ContractCaller, proxy common.Address, block *big.Int) (string, bool, error) {
+	data, err := versionABI.Pack("version")
+	if err != nil {
+		return "", false, err
+	}
+	out, err := c.CallContract(ctx, ethereum.CallMsg{To: &proxy, Data: data}, block)
+	if err != nil {
+		if strings.Contains(err.Error(), "execution reverted") {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	if len(out) == 0 {
+		return "", false, nil
+	}
+	vals, err := versionABI.Unpack("version", out)
+	if err != nil {
+		return "", false, nil
+	}
+	return vals[0].(string), true, nil
+}
+
+// FilterUpgraded 拉取 [from, to] 区块内代理发出的 Upgraded 事件
+func FilterUpgraded(ctx context.Context, c LogFilterer, proxy common.Address, from, to uint64) ([]UpgradedEvent, error) {
+	logs, err := c.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{proxy},
+		Topics:    [][]common.Hash{{UpgradedTopic}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]UpgradedEvent, 0, len(logs))
+	for _, l := range logs {
+		if l.Removed || len(l.Topics) < 2 {
+			continue
+		}
+		events = append(events, UpgradedEvent{
+			Implementation: common.BytesToAddress(l.Topics[1].Bytes()),
+			BlockNumber:    l.BlockNumber,
+			TxHash:         l.TxHash,
+			LogIndex:       l.Index,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,119 @@
+package bindings
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	testProxy = common.HexToAddress("0x00000000000000000000000000000000000000c1")
+	testImpl  = common.HexToAddress("0x00000000000000000000000000000000000000a2")
+	testAdmin = common.HexToAddress("0x00000000000000000000000000000000000000ad")
+)
+
+// fakeProxyChain 按槽返回存储、按预设结果响应 version() 调用与日志查询
+type fakeProxyChain struct {
+	storage map[common.Hash]common.Hash
+	out     []byte
+	callErr error
+	logs    []types.Log
+	query   ethereum.FilterQuery
+	block   *big.Int
+}
+
+func (f *fakeProxyChain) StorageAt(_ context.Context, account common.Address, key common.Hash, block *big.Int) ([]byte, error) {
+	if account != testProxy {
+		return make([]byte, 32), nil
+	}
+	f.block = block
+	v := f.storage[key]
+	return v.Bytes(), nil
+}
+
+func (f *fakeProxyChain) CallContract(_ context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	f.block = block
+	if msg.To == nil || *msg.To != testProxy {
+		return nil, errors.New("unexpected target")
+	}
+	return f.out, f.callErr
+}
+
+func (f *fakeProxyChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.query = q
+	return f.logs, nil
+}
+
+func TestProxySlots(t *testing.T) {
+	chain := &fakeProxyChain{storage: map[common.Hash]common.Hash{
+		ImplementationSlot: common.BytesToHash(testImpl.Bytes()),
+		AdminSlot:          common.BytesToHash(testAdmin.Bytes()),
+	}}
+	impl, admin, err := ProxySlots(context.Background(), chain, testProxy, big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if impl != testImpl || admin != testAdmin || chain.block.Int64() != 7 {
+		t.Fatalf("ProxySlots = %s, %s at %v", impl.Hex(), admin.Hex(), chain.block)
+	}
+
+	// 非代理合约两个槽都为空
+	impl, admin, err = ProxySlots(context.Background(), chain, testImpl, nil)
+	if err != nil || impl != (common.Address{}) || admin != (common.Address{}) {
+		t.Fatalf("ProxySlots(非代理) = %s, %s, %v", impl.Hex(), admin.Hex(), err)
+	}
+}
+
+func TestProxyVersion(t *testing.T) {
+	encoded, err := versionABI.Methods["version"].Outputs.Pack("2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name    string
+		out     []byte
+		callErr error
+		want    string
+		ok      bool
+		wantErr bool
+	}{
+		{"version", encoded, nil, "2.0.0", true, false},
+		// V1 没有 version()：回退到 fallback 时 revert 或返回空数据
+		{"reverted", nil, errors.New("execution reverted"), "", false, false},
+		{"empty", nil, nil, "", false, false},
+		{"undecodable", []byte{0x01}, nil, "", false, false},
+		{"rpc error", nil, errors.New("connection refused"), "", false, true},
+	}
+	for _, c := range cases {
+		chain := &fakeProxyChain{out: c.out, callErr: c.callErr}
+		got, ok, err := ProxyVersion(context.Background(), chain, testProxy, big.NewInt(9))
+		if (err != nil) != c.wantErr || got != c.want || ok != c.ok {
+			t.Errorf("%s: ProxyVersion = %q, %v, %v", c.name, got, ok, err)
+		}
+	}
+}
+
+func TestFilterUpgraded(t *testing.T) {
+	chain := &fakeProxyChain{logs: []types.Log{
+		{Address: testProxy, Topics: []common.Hash{UpgradedTopic, common.BytesToHash(testImpl.Bytes())}, BlockNumber: 12, TxHash: common.HexToHash("0x01"), Index: 3},
+		// 重组移除的日志与缺少 indexed 参数的日志跳过
+		{Address: testProxy, Topics: []common.Hash{UpgradedTopic, common.BytesToHash(testAdmin.Bytes())}, Removed: true},
+		{Address: testProxy, Topics: []common.Hash{UpgradedTopic}},
+	}}
+	events, err := FilterUpgraded(context.Background(), chain, testProxy, 10, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Implementation != testImpl || events[0].BlockNumber != 12 || events[0].LogIndex != 3 {
+		t.Fatalf("events = %+v", events)
+	}
+	q := chain.query
+	if q.FromBlock.Uint64() != 10 || q.ToBlock.Uint64() != 20 || q.Addresses[0] != testProxy || q.Topics[0][0] != UpgradedTopic {
+		t.Fatalf("query = %+v", q)
+	}
+}
@@ -1,17 +1,32 @@
 package db
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"lending-copy/config"
 	"lending-copy/log"
+	"lending-copy/metrics"
+	"lending-copy/tracing"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
 
+// CloseMysql 关闭连接池，进程退出时调用
+func CloseMysql(ctx context.Context) error {
+	if Mysql == nil {
+		return nil
+	}
+	sqlDB, err := Mysql.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
 func InitMysql() {
 	mysqlConf := config.Config.Mysql
 	log.Logger.Info("Init Mysql")
@@ -37,6 +52,12 @@ func InitMysql() {
 	if err != nil {
 		log.Logger.Panic(fmt.Sprintf("mysql connention error ==>  %+v", err))
 	}
+	if err := db.Use(metrics.GormPlugin{}); err != nil {
+		log.Logger.Error("gorm metrics plugin err:" + err.Error())
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		log.Logger.Error("gorm tracing plugin err:" + err.Error())
+	}
 	sqlDB, err := db.DB()
 	if err != nil {
 		log.Logger.Error("db.DB() err:" + err.Error())
@@ -1,14 +1,19 @@
 package db
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"lending-copy/config"
 	"lending-copy/log"
+	"lending-copy/tracing"
 
 	"github.com/gomodule/redigo/redis"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func InitRedis() *redis.Pool {
@@ -41,42 +46,125 @@ func InitRedis() *redis.Pool {
 	return RedisConn
 }
 
-func RedisSet(key string, data interface{}, aliveSeconds int) error {
+// CloseRedis 关闭连接池，进程退出时调用
+func CloseRedis(ctx context.Context) error {
+	if RedisConn == nil {
+		return nil
+	}
+	return RedisConn.Close()
+}
+
+// redisDo 执行一条命令，并以 ctx 中的 span 为父节点记录 "redis <CMD>" 子 span
+func redisDo(ctx context.Context, cmd string, args ...interface{}) (interface{}, error) {
+	attrs := []attribute.KeyValue{semconv.DBSystemRedis, semconv.DBOperationKey.String(cmd)}
+	if len(args) > 0 {
+		if key, ok := args[0].(string); ok {
+			attrs = append(attrs, semconv.DBStatementKey.String(cmd+" "+key))
+		}
+	}
+	_, span := tracing.Tracer().Start(ctx, "redis "+cmd, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
 	conn := RedisConn.Get()
 	defer func() { _ = conn.Close() }()
+	reply, err := conn.Do(cmd, args...)
+	tracing.End(span, err)
+	return reply, err
+}
+
+func RedisPing(ctx context.Context) error {
+	_, err := redisDo(ctx, "PING")
+	return err
+}
+
+func RedisSet(ctx context.Context, key string, data interface{}, aliveSeconds int) error {
 	value, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
 	if aliveSeconds > 0 {
-		_, err = conn.Do("set", key, value, "EX", aliveSeconds)
+		_, err = redisDo(ctx, "SET", key, value, "EX", aliveSeconds)
 	} else {
-		_, err = conn.Do("set", key, value)
+		_, err = redisDo(ctx, "SET", key, value)
 	}
 	return err
 }
 
-func RedisGet(key string) ([]byte, error) {
-	conn := RedisConn.Get()
-	defer func() { _ = conn.Close() }()
-	return redis.Bytes(conn.Do("get", key))
+func RedisGet(ctx context.Context, key string) ([]byte, error) {
+	return redis.Bytes(redisDo(ctx, "GET", key))
 }
 
-func RedisFlushDB() error {
-	conn := RedisConn.Get()
-	defer func() { _ = conn.Close() }()
-	_, err := conn.Do("flushdb")
+func RedisFlushDB(ctx context.Context) error {
+	_, err := redisDo(ctx, "FLUSHDB")
 	return err
 }
 
-func RedisSetString(key string, data string, aliveSeconds int) error {
-	conn := RedisConn.Get()
-	defer func() { _ = conn.Close() }()
+func RedisSetString(ctx context.Context, key string, data string, aliveSeconds int) error {
 	var err error
 	if aliveSeconds > 0 {
-		_, err = conn.Do("set", key, data, "EX", aliveSeconds)
+		_, err = redisDo(ctx, "SET", key, data, "EX", aliveSeconds)
 	} else {
-		_, err = conn.Do("set", key, data)
+		_, err = redisDo(ctx, "SET", key, data)
 	}
 	return err
 }
+
+func RedisDelete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = key
+	}
+	_, err := redisDo(ctx, "DEL", args...)
+	return err
+}
+
+// RedisPublish 发布一条消息，返回收到消息的订阅者数
+func RedisPublish(ctx context.Context, channel, message string) (int, error) {
+	return redis.Int(redisDo(ctx, "PUBLISH", channel, message))
+}
+
+// RedisSubscribe 订阅 channel 并对每条消息调用 fn，直到 ctx 结束（返回 nil）或连接出错；
+// 订阅独占一条连接，不从连接池中取，ctx 结束时直接关闭它以打断阻塞的读取
+func RedisSubscribe(ctx context.Context, channel string, fn func(message string)) error {
+	conn, err := RedisConn.Dial()
+	if err != nil {
+		return err
+	}
+	psc := redis.PubSubConn{Conn: conn}
+	if err := psc.Subscribe(channel); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		_ = conn.Close()
+	}()
+	for {
+		switch v := psc.Receive().(type) {
+		case redis.Message:
+			fn(string(v.Data))
+		case error:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return v
+		}
+	}
+}
+
+// RedisEval 执行 Lua 脚本（先 EVALSHA，服务端没有缓存脚本时退回 EVAL）
+func RedisEval(ctx context.Context, script *redis.Script, keysAndArgs ...interface{}) (interface{}, error) {
+	_, span := tracing.Tracer().Start(ctx, "redis EVALSHA", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis, semconv.DBOperationKey.String("EVALSHA")))
+	conn := RedisConn.Get()
+	defer func() { _ = conn.Close() }()
+	reply, err := script.Do(conn, keysAndArgs...)
+	tracing.End(span, err)
+	return reply, err
+}
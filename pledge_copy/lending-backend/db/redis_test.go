@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"lending-copy/tracing"
+
+	"github.com/gomodule/redigo/redis"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// fakeConn 按命令返回预设结果，DEL 模拟服务端报错
+type fakeConn struct {
+	redis.Conn
+	store map[string][]byte
+}
+
+func (c *fakeConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	switch cmd {
+	case "GET":
+		if v, ok := c.store[args[0].(string)]; ok {
+			return v, nil
+		}
+		return nil, nil
+	case "SET":
+		c.store[args[0].(string)] = []byte(args[1].(string))
+		return "OK", nil
+	}
+	return nil, redis.Error("ERR unknown command")
+}
+
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Err() error   { return nil }
+
+func TestRedisSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	conn := &fakeConn{store: map[string][]byte{}}
+	RedisConn = &redis.Pool{Dial: func() (redis.Conn, error) { return conn, nil }}
+
+	ctx, root := tracing.Tracer().Start(context.Background(), "root")
+	if err := RedisSetString(ctx, "token_list:97", "v", 60); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := RedisGet(ctx, "token_list:97"); err != nil || string(b) != "v" {
+		t.Fatalf("RedisGet = %q, %v", b, err)
+	}
+	var redisErr redis.Error
+	if err := RedisDelete(ctx, "token_list:97"); !errors.As(err, &redisErr) {
+		t.Fatalf("RedisDelete err = %v", err)
+	}
+	root.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 4 {
+		t.Fatalf("got %d spans, want 4", len(spans))
+	}
+	rootID := spans[3].SpanContext.SpanID()
+	for i, want := range []string{"SET token_list:97", "GET token_list:97", "DEL token_list:97"} {
+		s := spans[i]
+		stmt := ""
+		for _, kv := range s.Attributes {
+			if kv.Key == "db.statement" {
+				stmt = kv.Value.Emit()
+			}
+		}
+		if stmt != want || s.Parent.SpanID() != rootID {
+			t.Errorf("span %d = %s %q parent %v, want %q under root", i, s.Name, stmt, s.Parent.SpanID(), want)
+		}
+	}
+	if spans[2].Status.Code != codes.Error || len(spans[2].Events) == 0 {
+		t.Errorf("DEL span should record the error: %+v", spans[2].Status)
+	}
+}
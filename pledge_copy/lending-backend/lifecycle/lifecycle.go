@@ -0,0 +1,88 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Manager 管理进程生命周期：收到 SIGINT / SIGTERM 或调用 Stop 后取消根 context，
+// 再按注册的逆序执行关闭函数，全部关闭共用一个超时
+type Manager struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+
+	mu    sync.Mutex
+	hooks []hook
+}
+
+type hook struct {
+	name string
+	fn   func(context.Context) error
+}
+
+func New(timeout time.Duration) *Manager {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	return &Manager{ctx: ctx, cancel: cancel, timeout: timeout}
+}
+
+// Context 根 context，开始退出时被取消；后台任务应以它为父 context
+func (m *Manager) Context() context.Context {
+	return m.ctx
+}
+
+// OnStop 注册关闭函数，先注册的后关闭（如先注册日志，最后才刷新日志）
+func (m *Manager) OnStop(name string, fn func(context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// Stop 主动触发退出，如 HTTP 服务监听失败时
+func (m *Manager) Stop() {
+	m.cancel()
+}
+
+// Wait 阻塞到收到退出信号或 Stop，然后执行全部关闭函数；
+// 超时后不再等待剩余的关闭函数，返回的错误包含每个失败或超时的组件
+func (m *Manager) Wait() error {
+	<-m.ctx.Done()
+	m.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	m.mu.Lock()
+	hooks := make([]hook, len(m.hooks))
+	copy(hooks, m.hooks)
+	m.mu.Unlock()
+
+	var errs []string
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		if ctx.Err() != nil {
+			errs = append(errs, h.name+": skipped, shutdown timed out")
+			continue
+		}
+		done := make(chan error, 1)
+		go func() { done <- h.fn(ctx) }()
+		select {
+		case err := <-done:
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", h.name, err))
+			}
+		case <-ctx.Done():
+			errs = append(errs, h.name+": shutdown timed out")
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.New("shutdown: " + strings.Join(errs, "; "))
+}
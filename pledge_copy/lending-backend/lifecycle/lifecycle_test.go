@@ -0,0 +1,57 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitRunsHooksInReverse(t *testing.T) {
+	m := New(time.Second)
+	var order []string
+	for _, name := range []string{"log", "mysql", "http"} {
+		name := name
+		m.OnStop(name, func(context.Context) error {
+			order = append(order, name)
+			if name == "mysql" {
+				return errors.New("close failed")
+			}
+			return nil
+		})
+	}
+	m.Stop()
+	err := m.Wait()
+	if strings.Join(order, ",") != "http,mysql,log" {
+		t.Fatalf("order = %v", order)
+	}
+	if err == nil || !strings.Contains(err.Error(), "mysql: close failed") {
+		t.Fatalf("err = %v", err)
+	}
+	if m.Context().Err() == nil {
+		t.Fatal("root context not canceled")
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	m := New(50 * time.Millisecond)
+	ran := false
+	m.OnStop("log", func(context.Context) error {
+		ran = true
+		return nil
+	})
+	m.OnStop("scheduler", func(ctx context.Context) error {
+		<-make(chan struct{})
+		return nil
+	})
+	m.Stop()
+	start := time.Now()
+	err := m.Wait()
+	if time.Since(start) > time.Second {
+		t.Fatal("Wait did not honour the timeout")
+	}
+	if ran || err == nil || !strings.Contains(err.Error(), "scheduler: shutdown timed out") || !strings.Contains(err.Error(), "log: skipped") {
+		t.Fatalf("ran = %v, err = %v", ran, err)
+	}
+}
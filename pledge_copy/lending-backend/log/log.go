@@ -1,20 +1,31 @@
 package log
 
 import (
+	"context"
 	"os"
-	"path"
-	"runtime"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-var Logger *zap.Logger
+// Logger 调用 Init 之前为空实现，测试中可以直接使用
+var Logger = zap.NewNop()
 
-func init() {
-	hook := lumberjack.Logger{
-		Filename:   getCurrentAbPathByCaller() + "/logs/log.log",
+// logFile Init 之后的切割日志文件，退出时由 Sync 关闭
+var logFile *lumberjack.Logger
+
+// level 运行期可调整的日志级别，配置热更新时通过 SetLevel 修改
+var level = zap.NewAtomicLevelAt(zap.InfoLevel)
+
+// Init file 为切割日志文件路径，level 为 debug / info / warn / error
+func Init(file, lvl string) error {
+	if err := SetLevel(lvl); err != nil {
+		return err
+	}
+	hook := &lumberjack.Logger{
+		Filename:   file,
 		MaxSize:    50,
 		MaxBackups: 20,
 		MaxAge:     7,
@@ -34,21 +45,39 @@ func init() {
 		EncodeCaller:   zapcore.FullCallerEncoder,
 		EncodeName:     zapcore.FullNameEncoder,
 	}
-	atomicLevel := zap.NewAtomicLevel()
-	atomicLevel.SetLevel(zap.InfoLevel)
 	core := zapcore.NewCore(
 		zapcore.NewJSONEncoder(encoderConfig),
-		zapcore.NewMultiWriteSyncer(zapcore.AddSync(os.Stdout), zapcore.AddSync(&hook)),
-		atomicLevel,
+		zapcore.NewMultiWriteSyncer(zapcore.AddSync(os.Stdout), zapcore.AddSync(hook)),
+		level,
 	)
+	logFile = hook
 	Logger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(0), zap.Fields(zap.String("serviceName", "lending-copy")))
+	return nil
+}
+
+// Sync 进程退出前刷新日志并关闭日志文件；stdout 不支持 fsync 的错误忽略
+func Sync() error {
+	_ = Logger.Sync()
+	if logFile == nil {
+		return nil
+	}
+	return logFile.Close()
+}
+
+// Ctx 带上 ctx 中当前 span 的 trace_id / span_id，便于从日志跳转到链路
+func Ctx(ctx context.Context) *zap.Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return Logger
+	}
+	return Logger.With(zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
 }
 
-func getCurrentAbPathByCaller() string {
-	var abPath string
-	_, filename, _, ok := runtime.Caller(0)
-	if ok {
-		abPath = path.Dir(filename)
+func SetLevel(lvl string) error {
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(lvl)); err != nil {
+		return err
 	}
-	return abPath
+	level.SetLevel(l)
+	return nil
 }
@@ -1,29 +1,92 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
 	"lending-copy/api/middlewares"
+	apimodels "lending-copy/api/models"
 	"lending-copy/api/routes"
-	"lending-copy/api/static"
 	"lending-copy/api/validate"
 	"lending-copy/config"
 	"lending-copy/db"
+	"lending-copy/lifecycle"
+	"lending-copy/log"
 	schedmodels "lending-copy/schedule/models"
+	"lending-copy/storage"
+	"lending-copy/tracing"
 
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
+	configPath := flag.String("config", config.DefaultPath(), "path to config.toml")
+	flag.Parse()
+	if err := config.Init(*configPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := log.Init(config.Config.Log.File, config.Config.Log.Level); err != nil {
+		fmt.Fprintln(os.Stderr, "init log:", err)
+		os.Exit(1)
+	}
+	config.OnReload(func(old, cur *config.Conf) {
+		if err := log.SetLevel(cur.Log.Level); err != nil {
+			log.Logger.Sugar().Error("config reload log level ", err)
+		}
+	})
+	// 关闭顺序与注册顺序相反：最后刷新日志
+	lc := lifecycle.New(config.Config.Shutdown.Timeout.Duration)
+	lc.OnStop("log", func(context.Context) error { return log.Sync() })
+	go config.Watch(lc.Context(), *configPath)
+	shutdownTracing, err := tracing.Init(config.Config.Tracing, "lending-api")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "init tracing:", err)
+		os.Exit(1)
+	}
+	lc.OnStop("tracing", shutdownTracing)
+
 	db.InitMysql()
+	lc.OnStop("mysql", db.CloseMysql)
 	db.InitRedis()
+	lc.OnStop("redis", db.CloseRedis)
 	schedmodels.InitTable()
+	apimodels.InitTable()
 
 	validate.BindingValidator()
 
 	gin.SetMode(gin.ReleaseMode)
 	app := gin.Default()
-	staticPath := static.GetCurrentAbPathByCaller()
-	app.Static("/storage/", staticPath)
+	if err := app.SetTrustedProxies(config.Config.Env.TrustedProxies); err != nil {
+		fmt.Fprintln(os.Stderr, "trusted proxies:", err)
+		os.Exit(1)
+	}
+	storage.InitStorage(config.Config.Storage.StaticDir)
+	app.Static("/storage/", config.Config.Storage.StaticDir)
+	app.Use(middlewares.Tracing())
+	app.Use(middlewares.Metrics())
 	app.Use(middlewares.Cors())
+	app.Use(middlewares.Lang())
 	routes.InitRoute(app)
-	_ = app.Run(":" + config.Config.Env.Port)
+
+	srv := &http.Server{Addr: ":" + config.Config.Env.Port, Handler: app}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Logger.Sugar().Error("http server ", err)
+			lc.Stop()
+		}
+	}()
+	// Shutdown 先停止接收新连接，再等待处理中的请求完成
+	lc.OnStop("http", srv.Shutdown)
+
+	<-lc.Context().Done()
+	log.Logger.Info("shutting down")
+	if err := lc.Wait(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "lending"
+
+// Registry 本服务的全部指标，API 与定时任务进程各自一份
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route template, method and status code.",
+	}, []string{"route", "method", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route template, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_requests_total",
+		Help:      "JSON-RPC calls by method and endpoint host.",
+	}, []string{"method", "endpoint"})
+	rpcErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "JSON-RPC calls that failed at transport level or returned an error object.",
+	}, []string{"method", "endpoint"})
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_duration_seconds",
+		Help:      "JSON-RPC round trip latency by method and endpoint host.",
+		Buckets:   []float64{.025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "endpoint"})
+
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "sync_duration_seconds",
+		Help:      "Duration of one scheduled job tick.",
+		Buckets:   []float64{.1, .5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"job"})
+	jobRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_runs_total",
+		Help:      "Scheduled job runs by outcome: success, failed, timeout, canceled or skipped.",
+	}, []string{"job", "status"})
+	poolsChanged = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "sync_pools_changed",
+		Help:      "Pools whose base or data info changed during the last sync tick.",
+	}, []string{"chain_id"})
+	poolsChangedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sync_pools_changed_total",
+		Help:      "Pools whose base or data info changed, summed over all sync ticks.",
+	}, []string{"chain_id"})
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pool_md5_cache_lookups_total",
+		Help:      "Pool info MD5 cache lookups in Redis by result (hit or miss).",
+	}, []string{"result"})
+
+	dbDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "GORM statement latency by operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+	dbErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "GORM statements that returned an error other than record not found.",
+	}, []string{"operation", "table"})
+
+	nativeBalance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "native_balance_wei",
+		Help:      "Native coin balance of the monitored contract in wei.",
+	}, []string{"chain_id", "contract"})
+	nativeThreshold = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "native_balance_threshold_wei",
+		Help:      "Configured low balance threshold of the monitored contract in wei.",
+	}, []string{"chain_id", "contract"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration,
+		rpcRequests, rpcErrors, rpcDuration,
+		jobDuration, jobRuns, poolsChanged, poolsChangedTotal, cacheLookups,
+		dbDuration, dbErrors,
+		nativeBalance, nativeThreshold,
+	)
+}
+
+// Handler /metrics 的处理器
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Server 单独监听一个端口暴露 /metrics，供没有 HTTP 服务的定时任务进程使用
+func Server(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &http.Server{Addr: addr, Handler: mux}
+}
+
+// ObserveHTTP 记录一次 HTTP 请求，route 为路由模板（如 /auctions/:id/bids）
+func ObserveHTTP(route, method string, status int, d time.Duration) {
+	labels := prometheus.Labels{"route": route, "method": method, "status": strconv.Itoa(status)}
+	httpRequests.With(labels).Inc()
+	httpDuration.With(labels).Observe(d.Seconds())
+}
+
+// ObserveJob 记录一次定时任务的结果与耗时；被跳过的执行只计数，不记录耗时
+func ObserveJob(job, status string, d time.Duration) {
+	jobRuns.WithLabelValues(job, status).Inc()
+	if status != "skipped" {
+		jobDuration.WithLabelValues(job).Observe(d.Seconds())
+	}
+}
+
+// PoolsChanged 记录一次池子同步中发生变化的池子数
+func PoolsChanged(chainId string, n int) {
+	poolsChanged.WithLabelValues(chainId).Set(float64(n))
+	poolsChangedTotal.WithLabelValues(chainId).Add(float64(n))
+}
+
+// CacheLookup 记录一次池子信息 MD5 缓存查询，命中率 = hit / (hit + miss)
+func CacheLookup(hit bool) {
+	if hit {
+		cacheLookups.WithLabelValues("hit").Inc()
+		return
+	}
+	cacheLookups.WithLabelValues("miss").Inc()
+}
+
+// NativeBalance 记录被监控合约的原生币余额与告警阈值，threshold 为 nil 时不更新阈值
+func NativeBalance(chainId, contract string, balance, threshold *big.Int) {
+	nativeBalance.WithLabelValues(chainId, contract).Set(weiFloat(balance))
+	if threshold != nil {
+		nativeThreshold.WithLabelValues(chainId, contract).Set(weiFloat(threshold))
+	}
+}
+
+func weiFloat(v *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(v).Float64()
+	return f
+}
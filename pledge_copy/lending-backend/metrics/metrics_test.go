@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRPCTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if bytes.HasPrefix(body, []byte("[")) {
+			_, _ = w.Write([]byte(`[{"jsonrpc":"2.0","id":1,"result":"0x1"},{"jsonrpc":"2.0","id":2,"error":{"code":-32000,"message":"boom"}}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":7,"result":"0x10"}`))
+	}))
+	defer srv.Close()
+
+	tr := NewRPCTransport(srv.URL+"/v3/secret-key", http.DefaultTransport)
+	endpoint := endpointLabel(srv.URL)
+	if strings.Contains(endpoint, "secret") {
+		t.Fatalf("endpoint label leaks path: %s", endpoint)
+	}
+	post := func(body string) string {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := ioutil.ReadAll(resp.Body)
+		return string(b)
+	}
+
+	if got := post(`{"jsonrpc":"2.0","id":7,"method":"eth_blockNumber","params":[]}`); !strings.Contains(got, "0x10") {
+		t.Fatalf("response body not passed through: %s", got)
+	}
+	post(`[{"jsonrpc":"2.0","id":1,"method":"eth_call","params":[]},{"jsonrpc":"2.0","id":2,"method":"eth_getLogs","params":[]}]`)
+
+	for _, c := range []struct {
+		method         string
+		calls, errored float64
+	}{
+		{"eth_blockNumber", 1, 0},
+		{"eth_call", 1, 0},
+		{"eth_getLogs", 1, 1},
+	} {
+		if got := testutil.ToFloat64(rpcRequests.WithLabelValues(c.method, endpoint)); got != c.calls {
+			t.Errorf("%s calls = %v, want %v", c.method, got, c.calls)
+		}
+		if got := testutil.ToFloat64(rpcErrors.WithLabelValues(c.method, endpoint)); got != c.errored {
+			t.Errorf("%s errors = %v, want %v", c.method, got, c.errored)
+		}
+	}
+}
+
+func TestHandlerExposesSeries(t *testing.T) {
+	ObserveHTTP("/api/v1/auctions/:id/bids", http.MethodGet, http.StatusOK, 20*time.Millisecond)
+	CacheLookup(true)
+	CacheLookup(false)
+	PoolsChanged("97", 3)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		`lending_http_requests_total{method="GET",route="/api/v1/auctions/:id/bids",status="200"} 1`,
+		`lending_pool_md5_cache_lookups_total{result="hit"} 1`,
+		`lending_sync_pools_changed{chain_id="97"} 3`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DialEth 连接节点；http(s) 节点的每次 JSON-RPC 调用都按方法与节点记录次数、错误与耗时，
+// ws/ipc 节点不经过 http.Client，直接用 ethclient.Dial 连接且不记录
+func DialEth(rawURL string) (*ethclient.Client, error) {
+	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
+		return ethclient.Dial(rawURL)
+	}
+	hc := &http.Client{Transport: NewRPCTransport(rawURL, http.DefaultTransport)}
+	c, err := rpc.DialHTTPWithClient(rawURL, hc)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(c), nil
+}
+
+// NewRPCTransport 包装 http.RoundTripper，解析 JSON-RPC 请求/响应体记录指标
+func NewRPCTransport(rawURL string, base http.RoundTripper) http.RoundTripper {
+	return &rpcTransport{endpoint: endpointLabel(rawURL), base: base}
+}
+
+type rpcTransport struct {
+	endpoint string
+	base     http.RoundTripper
+}
+
+type rpcMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Error  json.RawMessage `json:"error"`
+}
+
+func (t *rpcTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var calls []rpcMessage
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		calls = decodeMessages(body)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	failed := map[string]bool{}
+	failAll := err != nil || resp.StatusCode != http.StatusOK
+	if err == nil {
+		body, readErr := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if readErr != nil {
+			resp, err = nil, readErr
+			failAll = true
+		} else {
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			for _, m := range decodeMessages(body) {
+				if len(m.Error) > 0 && string(m.Error) != "null" {
+					failed[string(m.ID)] = true
+				}
+			}
+		}
+	}
+	for _, c := range calls {
+		rpcRequests.WithLabelValues(c.Method, t.endpoint).Inc()
+		rpcDuration.WithLabelValues(c.Method, t.endpoint).Observe(elapsed.Seconds())
+		if failAll || failed[string(c.ID)] {
+			rpcErrors.WithLabelValues(c.Method, t.endpoint).Inc()
+		}
+	}
+	return resp, err
+}
+
+// decodeMessages 兼容单个请求与批量请求两种格式
+func decodeMessages(body []byte) []rpcMessage {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []rpcMessage
+		if json.Unmarshal(body, &batch) == nil {
+			return batch
+		}
+		return nil
+	}
+	var m rpcMessage
+	if json.Unmarshal(body, &m) != nil {
+		return nil
+	}
+	return []rpcMessage{m}
+}
+
+// endpointLabel 只保留 scheme://host，避免把 URL 路径里的 API key 写进指标
+func endpointLabel(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "unknown"
+	}
+	return u.Scheme + "://" + u.Host
+}
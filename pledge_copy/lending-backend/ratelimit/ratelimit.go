@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval 内存桶的清理间隔：已补满的桶与新建的桶等价，直接删除
+const sweepInterval = time.Minute
+
+// Rate 令牌桶配额：每分钟补充 PerMinute 个令牌，最多攒 Burst 个
+type Rate struct {
+	PerMinute int
+	Burst     int
+}
+
+// perMs 每毫秒补充的令牌数
+func (r Rate) perMs() float64 {
+	return float64(r.PerMinute) / float64(time.Minute/time.Millisecond)
+}
+
+// Result 一次取令牌的结果；Reset 为桶补满所需时间，RetryAfter 仅在拒绝时有值，为攒够本次所需令牌的时间
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Limiter 按 key 限流；cost 为本次请求消耗的令牌数
+type Limiter interface {
+	Take(ctx context.Context, key string, rate Rate, cost int) (Result, error)
+}
+
+// refill 按经过的毫秒数补充令牌并尝试扣除 cost，返回剩余令牌数与是否放行
+func refill(tokens float64, elapsedMs int64, rate Rate, cost int) (float64, bool) {
+	if elapsedMs > 0 {
+		tokens = math.Min(float64(rate.Burst), tokens+float64(elapsedMs)*rate.perMs())
+	}
+	if tokens >= float64(cost) {
+		return tokens - float64(cost), true
+	}
+	return tokens, false
+}
+
+// result 由剩余令牌数计算响应头所需的各项
+func result(tokens float64, allowed bool, rate Rate, cost int) Result {
+	res := Result{Allowed: allowed, Limit: rate.Burst, Remaining: int(math.Floor(tokens))}
+	per := rate.perMs()
+	if per <= 0 {
+		return res
+	}
+	res.Reset = time.Duration(math.Ceil((float64(rate.Burst)-tokens)/per)) * time.Millisecond
+	if !allowed {
+		res.RetryAfter = time.Duration(math.Ceil((float64(cost)-tokens)/per)) * time.Millisecond
+	}
+	return res
+}
+
+// clampCost 单次消耗不超过桶容量，否则永远取不到
+func clampCost(rate Rate, cost int) int {
+	if cost > rate.Burst {
+		return rate.Burst
+	}
+	if cost < 1 {
+		return 1
+	}
+	return cost
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	rate   Rate
+}
+
+// Memory 进程内令牌桶，多副本部署时各实例分别计数
+type Memory struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{buckets: map[string]*bucket{}, now: time.Now}
+}
+
+func (m *Memory) Take(ctx context.Context, key string, rate Rate, cost int) (Result, error) {
+	cost = clampCost(rate, cost)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if now.Sub(m.lastSweep) >= sweepInterval {
+		m.sweep(now)
+	}
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rate.Burst), last: now}
+		m.buckets[key] = b
+	}
+	b.rate = rate
+	tokens, allowed := refill(b.tokens, now.Sub(b.last).Milliseconds(), rate, cost)
+	b.tokens, b.last = tokens, now
+	return result(tokens, allowed, rate, cost), nil
+}
+
+func (m *Memory) sweep(now time.Time) {
+	m.lastSweep = now
+	for key, b := range m.buckets {
+		if tokens, _ := refill(b.tokens, now.Sub(b.last).Milliseconds(), b.rate, 0); tokens >= float64(b.rate.Burst) {
+			delete(m.buckets, key)
+		}
+	}
+}
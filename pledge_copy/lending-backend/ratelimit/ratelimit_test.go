@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryTokenBucket(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+	rate := Rate{PerMinute: 60, Burst: 3}
+	ctx := context.Background()
+
+	for i := 2; i >= 0; i-- {
+		res, _ := m.Take(ctx, "ip:1.2.3.4", rate, 1)
+		if !res.Allowed || res.Remaining != i || res.Limit != 3 {
+			t.Fatalf("take %d = %+v", i, res)
+		}
+	}
+	res, _ := m.Take(ctx, "ip:1.2.3.4", rate, 1)
+	if res.Allowed || res.RetryAfter != time.Second || res.Reset != 3*time.Second {
+		t.Fatalf("over limit = %+v", res)
+	}
+	if res, _ := m.Take(ctx, "ip:5.6.7.8", rate, 1); !res.Allowed {
+		t.Fatalf("other key limited: %+v", res)
+	}
+
+	// 每秒补充一个令牌
+	now = now.Add(1500 * time.Millisecond)
+	if res, _ := m.Take(ctx, "ip:1.2.3.4", rate, 1); !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("after refill = %+v", res)
+	}
+	// 消耗大于剩余令牌时拒绝，超过桶容量时按容量计
+	if res, _ := m.Take(ctx, "ip:1.2.3.4", rate, 5); res.Allowed || res.RetryAfter != 2500*time.Millisecond {
+		t.Fatalf("expensive = %+v", res)
+	}
+}
+
+func TestMemorySweep(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+	rate := Rate{PerMinute: 60, Burst: 10}
+	ctx := context.Background()
+	_, _ = m.Take(ctx, "a", rate, 1)
+	_, _ = m.Take(ctx, "b", Rate{PerMinute: 1, Burst: 10}, 10)
+
+	now = now.Add(sweepInterval)
+	_, _ = m.Take(ctx, "c", rate, 1)
+	if _, ok := m.buckets["a"]; ok {
+		t.Fatal("full bucket not swept")
+	}
+	if _, ok := m.buckets["b"]; !ok {
+		t.Fatal("draining bucket swept")
+	}
+}
@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"lending-copy/db"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// keyPrefix Redis 中令牌桶的键前缀
+const keyPrefix = "ratelimit:"
+
+// takeScript 原子地补充并扣除令牌；桶以 hash 保存剩余令牌数 t 与上次更新时间 ts（毫秒），补满后自动过期。
+// 时间取自调用方，各副本时钟偏差只影响补充速度的精度
+var takeScript = redis.NewScript(1, `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local cost = tonumber(ARGV[4])
+local b = redis.call('HMGET', KEYS[1], 't', 'ts')
+local tokens = tonumber(b[1]) or burst
+local ts = tonumber(b[2]) or now
+if now > ts then
+  tokens = math.min(burst, tokens + (now - ts) * rate)
+else
+  now = ts
+end
+local allowed = 0
+if tokens >= cost then
+  tokens = tokens - cost
+  allowed = 1
+end
+redis.call('HMSET', KEYS[1], 't', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// Redis 多副本共享的令牌桶
+type Redis struct {
+	now func() time.Time
+}
+
+func NewRedis() *Redis {
+	return &Redis{now: time.Now}
+}
+
+func (r *Redis) Take(ctx context.Context, key string, rate Rate, cost int) (Result, error) {
+	cost = clampCost(rate, cost)
+	reply, err := redis.Values(db.RedisEval(ctx, takeScript, keyPrefix+key,
+		strconv.FormatFloat(rate.perMs(), 'g', -1, 64), rate.Burst, r.now().UnixMilli(), cost))
+	if err != nil {
+		return Result{}, err
+	}
+	var (
+		allowed int
+		raw     string
+	)
+	if _, err := redis.Scan(reply, &allowed, &raw); err != nil {
+		return Result{}, err
+	}
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return Result{}, err
+	}
+	return result(tokens, allowed == 1, rate, cost), nil
+}
@@ -0,0 +1,300 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"lending-copy/log"
+	"lending-copy/metrics"
+	"lending-copy/schedule/models"
+
+	"github.com/robfig/cron/v3"
+)
+
+// 上一次执行还没结束时再次触发的处理方式
+const (
+	OverlapSkip  = "skip"
+	OverlapQueue = "queue"
+)
+
+// storeTimeout 写执行记录的超时；记录写在任务 ctx 之外，退出时被取消的执行也能落库
+const storeTimeout = 5 * time.Second
+
+var (
+	ErrUnknownJob = errors.New("unknown job")
+	ErrClosed     = errors.New("runner closed")
+)
+
+// Job 一个定时任务；Run 返回本次处理的条目数
+type Job struct {
+	Name     string
+	Spec     string
+	Schedule cron.Schedule
+	Timeout  time.Duration
+	Jitter   time.Duration
+	Overlap  string
+	Run      func(ctx context.Context) (int, error)
+}
+
+// Store 任务定义与执行记录的持久化，写失败只记日志，不影响任务执行
+type Store interface {
+	Save(ctx context.Context, jobs []models.Job) error
+	StartRun(ctx context.Context, run *models.JobRun) error
+	FinishRun(ctx context.Context, run *models.JobRun) error
+}
+
+// Runner 按 cron 调度任务：同一任务同时只跑一次，重叠的触发按 Overlap 跳过或排队，
+// 每次执行有独立超时，结果写入 Store 并记入 lending_job_runs_total{job,status}
+type Runner struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	store  Store
+
+	// mu 保护 entries、closed 与 rand；entry 的状态也只在持锁时读写
+	mu      sync.Mutex
+	entries map[string]*entry
+	closed  bool
+	rand    *rand.Rand
+	wg      sync.WaitGroup
+}
+
+type entry struct {
+	job     Job
+	timer   *time.Timer
+	gen     int
+	running bool
+	queued  bool
+}
+
+// NewRunner ctx 取消后不再启动新的执行，进行中的执行随之取消
+func NewRunner(ctx context.Context, store Store) *Runner {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Runner{
+		ctx:     ctx,
+		cancel:  cancel,
+		store:   store,
+		entries: map[string]*entry{},
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Schedule 以 jobs 替换当前的任务集合，新增及 Spec、Jitter 变化的任务重新计算下次触发时间；
+// 进行中的执行不受影响，同名任务保留执行中与排队状态，新配置从下一次执行开始生效
+func (r *Runner) Schedule(jobs []Job) {
+	defs := make([]models.Job, 0, len(jobs))
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	keep := make(map[string]bool, len(jobs))
+	for _, j := range jobs {
+		keep[j.Name] = true
+		e, ok := r.entries[j.Name]
+		if !ok {
+			e = &entry{}
+			r.entries[j.Name] = e
+		}
+		// 调度未变的任务保留原定时器，频繁重载不会把下次触发一直往后推
+		rearm := !ok || e.job.Spec != j.Spec || e.job.Jitter != j.Jitter
+		e.job = j
+		if rearm {
+			r.arm(e)
+		}
+		defs = append(defs, models.Job{
+			Name:     j.Name,
+			Schedule: j.Spec,
+			Timeout:  int64(j.Timeout / time.Second),
+			Jitter:   int64(j.Jitter / time.Second),
+			Overlap:  j.Overlap,
+		})
+	}
+	for name, e := range r.entries {
+		if !keep[name] {
+			e.stop()
+			delete(r.entries, name)
+		}
+	}
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	if err := r.store.Save(ctx, defs); err != nil {
+		log.Logger.Sugar().Error("Runner save jobs ", err)
+	}
+}
+
+// Trigger 立即执行一次；任务正在执行时按其 Overlap 处理
+func (r *Runner) Trigger(name, source string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return ErrClosed
+	}
+	e, ok := r.entries[name]
+	if !ok {
+		return ErrUnknownJob
+	}
+	r.fire(e, source)
+	return nil
+}
+
+// Shutdown 停止调度并取消进行中的执行，等待它们返回或 ctx 超时
+func (r *Runner) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	for _, e := range r.entries {
+		e.stop()
+		e.queued = false
+	}
+	r.mu.Unlock()
+	r.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// arm 按 Schedule 与 Jitter 设置下一次触发；gen 让重新调度前已触发的旧定时器失效
+func (r *Runner) arm(e *entry) {
+	e.stop()
+	e.gen++
+	gen := e.gen
+	now := time.Now().UTC()
+	next := e.job.Schedule.Next(now)
+	if next.IsZero() {
+		return
+	}
+	delay := next.Sub(now)
+	if e.job.Jitter > 0 {
+		delay += time.Duration(r.rand.Int63n(int64(e.job.Jitter)))
+	}
+	e.timer = time.AfterFunc(delay, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.closed || e.gen != gen {
+			return
+		}
+		r.arm(e)
+		r.fire(e, models.JobSourceSchedule)
+	})
+}
+
+// fire 启动一次执行，调用方持有 mu
+func (r *Runner) fire(e *entry, source string) {
+	if e.running {
+		if e.job.Overlap == OverlapQueue {
+			e.queued = true
+			return
+		}
+		r.wg.Add(1)
+		go r.skip(e.job.Name, source)
+		return
+	}
+	e.running = true
+	r.wg.Add(1)
+	go r.loop(e, e.job, source)
+}
+
+// loop 执行一次，结束时若有排队的触发则接着再执行一次
+func (r *Runner) loop(e *entry, job Job, source string) {
+	defer r.wg.Done()
+	for {
+		r.run(job, source)
+
+		r.mu.Lock()
+		if !e.queued || r.closed {
+			e.running = false
+			r.mu.Unlock()
+			return
+		}
+		e.queued = false
+		job, source = e.job, models.JobSourceQueued
+		r.mu.Unlock()
+	}
+}
+
+func (r *Runner) run(job Job, source string) {
+	ctx := r.ctx
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	run := &models.JobRun{Job: job.Name, Source: source, Status: models.JobStatusRunning, StartedAt: start.Unix()}
+	r.record(run, r.store.StartRun)
+
+	items, err := call(ctx, job.Run)
+	d := time.Since(start)
+	run.Status = status(ctx, r.ctx, err)
+	run.FinishedAt = time.Now().Unix()
+	run.DurationMs = d.Milliseconds()
+	run.Items = items
+	if err != nil {
+		run.Error = err.Error()
+		log.Logger.Sugar().Error("Job ", job.Name, " ", run.Status, " after ", d, ": ", err)
+	}
+	metrics.ObserveJob(job.Name, run.Status, d)
+	r.record(run, r.store.FinishRun)
+}
+
+func (r *Runner) skip(name, source string) {
+	defer r.wg.Done()
+	log.Logger.Sugar().Warn("Job ", name, " skipped: previous run still in progress")
+	now := time.Now().Unix()
+	metrics.ObserveJob(name, models.JobStatusSkipped, 0)
+	r.record(&models.JobRun{Job: name, Source: source, Status: models.JobStatusSkipped, StartedAt: now, FinishedAt: now}, r.store.StartRun)
+}
+
+func (r *Runner) record(run *models.JobRun, fn func(context.Context, *models.JobRun) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	if err := fn(ctx, run); err != nil {
+		log.Logger.Sugar().Error("Job ", run.Job, " record run ", err)
+	}
+}
+
+// call 执行任务，panic 转为错误，不让一个任务拖垮整个进程
+func call(ctx context.Context, fn func(context.Context) (int, error)) (items int, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v\n%s", p, debug.Stack())
+		}
+	}()
+	return fn(ctx)
+}
+
+// status 区分超时与退出取消：任务自身 ctx 到期而 Runner 未关闭时为 timeout
+func status(ctx, parent context.Context, err error) string {
+	switch {
+	case err == nil:
+		return models.JobStatusSuccess
+	case parent.Err() != nil:
+		return models.JobStatusCanceled
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return models.JobStatusTimeout
+	default:
+		return models.JobStatusFailed
+	}
+}
+
+func (e *entry) stop() {
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+}
@@ -0,0 +1,258 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"lending-copy/schedule/models"
+)
+
+type memStore struct {
+	mu   sync.Mutex
+	jobs []models.Job
+	runs []models.JobRun
+}
+
+func (s *memStore) Save(ctx context.Context, jobs []models.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = jobs
+	return nil
+}
+
+func (s *memStore) StartRun(ctx context.Context, run *models.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run.Id = int64(len(s.runs) + 1)
+	s.runs = append(s.runs, *run)
+	return nil
+}
+
+func (s *memStore) FinishRun(ctx context.Context, run *models.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs[run.Id-1] = *run
+	return nil
+}
+
+// statuses 按记录顺序返回状态与来源
+func (s *memStore) statuses() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]string, len(s.runs))
+	for i, r := range s.runs {
+		out[i] = r.Source + ":" + r.Status
+	}
+	return out
+}
+
+// never 只能手动触发
+type never struct{}
+
+func (never) Next(time.Time) time.Time { return time.Time{} }
+
+// every 测试用的短间隔调度
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time { return t.Add(time.Duration(e)) }
+
+func blocking(started chan<- struct{}, release <-chan struct{}) func(context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) {
+		started <- struct{}{}
+		<-release
+		return 1, nil
+	}
+}
+
+func shutdown(t *testing.T, r *Runner) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func equal(a, b []string) bool {
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
+
+func TestOverlapSkip(t *testing.T) {
+	store := &memStore{}
+	r := NewRunner(context.Background(), store)
+	started, release := make(chan struct{}, 4), make(chan struct{})
+	r.Schedule([]Job{{Name: "sync", Spec: "@every 1m", Schedule: never{}, Timeout: time.Second, Overlap: OverlapSkip, Run: blocking(started, release)}})
+
+	if err := r.Trigger("sync", models.JobSourceManual); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if err := r.Trigger("sync", models.JobSourceSchedule); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	shutdown(t, r)
+
+	got := store.statuses()
+	if !equal(got, []string{"manual:success", "schedule:skipped"}) && !equal(got, []string{"schedule:skipped", "manual:success"}) {
+		t.Fatalf("runs = %v", got)
+	}
+	if len(store.jobs) != 1 || store.jobs[0].Name != "sync" || store.jobs[0].Timeout != 1 || store.jobs[0].Schedule != "@every 1m" {
+		t.Fatalf("jobs = %+v", store.jobs)
+	}
+}
+
+func TestOverlapQueueCoalesces(t *testing.T) {
+	store := &memStore{}
+	r := NewRunner(context.Background(), store)
+	started, release := make(chan struct{}, 4), make(chan struct{}, 4)
+	r.Schedule([]Job{{Name: "sync", Schedule: never{}, Overlap: OverlapQueue, Run: blocking(started, release)}})
+
+	_ = r.Trigger("sync", models.JobSourceManual)
+	<-started
+	_ = r.Trigger("sync", models.JobSourceManual)
+	_ = r.Trigger("sync", models.JobSourceManual)
+	release <- struct{}{}
+	<-started
+	release <- struct{}{}
+	shutdown(t, r)
+
+	if got := store.statuses(); !equal(got, []string{"manual:success", "queued:success"}) {
+		t.Fatalf("runs = %v", got)
+	}
+}
+
+func TestRunOutcomes(t *testing.T) {
+	store := &memStore{}
+	r := NewRunner(context.Background(), store)
+	r.Schedule([]Job{
+		{Name: "slow", Schedule: never{}, Timeout: 20 * time.Millisecond, Run: func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 3, ctx.Err()
+		}},
+		{Name: "boom", Schedule: never{}, Run: func(context.Context) (int, error) { panic("boom") }},
+		{Name: "fail", Schedule: never{}, Run: func(context.Context) (int, error) { return 2, errors.New("rpc down") }},
+	})
+	if err := r.Trigger("missing", models.JobSourceManual); err != ErrUnknownJob {
+		t.Fatalf("err = %v, want ErrUnknownJob", err)
+	}
+	for _, name := range []string{"slow", "boom", "fail"} {
+		_ = r.Trigger(name, models.JobSourceManual)
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(store.statuses()) < 3 || strings.Contains(strings.Join(store.statuses(), ","), models.JobStatusRunning) {
+		if time.Now().After(deadline) {
+			t.Fatalf("runs = %v", store.statuses())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	shutdown(t, r)
+
+	byJob := map[string]models.JobRun{}
+	for _, run := range store.runs {
+		byJob[run.Job] = run
+	}
+	if run := byJob["slow"]; run.Status != models.JobStatusTimeout || run.Items != 3 {
+		t.Fatalf("slow = %+v", run)
+	}
+	if run := byJob["boom"]; run.Status != models.JobStatusFailed || !strings.HasPrefix(run.Error, "panic: boom") {
+		t.Fatalf("boom = %+v", run)
+	}
+	if run := byJob["fail"]; run.Status != models.JobStatusFailed || run.Error != "rpc down" || run.Items != 2 {
+		t.Fatalf("fail = %+v", run)
+	}
+}
+
+func TestScheduleAndShutdown(t *testing.T) {
+	store := &memStore{}
+	r := NewRunner(context.Background(), store)
+	started := make(chan struct{}, 1)
+	r.Schedule([]Job{{Name: "tick", Schedule: every(10 * time.Millisecond), Jitter: 5 * time.Millisecond, Overlap: OverlapQueue, Run: func(ctx context.Context) (int, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}}})
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("scheduled job never ran")
+	}
+	shutdown(t, r)
+	if got := store.statuses(); !equal(got, []string{"schedule:canceled"}) {
+		t.Fatalf("runs = %v", got)
+	}
+	if err := r.Trigger("tick", models.JobSourceManual); err != ErrClosed {
+		t.Fatalf("err = %v, want ErrClosed", err)
+	}
+}
+
+func TestScheduleRearmsOnlyChangedJobs(t *testing.T) {
+	r := NewRunner(context.Background(), &memStore{})
+	defer shutdown(t, r)
+	run := func(context.Context) (int, error) { return 0, nil }
+	jobs := func(spec string, timeout, jitter time.Duration) []Job {
+		return []Job{{Name: "sync", Spec: spec, Schedule: every(time.Hour), Timeout: timeout, Jitter: jitter, Run: run}}
+	}
+	gen := func() int {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.entries["sync"].gen
+	}
+
+	r.Schedule(jobs("@every 1h", time.Second, 0))
+	if gen() != 1 {
+		t.Fatalf("新任务应设置定时器: gen = %d", gen())
+	}
+	// 只改超时不影响下次触发时间
+	r.Schedule(jobs("@every 1h", 2*time.Second, 0))
+	if gen() != 1 {
+		t.Fatalf("调度未变不应重新设置定时器: gen = %d", gen())
+	}
+	r.mu.Lock()
+	timeout := r.entries["sync"].job.Timeout
+	r.mu.Unlock()
+	if timeout != 2*time.Second {
+		t.Fatalf("新配置未生效: timeout = %s", timeout)
+	}
+	r.Schedule(jobs("@every 1h", 2*time.Second, time.Second))
+	if gen() != 2 {
+		t.Fatalf("Jitter 变化应重新设置定时器: gen = %d", gen())
+	}
+	r.Schedule(jobs("@every 2h", 2*time.Second, time.Second))
+	if gen() != 3 {
+		t.Fatalf("Spec 变化应重新设置定时器: gen = %d", gen())
+	}
+}
+
+func TestFrequentReloadStillFires(t *testing.T) {
+	r := NewRunner(context.Background(), &memStore{})
+	defer shutdown(t, r)
+	fired := make(chan struct{}, 1)
+	job := []Job{{Name: "tick", Spec: "@every 50ms", Schedule: every(50 * time.Millisecond), Run: func(context.Context) (int, error) {
+		select {
+		case fired <- struct{}{}:
+		default:
+		}
+		return 0, nil
+	}}}
+	r.Schedule(job)
+	// 重载间隔短于调度间隔，原定时器不被重置才能按时触发
+	deadline := time.After(2 * time.Second)
+	reload := time.NewTicker(10 * time.Millisecond)
+	defer reload.Stop()
+	for {
+		select {
+		case <-fired:
+			return
+		case <-reload.C:
+			r.Schedule(job)
+		case <-deadline:
+			t.Fatal("频繁重载时任务没有触发")
+		}
+	}
+}
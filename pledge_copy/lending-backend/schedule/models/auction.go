@@ -0,0 +1,162 @@
+package models
+
+import (
+	"errors"
+
+	"lending-copy/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 金额与 USD 值均以十进制字符串保存最小单位；USD 与合约 highestBidUsd 同一口径（amount * answer / 10^feedDecimals）
+
+type Auction struct {
+	Id            int    `json:"-" gorm:"column:id;primaryKey;autoIncrement"`
+	ChainId       string `json:"chain_id" gorm:"column:chain_id;type:varchar(32);uniqueIndex:uk_auction"`
+	Contract      string `json:"contract" gorm:"column:contract;type:varchar(42);uniqueIndex:uk_auction"`
+	AuctionId     int64  `json:"auction_id" gorm:"column:auction_id;uniqueIndex:uk_auction"`
+	Seller        string `json:"seller" gorm:"column:seller;type:varchar(42);index"`
+	NftAddress    string `json:"nft_address" gorm:"column:nft_address;type:varchar(42)"`
+	TokenId       string `json:"token_id" gorm:"column:token_id"`
+	EndTime       int64  `json:"end_time" gorm:"column:end_time"`
+	BidToken      string `json:"bid_token" gorm:"column:bid_token;type:varchar(42)"`
+	HighestBid    string `json:"highest_bid" gorm:"column:highest_bid"`
+	HighestBidUsd string `json:"highest_bid_usd" gorm:"column:highest_bid_usd"`
+	HighestBidder string `json:"highest_bidder" gorm:"column:highest_bidder;type:varchar(42)"`
+	BidCount      int    `json:"bid_count" gorm:"column:bid_count"`
+	Ended         bool   `json:"ended" gorm:"column:ended"`
+	Winner        string `json:"winner" gorm:"column:winner;type:varchar(42)"`
+	FinalAmount   string `json:"final_amount" gorm:"column:final_amount"`
+	FinalUsd      string `json:"final_usd" gorm:"column:final_usd"`
+	CreatedBlock  uint64 `json:"created_block" gorm:"column:created_block"`
+	CreatedTx     string `json:"created_tx" gorm:"column:created_tx;type:varchar(66)"`
+	EndedBlock    uint64 `json:"ended_block" gorm:"column:ended_block"`
+	EndedTx       string `json:"ended_tx" gorm:"column:ended_tx;type:varchar(66)"`
+	CreatedAt     string `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt     string `json:"updated_at" gorm:"column:updated_at"`
+}
+
+type AuctionBid struct {
+	Id          int    `json:"-" gorm:"column:id;primaryKey;autoIncrement"`
+	ChainId     string `json:"chain_id" gorm:"column:chain_id;type:varchar(32);uniqueIndex:uk_auction_bid;index:idx_auction_bid_auction"`
+	Contract    string `json:"contract" gorm:"column:contract;type:varchar(42);index:idx_auction_bid_auction"`
+	AuctionId   int64  `json:"auction_id" gorm:"column:auction_id;index:idx_auction_bid_auction"`
+	Bidder      string `json:"bidder" gorm:"column:bidder;type:varchar(42)"`
+	BidToken    string `json:"bid_token" gorm:"column:bid_token;type:varchar(42)"`
+	Amount      string `json:"amount" gorm:"column:amount"`
+	UsdValue    string `json:"usd_value" gorm:"column:usd_value"`
+	BlockNumber uint64 `json:"block_number" gorm:"column:block_number"`
+	BlockTime   int64  `json:"block_time" gorm:"column:block_time"`
+	TxHash      string `json:"tx_hash" gorm:"column:tx_hash;type:varchar(66);uniqueIndex:uk_auction_bid"`
+	LogIndex    uint   `json:"log_index" gorm:"column:log_index;uniqueIndex:uk_auction_bid"`
+	CreatedAt   string `json:"created_at" gorm:"column:created_at"`
+}
+
+const (
+	RefundActionQueued    = "queued"
+	RefundActionWithdrawn = "withdrawn"
+)
+
+type AuctionRefund struct {
+	Id          int    `json:"-" gorm:"column:id;primaryKey;autoIncrement"`
+	ChainId     string `json:"chain_id" gorm:"column:chain_id;type:varchar(32);uniqueIndex:uk_auction_refund;index:idx_auction_refund_bidder"`
+	Contract    string `json:"contract" gorm:"column:contract;type:varchar(42);index:idx_auction_refund_bidder"`
+	Bidder      string `json:"bidder" gorm:"column:bidder;type:varchar(42);index:idx_auction_refund_bidder"`
+	Action      string `json:"action" gorm:"column:action;type:varchar(16)"`
+	AuctionId   *int64 `json:"auction_id" gorm:"column:auction_id"`
+	RefundToken string `json:"refund_token" gorm:"column:refund_token;type:varchar(42)"`
+	Amount      string `json:"amount" gorm:"column:amount"`
+	UsdValue    string `json:"usd_value" gorm:"column:usd_value"`
+	BlockNumber uint64 `json:"block_number" gorm:"column:block_number"`
+	BlockTime   int64  `json:"block_time" gorm:"column:block_time"`
+	TxHash      string `json:"tx_hash" gorm:"column:tx_hash;type:varchar(66);uniqueIndex:uk_auction_refund"`
+	LogIndex    uint   `json:"log_index" gorm:"column:log_index;uniqueIndex:uk_auction_refund"`
+	CreatedAt   string `json:"created_at" gorm:"column:created_at"`
+}
+
+// AuctionSyncState 每个拍卖合约已索引到的区块
+type AuctionSyncState struct {
+	Id        int    `json:"-" gorm:"column:id;primaryKey;autoIncrement"`
+	ChainId   string `json:"chain_id" gorm:"column:chain_id;type:varchar(32);uniqueIndex:uk_auction_sync"`
+	Contract  string `json:"contract" gorm:"column:contract;type:varchar(42);uniqueIndex:uk_auction_sync"`
+	LastBlock uint64 `json:"last_block" gorm:"column:last_block"`
+	UpdatedAt string `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Auction) TableName() string          { return "auctions" }
+func (AuctionBid) TableName() string       { return "auction_bids" }
+func (AuctionRefund) TableName() string    { return "auction_refunds" }
+func (AuctionSyncState) TableName() string { return "auction_sync_state" }
+
+func NewAuction() *Auction {
+	return &Auction{}
+}
+
+// LastSyncedBlock 返回已索引到的区块；从未同步过时 ok 为 false
+func (a *Auction) LastSyncedBlock(tx *gorm.DB, chainId, contract string) (uint64, bool, error) {
+	state := AuctionSyncState{}
+	err := tx.Table("auction_sync_state").Where("chain_id=? and contract=?", chainId, contract).First(&state).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return state.LastBlock, true, nil
+}
+
+func (a *Auction) SaveSyncedBlock(tx *gorm.DB, chainId, contract string, block uint64) error {
+	return tx.Table("auction_sync_state").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_block", "updated_at"}),
+	}).Create(&AuctionSyncState{
+		ChainId:   chainId,
+		Contract:  contract,
+		LastBlock: block,
+		UpdatedAt: utils.GetCurDateTimeFormat(),
+	}).Error
+}
+
+// CreateAuction 写入 AuctionCreated；重复同步同一事件时忽略
+func (a *Auction) CreateAuction(tx *gorm.DB, auction *Auction) error {
+	nowDateTime := utils.GetCurDateTimeFormat()
+	auction.CreatedAt = nowDateTime
+	auction.UpdatedAt = nowDateTime
+	return tx.Table("auctions").Clauses(clause.OnConflict{DoNothing: true}).Create(auction).Error
+}
+
+// SaveBid 写入出价并刷新拍卖的最高价；出价已存在时不重复累计
+func (a *Auction) SaveBid(tx *gorm.DB, bid *AuctionBid) error {
+	bid.CreatedAt = utils.GetCurDateTimeFormat()
+	res := tx.Table("auction_bids").Clauses(clause.OnConflict{DoNothing: true}).Create(bid)
+	if res.Error != nil || res.RowsAffected == 0 {
+		return res.Error
+	}
+	return tx.Table("auctions").Where("chain_id=? and contract=? and auction_id=?", bid.ChainId, bid.Contract, bid.AuctionId).Updates(map[string]interface{}{
+		"bid_token":       bid.BidToken,
+		"highest_bid":     bid.Amount,
+		"highest_bid_usd": bid.UsdValue,
+		"highest_bidder":  bid.Bidder,
+		"bid_count":       gorm.Expr("bid_count + 1"),
+		"updated_at":      bid.CreatedAt,
+	}).Error
+}
+
+// EndAuction 记录 AuctionEnded 的成交结果
+func (a *Auction) EndAuction(tx *gorm.DB, chainId, contract string, auctionId int64, ended *Auction) error {
+	return tx.Table("auctions").Where("chain_id=? and contract=? and auction_id=?", chainId, contract, auctionId).Updates(map[string]interface{}{
+		"ended":        true,
+		"winner":       ended.Winner,
+		"final_amount": ended.FinalAmount,
+		"final_usd":    ended.FinalUsd,
+		"ended_block":  ended.EndedBlock,
+		"ended_tx":     ended.EndedTx,
+		"updated_at":   utils.GetCurDateTimeFormat(),
+	}).Error
+}
+
+func (a *Auction) SaveRefund(tx *gorm.DB, refund *AuctionRefund) error {
+	refund.CreatedAt = utils.GetCurDateTimeFormat()
+	return tx.Table("auction_refunds").Clauses(clause.OnConflict{DoNothing: true}).Create(refund).Error
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"context"
+
+	"lending-copy/db"
+	"lending-copy/utils"
+
+	"gorm.io/gorm/clause"
+)
+
+// 单次执行的结果；skipped 表示触发时上一次还没结束而被跳过
+const (
+	JobStatusRunning  = "running"
+	JobStatusSuccess  = "success"
+	JobStatusFailed   = "failed"
+	JobStatusTimeout  = "timeout"
+	JobStatusCanceled = "canceled"
+	JobStatusSkipped  = "skipped"
+)
+
+// 触发来源
+const (
+	JobSourceStartup  = "startup"
+	JobSourceSchedule = "schedule"
+	JobSourceManual   = "manual"
+	JobSourceQueued   = "queued"
+)
+
+// JobTriggerChannel 管理接口经 Redis 该频道通知定时任务进程立即执行一次，消息内容为任务名
+const JobTriggerChannel = "lending:jobs:trigger"
+
+// Job 定时任务进程当前注册的任务及其调度策略，启动与热更新时写入，API 的管理接口据此列出任务
+type Job struct {
+	Id        int    `json:"-" gorm:"column:id;primaryKey;autoIncrement"`
+	Name      string `json:"name" gorm:"column:name;type:varchar(32);uniqueIndex:uk_jobs_name"`
+	Schedule  string `json:"schedule" gorm:"column:schedule;type:varchar(64)"`
+	Timeout   int64  `json:"timeout" gorm:"column:timeout"`
+	Jitter    int64  `json:"jitter" gorm:"column:jitter"`
+	Overlap   string `json:"overlap" gorm:"column:overlap;type:varchar(8)"`
+	UpdatedAt string `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Job) TableName() string { return "jobs" }
+
+// JobRun 一次任务执行记录；时间为 unix 秒，items 为本次处理的条目数（变化的池子、写入的事件等）
+type JobRun struct {
+	Id         int64  `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	Job        string `json:"job" gorm:"column:job;type:varchar(32);index:idx_job_runs_job"`
+	Source     string `json:"source" gorm:"column:source;type:varchar(16)"`
+	Status     string `json:"status" gorm:"column:status;type:varchar(16)"`
+	StartedAt  int64  `json:"started_at" gorm:"column:started_at"`
+	FinishedAt int64  `json:"finished_at" gorm:"column:finished_at"`
+	DurationMs int64  `json:"duration_ms" gorm:"column:duration_ms"`
+	Items      int    `json:"items" gorm:"column:items"`
+	Error      string `json:"error" gorm:"column:error;type:text"`
+}
+
+func (JobRun) TableName() string { return "job_runs" }
+
+func NewJob() *Job {
+	return &Job{}
+}
+
+// Save 按名称写入任务定义
+func (j *Job) Save(ctx context.Context, jobs []Job) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	now := utils.GetCurDateTimeFormat()
+	for i := range jobs {
+		jobs[i].UpdatedAt = now
+	}
+	return db.Mysql.WithContext(ctx).Table("jobs").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"schedule", "timeout", "jitter", "overlap", "updated_at"}),
+	}).Create(&jobs).Error
+}
+
+func (j *Job) List(ctx context.Context) ([]Job, error) {
+	var rows []Job
+	err := db.Mysql.WithContext(ctx).Table("jobs").Order("name asc").Find(&rows).Error
+	return rows, err
+}
+
+func (j *Job) Exists(ctx context.Context, name string) (bool, error) {
+	var n int64
+	err := db.Mysql.WithContext(ctx).Table("jobs").Where("name = ?", name).Count(&n).Error
+	return n > 0, err
+}
+
+// StartRun 插入一条 running 记录并回填 Id
+func (j *Job) StartRun(ctx context.Context, run *JobRun) error {
+	return db.Mysql.WithContext(ctx).Table("job_runs").Create(run).Error
+}
+
+// FinishRun 写入执行结果
+func (j *Job) FinishRun(ctx context.Context, run *JobRun) error {
+	return db.Mysql.WithContext(ctx).Table("job_runs").Where("id = ?", run.Id).Updates(map[string]interface{}{
+		"status":      run.Status,
+		"finished_at": run.FinishedAt,
+		"duration_ms": run.DurationMs,
+		"items":       run.Items,
+		"error":       run.Error,
+	}).Error
+}
+
+// LastRuns 每个任务最近一次执行记录，按任务名索引
+func (j *Job) LastRuns(ctx context.Context) (map[string]JobRun, error) {
+	var rows []JobRun
+	latest := db.Mysql.Table("job_runs").Select("MAX(id)").Group("job")
+	if err := db.Mysql.WithContext(ctx).Table("job_runs").Where("id IN (?)", latest).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	runs := make(map[string]JobRun, len(rows))
+	for _, r := range rows {
+		runs[r.Job] = r
+	}
+	return runs, nil
+}
+
+// Runs 按时间倒序分页查询某个任务的执行记录
+func (j *Job) Runs(ctx context.Context, name string, page, pageSize int) ([]JobRun, int64, error) {
+	var (
+		rows  []JobRun
+		total int64
+	)
+	query := db.Mysql.WithContext(ctx).Table("job_runs").Where("job = ?", name)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("id desc").Limit(pageSize).Offset((page - 1) * pageSize).Find(&rows).Error
+	return rows, total, err
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"errors"
 
 	"lending-copy/db"
@@ -57,9 +58,9 @@ func (p *PoolBase) TableName() string {
 	return "poolbases"
 }
 
-func (p *PoolBase) SavePoolBase(chainId, poolId string, poolBase *PoolBase) error {
+func (p *PoolBase) SavePoolBase(ctx context.Context, chainId, poolId string, poolBase *PoolBase) error {
 	nowDateTime := utils.GetCurDateTimeFormat()
-	err, symbol := p.SaveTokenInfo(poolBase)
+	err, symbol := p.SaveTokenInfo(ctx, poolBase)
 	if err != nil {
 		log.Logger.Error(err.Error())
 		return err
@@ -67,28 +68,28 @@ func (p *PoolBase) SavePoolBase(chainId, poolId string, poolBase *PoolBase) erro
 	poolBase.BorrowTokenSymbol = symbol[0]
 	poolBase.LendTokenSymbol = symbol[1]
 
-	err = db.Mysql.Table("poolbases").Where("chain_id=? and pool_id=?", chainId, poolId).First(&p).Error
+	err = db.Mysql.WithContext(ctx).Table("poolbases").Where("chain_id=? and pool_id=?", chainId, poolId).First(&p).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			poolBase.CreatedAt = nowDateTime
 			poolBase.UpdatedAt = nowDateTime
-			return db.Mysql.Table("poolbases").Create(poolBase).Error
+			return db.Mysql.WithContext(ctx).Table("poolbases").Create(poolBase).Error
 		}
 		return errors.New("record select err " + err.Error())
 	}
 	poolBase.UpdatedAt = nowDateTime
-	return db.Mysql.Table("poolbases").Where("chain_id=? and pool_id=?", chainId, poolId).Updates(poolBase).Error
+	return db.Mysql.WithContext(ctx).Table("poolbases").Where("chain_id=? and pool_id=?", chainId, poolId).Updates(poolBase).Error
 }
 
-func (p *PoolBase) SaveTokenInfo(base *PoolBase) (error, []string) {
+func (p *PoolBase) SaveTokenInfo(ctx context.Context, base *PoolBase) (error, []string) {
 	tokenInfo := TokenInfo{}
 	tokenSymbol := []string{"", ""}
 	nowDateTime := utils.GetCurDateTimeFormat()
 
-	err := db.Mysql.Table("token_info").Where("chain_id=? and token=?", base.ChainId, base.BorrowToken).First(&tokenInfo).Error
+	err := db.Mysql.WithContext(ctx).Table("token_info").Where("chain_id=? and token=?", base.ChainId, base.BorrowToken).First(&tokenInfo).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			err = db.Mysql.Table("token_info").Create(&TokenInfo{
+			err = db.Mysql.WithContext(ctx).Table("token_info").Create(&TokenInfo{
 				Token:     base.BorrowToken,
 				ChainId:   base.ChainId,
 				CreatedAt: nowDateTime,
@@ -104,10 +105,10 @@ func (p *PoolBase) SaveTokenInfo(base *PoolBase) (error, []string) {
 	tokenSymbol[0] = tokenInfo.Symbol
 
 	tokenInfo = TokenInfo{}
-	err = db.Mysql.Table("token_info").Where("chain_id=? and token=?", base.ChainId, base.LendToken).First(&tokenInfo).Error
+	err = db.Mysql.WithContext(ctx).Table("token_info").Where("chain_id=? and token=?", base.ChainId, base.LendToken).First(&tokenInfo).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			err = db.Mysql.Table("token_info").Create(&TokenInfo{
+			err = db.Mysql.WithContext(ctx).Table("token_info").Create(&TokenInfo{
 				Token:     base.LendToken,
 				ChainId:   base.ChainId,
 				CreatedAt: nowDateTime,
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"errors"
 
 	"lending-copy/db"
@@ -29,16 +30,16 @@ func NewPoolData() *PoolData {
 
 func (PoolData) TableName() string { return "pooldata" }
 
-func (t *PoolData) SavePoolData(chainId, poolId string, poolData *PoolData) error {
+func (t *PoolData) SavePoolData(ctx context.Context, chainId, poolId string, poolData *PoolData) error {
 	nowDateTime := utils.GetCurDateTimeFormat()
 	poolData.UpdatedAt = nowDateTime
-	err := db.Mysql.Table("pooldata").Where("chain_id=? and pool_id=?", chainId, poolId).First(&t).Error
+	err := db.Mysql.WithContext(ctx).Table("pooldata").Where("chain_id=? and pool_id=?", chainId, poolId).First(&t).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			poolData.CreatedAt = nowDateTime
-			return db.Mysql.Table("pooldata").Create(poolData).Error
+			return db.Mysql.WithContext(ctx).Table("pooldata").Create(poolData).Error
 		}
 		return errors.New("record select err " + err.Error())
 	}
-	return db.Mysql.Table("pooldata").Where("chain_id=? and pool_id=?", chainId, poolId).Updates(poolData).Error
+	return db.Mysql.WithContext(ctx).Table("pooldata").Where("chain_id=? and pool_id=?", chainId, poolId).Updates(poolData).Error
 }
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+
+	"lending-copy/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProxyState 代理最近一次检查到的实现、管理员与版本
+type ProxyState struct {
+	Id             int    `json:"-" gorm:"column:id;primaryKey;autoIncrement"`
+	ChainId        string `json:"chain_id" gorm:"column:chain_id;type:varchar(32);uniqueIndex:uk_proxy_state"`
+	Proxy          string `json:"proxy" gorm:"column:proxy;type:varchar(42);uniqueIndex:uk_proxy_state"`
+	Name           string `json:"name" gorm:"column:name"`
+	Implementation string `json:"implementation" gorm:"column:implementation;type:varchar(42)"`
+	Admin          string `json:"admin" gorm:"column:admin;type:varchar(42)"`
+	Version        string `json:"version" gorm:"column:version"`
+	LastBlock      uint64 `json:"last_block" gorm:"column:last_block"`
+	CheckedAt      string `json:"checked_at" gorm:"column:checked_at"`
+	UpdatedAt      string `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// ProxyUpgrade 一条 Upgraded 事件
+type ProxyUpgrade struct {
+	Id             int    `json:"-" gorm:"column:id;primaryKey;autoIncrement"`
+	ChainId        string `json:"chain_id" gorm:"column:chain_id;type:varchar(32);uniqueIndex:uk_proxy_upgrade;index:idx_proxy_upgrade_proxy"`
+	Proxy          string `json:"proxy" gorm:"column:proxy;type:varchar(42);index:idx_proxy_upgrade_proxy"`
+	Implementation string `json:"implementation" gorm:"column:implementation;type:varchar(42)"`
+	Allowed        bool   `json:"allowed" gorm:"column:allowed"`
+	BlockNumber    uint64 `json:"block_number" gorm:"column:block_number"`
+	BlockTime      int64  `json:"block_time" gorm:"column:block_time"`
+	TxHash         string `json:"tx_hash" gorm:"column:tx_hash;type:varchar(66);uniqueIndex:uk_proxy_upgrade"`
+	LogIndex       uint   `json:"log_index" gorm:"column:log_index;uniqueIndex:uk_proxy_upgrade"`
+	CreatedAt      string `json:"created_at" gorm:"column:created_at"`
+}
+
+func (ProxyState) TableName() string   { return "proxy_state" }
+func (ProxyUpgrade) TableName() string { return "proxy_upgrades" }
+
+func NewProxyState() *ProxyState {
+	return &ProxyState{}
+}
+
+// GetProxyState 从未检查过时返回 nil
+func (p *ProxyState) GetProxyState(tx *gorm.DB, chainId, proxy string) (*ProxyState, error) {
+	state := ProxyState{}
+	err := tx.Table("proxy_state").Where("chain_id=? and proxy=?", chainId, proxy).First(&state).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (p *ProxyState) SaveProxyState(tx *gorm.DB, state *ProxyState) error {
+	nowDateTime := utils.GetCurDateTimeFormat()
+	state.CheckedAt = nowDateTime
+	state.UpdatedAt = nowDateTime
+	return tx.Table("proxy_state").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "proxy"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "implementation", "admin", "version", "last_block", "checked_at", "updated_at"}),
+	}).Create(state).Error
+}
+
+// SaveUpgrade 重复扫描同一事件时忽略
+func (p *ProxyState) SaveUpgrade(tx *gorm.DB, upgrade *ProxyUpgrade) error {
+	upgrade.CreatedAt = utils.GetCurDateTimeFormat()
+	return tx.Table("proxy_upgrades").Clauses(clause.OnConflict{DoNothing: true}).Create(upgrade).Error
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"context"
+
+	"lending-copy/db"
+	"lending-copy/utils"
+
+	"gorm.io/gorm/clause"
+)
+
+const (
+	SyncJobPool    = "pool_sync"
+	SyncJobAuction = "auction_sync"
+)
+
+// SyncStatus 同步任务在每条链上最近一次成功完成的时间（unix 秒）与已同步到的区块，
+// 定时任务写入，API 的 /status 据此判断数据是否过期
+type SyncStatus struct {
+	Id            int    `json:"-" gorm:"column:id;primaryKey;autoIncrement"`
+	Job           string `json:"job" gorm:"column:job;type:varchar(32);uniqueIndex:uk_sync_status"`
+	ChainId       string `json:"chain_id" gorm:"column:chain_id;type:varchar(32);uniqueIndex:uk_sync_status"`
+	LastSuccessAt int64  `json:"last_success_at" gorm:"column:last_success_at"`
+	LastBlock     uint64 `json:"last_block" gorm:"column:last_block"`
+	UpdatedAt     string `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (SyncStatus) TableName() string { return "sync_status" }
+
+func NewSyncStatus() *SyncStatus {
+	return &SyncStatus{}
+}
+
+// MarkSuccess 记录一次成功的同步；block 为 0 表示该任务不按区块同步
+func (s *SyncStatus) MarkSuccess(ctx context.Context, job, chainId string, at int64, block uint64) error {
+	return db.Mysql.WithContext(ctx).Table("sync_status").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job"}, {Name: "chain_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_success_at", "last_block", "updated_at"}),
+	}).Create(&SyncStatus{
+		Job:           job,
+		ChainId:       chainId,
+		LastSuccessAt: at,
+		LastBlock:     block,
+		UpdatedAt:     utils.GetCurDateTimeFormat(),
+	}).Error
+}
+
+func (s *SyncStatus) List(ctx context.Context) ([]SyncStatus, error) {
+	var rows []SyncStatus
+	err := db.Mysql.WithContext(ctx).Table("sync_status").Order("job asc, chain_id asc").Find(&rows).Error
+	return rows, err
+}
@@ -6,4 +6,14 @@ func InitTable() {
 	db.Mysql.AutoMigrate(&PoolBase{})
 	db.Mysql.AutoMigrate(&PoolData{})
 	db.Mysql.AutoMigrate(&TokenInfo{})
+	db.Mysql.AutoMigrate(&Auction{})
+	db.Mysql.AutoMigrate(&AuctionBid{})
+	db.Mysql.AutoMigrate(&AuctionRefund{})
+	db.Mysql.AutoMigrate(&AuctionSyncState{})
+	db.Mysql.AutoMigrate(&ProxyState{})
+	db.Mysql.AutoMigrate(&ProxyUpgrade{})
+	db.Mysql.AutoMigrate(&TokenListVersion{})
+	db.Mysql.AutoMigrate(&SyncStatus{})
+	db.Mysql.AutoMigrate(&Job{})
+	db.Mysql.AutoMigrate(&JobRun{})
 }
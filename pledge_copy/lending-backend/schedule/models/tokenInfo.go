@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 
@@ -27,19 +28,19 @@ func NewTokenInfo() *TokenInfo {
 	return &TokenInfo{}
 }
 
-func (t *TokenInfo) GetTokenInfo(token, chainId string) (error, TokenInfo) {
+func (t *TokenInfo) GetTokenInfo(ctx context.Context, token, chainId string) (error, TokenInfo) {
 	tokenInfo := TokenInfo{}
 	redisKey := "token_info:" + chainId + ":" + token
-	redisTokenInfoBytes, _ := db.RedisGet(redisKey)
+	redisTokenInfoBytes, _ := db.RedisGet(ctx, redisKey)
 	if len(redisTokenInfoBytes) <= 0 {
-		err := db.Mysql.Table("token_info").Where("token=? and chain_id=?", token, chainId).First(&tokenInfo).Error
+		err := db.Mysql.WithContext(ctx).Table("token_info").Where("token=? and chain_id=?", token, chainId).First(&tokenInfo).Error
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, tokenInfo
 			}
 			return err, tokenInfo
 		}
-		_ = db.RedisSet(redisKey, RedisTokenInfo{
+		_ = db.RedisSet(ctx, redisKey, RedisTokenInfo{
 			Token:   token,
 			ChainId: chainId,
 			Price:   tokenInfo.Price,
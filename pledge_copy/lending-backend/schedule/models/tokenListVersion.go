@@ -0,0 +1,15 @@
+package models
+
+// TokenListVersion 每个 token list（全部链或单条链）当前的版本号与对应的代币快照
+type TokenListVersion struct {
+	Id        int    `json:"-" gorm:"column:id;primaryKey;autoIncrement"`
+	Scope     string `json:"scope" gorm:"column:scope;type:varchar(32);uniqueIndex"`
+	Major     int    `json:"major" gorm:"column:major"`
+	Minor     int    `json:"minor" gorm:"column:minor"`
+	Patch     int    `json:"patch" gorm:"column:patch"`
+	Tokens    string `json:"tokens" gorm:"column:tokens;type:longtext"`
+	Timestamp string `json:"timestamp" gorm:"column:timestamp"`
+	UpdatedAt string `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (TokenListVersion) TableName() string { return "token_list_version" }
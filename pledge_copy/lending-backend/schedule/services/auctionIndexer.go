@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"lending-copy/config"
+	"lending-copy/contract/bindings"
+	"lending-copy/db"
+	"lending-copy/log"
+	"lending-copy/schedule/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+const defaultAuctionBlockChunk = 2000
+
+type AuctionIndexer struct{}
+
+func NewAuctionIndexer() *AuctionIndexer {
+	return &AuctionIndexer{}
+}
+
+// Sync 从上次同步的区块继续扫描拍卖合约事件，按区块段写库；每段事件与同步进度在同一事务中提交，返回本轮写入的事件数
+func (s *AuctionIndexer) Sync(ctx context.Context) (int, error) {
+	conf := config.Current().Auction
+	if !common.IsHexAddress(conf.AuctionAddr) || common.HexToAddress(conf.AuctionAddr) == (common.Address{}) {
+		log.Logger.Sugar().Warn("AuctionIndexer skipped: auction_addr not configured")
+		return 0, nil
+	}
+	cli, err := bindings.DialAuction(conf.NetUrl, conf.AuctionAddr)
+	if err != nil {
+		return 0, fmt.Errorf("dial: %w", err)
+	}
+	defer cli.Close()
+
+	contract := cli.Contract.Hex()
+	head, err := cli.Eth.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("BlockNumber: %w", err)
+	}
+	if head < conf.Confirmations {
+		return 0, nil
+	}
+	safeHead := head - conf.Confirmations
+
+	from := conf.StartBlock
+	last, ok, err := models.NewAuction().LastSyncedBlock(db.Mysql, conf.ChainId, contract)
+	if err != nil {
+		return 0, fmt.Errorf("LastSyncedBlock: %w", err)
+	}
+	if ok {
+		from = last + 1
+	}
+
+	scanner := &auctionScanner{c: cli, chunk: conf.BlockChunk}
+	if scanner.chunk == 0 {
+		scanner.chunk = defaultAuctionBlockChunk
+	}
+	prices := newUsdPricer(cli)
+	synced := 0
+	for from <= safeHead {
+		// 退出时在两个区块段之间停下，每段事件与进度在同一事务中提交，不会写一半
+		if ctx.Err() != nil {
+			return synced, fmt.Errorf("canceled at block %d: %w", from, ctx.Err())
+		}
+		events, to, err := scanner.next(ctx, from, safeHead)
+		if err != nil {
+			return synced, err
+		}
+		if err := s.apply(ctx, cli, prices, conf.ChainId, contract, events, to); err != nil {
+			return synced, fmt.Errorf("apply %d-%d: %w", from, to, err)
+		}
+		synced += len(events)
+		if len(events) > 0 {
+			log.Logger.Sugar().Info("AuctionIndexer synced ", len(events), " events in blocks ", from, "-", to)
+		}
+		from = to + 1
+	}
+	if err := models.NewSyncStatus().MarkSuccess(ctx, models.SyncJobAuction, conf.ChainId, time.Now().Unix(), safeHead); err != nil {
+		return synced, fmt.Errorf("MarkSuccess: %w", err)
+	}
+	return synced, nil
+}
+
+// auctionEventFilterer 按区块段拉取拍卖事件，由 bindings.AuctionClient 实现
+type auctionEventFilterer interface {
+	FilterEvents(ctx context.Context, from, to uint64) ([]bindings.AuctionEvent, error)
+}
+
+// auctionScanner 按 chunk 个区块拉取事件；缩小后的 chunk 在本轮后续区块段中沿用
+type auctionScanner struct {
+	c     auctionEventFilterer
+	chunk uint64
+}
+
+// next 拉取从 from 开始、不超过 safeHead 的一个区块段，返回事件与该段的结束区块
+func (a *auctionScanner) next(ctx context.Context, from, safeHead uint64) ([]bindings.AuctionEvent, uint64, error) {
+	for {
+		to := from + a.chunk - 1
+		if to > safeHead {
+			to = safeHead
+		}
+		events, err := a.c.FilterEvents(ctx, from, to)
+		if err == nil {
+			return events, to, nil
+		}
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+		// 只有节点限制 eth_getLogs 的范围或结果条数时才缩小区块段重试，其余错误直接返回
+		if a.chunk > 1 && isLogRangeError(err) {
+			a.chunk /= 2
+			log.Logger.Sugar().Warn("AuctionIndexer FilterEvents ", from, "-", to, " ", err, ", retry with chunk ", a.chunk)
+			continue
+		}
+		return nil, 0, fmt.Errorf("FilterEvents %d-%d: %w", from, to, err)
+	}
+}
+
+func (s *AuctionIndexer) apply(ctx context.Context, cli *bindings.AuctionClient, prices *usdPricer, chainId, contract string, events []bindings.AuctionEvent, to uint64) error {
+	// RPC 调用放在事务外：先取齐区块时间与 USD 值，再一次性写库
+	blockTimes := map[uint64]int64{}
+	for _, ev := range events {
+		if _, ok := blockTimes[ev.BlockNumber]; ok {
+			continue
+		}
+		header, err := cli.Eth.HeaderByNumber(ctx, new(big.Int).SetUint64(ev.BlockNumber))
+		if err != nil {
+			return err
+		}
+		blockTimes[ev.BlockNumber] = int64(header.Time)
+	}
+	usdValues := make([]string, len(events))
+	for i, ev := range events {
+		switch ev.Name {
+		case bindings.EventBidPlaced:
+			usdValues[i] = ev.UsdValue.String()
+		case bindings.EventAuctionEnded:
+			usdValues[i] = prices.usdValue(ctx, ev.BidToken, ev.Amount, ev.BlockNumber)
+		case bindings.EventRefundQueued, bindings.EventRefundWithdrawn:
+			usdValues[i] = prices.usdValue(ctx, ev.RefundToken, ev.Amount, ev.BlockNumber)
+		}
+	}
+
+	return db.Mysql.Transaction(func(tx *gorm.DB) error {
+		return writeAuctionEvents(tx, models.NewAuction(), chainId, contract, events, usdValues, blockTimes, to)
+	})
+}
+
+// auctionWriter 写库操作，由 models.Auction 实现
+type auctionWriter interface {
+	CreateAuction(tx *gorm.DB, auction *models.Auction) error
+	SaveBid(tx *gorm.DB, bid *models.AuctionBid) error
+	EndAuction(tx *gorm.DB, chainId, contract string, auctionId int64, ended *models.Auction) error
+	SaveRefund(tx *gorm.DB, refund *models.AuctionRefund) error
+	SaveSyncedBlock(tx *gorm.DB, chainId, contract string, block uint64) error
+}
+
+// writeAuctionEvents 按日志顺序把一个区块段的事件写入 tx，最后记录同步进度
+func writeAuctionEvents(tx *gorm.DB, auction auctionWriter, chainId, contract string, events []bindings.AuctionEvent, usdValues []string, blockTimes map[uint64]int64, to uint64) error {
+	for i, ev := range events {
+		var err error
+		switch ev.Name {
+		case bindings.EventAuctionCreated:
+			err = auction.CreateAuction(tx, &models.Auction{
+				ChainId:       chainId,
+				Contract:      contract,
+				AuctionId:     ev.AuctionId.Int64(),
+				Seller:        ev.Seller.Hex(),
+				NftAddress:    ev.NftAddress.Hex(),
+				TokenId:       ev.TokenId.String(),
+				EndTime:       ev.EndTime.Int64(),
+				HighestBid:    "0",
+				HighestBidUsd: "0",
+				CreatedBlock:  ev.BlockNumber,
+				CreatedTx:     ev.TxHash.Hex(),
+			})
+		case bindings.EventBidPlaced:
+			err = auction.SaveBid(tx, &models.AuctionBid{
+				ChainId:     chainId,
+				Contract:    contract,
+				AuctionId:   ev.AuctionId.Int64(),
+				Bidder:      ev.Bidder.Hex(),
+				BidToken:    ev.BidToken.Hex(),
+				Amount:      ev.Amount.String(),
+				UsdValue:    usdValues[i],
+				BlockNumber: ev.BlockNumber,
+				BlockTime:   blockTimes[ev.BlockNumber],
+				TxHash:      ev.TxHash.Hex(),
+				LogIndex:    ev.LogIndex,
+			})
+		case bindings.EventAuctionEnded:
+			err = auction.EndAuction(tx, chainId, contract, ev.AuctionId.Int64(), &models.Auction{
+				Winner:      ev.Winner.Hex(),
+				FinalAmount: ev.Amount.String(),
+				FinalUsd:    usdValues[i],
+				EndedBlock:  ev.BlockNumber,
+				EndedTx:     ev.TxHash.Hex(),
+			})
+		case bindings.EventRefundQueued, bindings.EventRefundWithdrawn:
+			refund := &models.AuctionRefund{
+				ChainId:     chainId,
+				Contract:    contract,
+				Bidder:      ev.Bidder.Hex(),
+				Action:      models.RefundActionWithdrawn,
+				RefundToken: ev.RefundToken.Hex(),
+				Amount:      ev.Amount.String(),
+				UsdValue:    usdValues[i],
+				BlockNumber: ev.BlockNumber,
+				BlockTime:   blockTimes[ev.BlockNumber],
+				TxHash:      ev.TxHash.Hex(),
+				LogIndex:    ev.LogIndex,
+			}
+			if ev.Name == bindings.EventRefundQueued {
+				refund.Action = models.RefundActionQueued
+				refund.AuctionId = outbidAuction(events[i+1:], ev.TxHash)
+			}
+			err = auction.SaveRefund(tx, refund)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return auction.SaveSyncedBlock(tx, chainId, contract, to)
+}
+
+// outbidAuction RefundQueued 不带拍卖 ID；合约在同一笔交易里紧接着发出新出价的 BidPlaced
+func outbidAuction(rest []bindings.AuctionEvent, txHash common.Hash) *int64 {
+	for _, ev := range rest {
+		if ev.TxHash != txHash {
+			break
+		}
+		if ev.Name == bindings.EventBidPlaced {
+			id := ev.AuctionId.Int64()
+			return &id
+		}
+	}
+	return nil
+}
+
+// priceReader 读取拍卖合约配置的喂价地址与喂价，由 bindings.AuctionClient 实现
+type priceReader interface {
+	PriceFeed(ctx context.Context, token common.Address, block *big.Int) (common.Address, error)
+	LatestAnswer(ctx context.Context, feed common.Address, block *big.Int) (*big.Int, uint8, error)
+}
+
+// feedAnswer 某个区块上的喂价读数
+type feedAnswer struct {
+	answer   *big.Int
+	decimals uint8
+}
+
+// usdPricer 按事件所在区块读取喂价，复现合约 _toUsdValue；同一次同步内按 (token, block) 缓存喂价读数，
+// 同一区块的多笔出价只读一次喂价
+type usdPricer struct {
+	prices priceReader
+	cache  map[string]*feedAnswer
+}
+
+func newUsdPricer(prices priceReader) *usdPricer {
+	return &usdPricer{prices: prices, cache: map[string]*feedAnswer{}}
+}
+
+// usdValue 读取失败时返回空字符串，不阻塞索引
+func (p *usdPricer) usdValue(ctx context.Context, token common.Address, amount *big.Int, block uint64) string {
+	price, err := p.priceAt(ctx, token, block)
+	if err != nil {
+		log.Logger.Sugar().Error("AuctionIndexer price ", token.Hex(), " ", err)
+		return ""
+	}
+	usd, err := bindings.ToUsdValue(amount, price.answer, price.decimals)
+	if err != nil {
+		log.Logger.Sugar().Error("AuctionIndexer ToUsdValue ", token.Hex(), " ", err)
+		return ""
+	}
+	return usd.String()
+}
+
+func (p *usdPricer) priceAt(ctx context.Context, token common.Address, block uint64) (*feedAnswer, error) {
+	key := token.Hex() + ":" + strconv.FormatUint(block, 10)
+	if price, ok := p.cache[key]; ok {
+		return price, nil
+	}
+	answer, decimals, err := p.answerAt(ctx, token, new(big.Int).SetUint64(block))
+	if err != nil {
+		// 非归档节点无法读取历史状态，退回最新价格
+		log.Logger.Sugar().Warn("AuctionIndexer price at block ", block, " ", err, ", fallback to latest")
+		answer, decimals, err = p.answerAt(ctx, token, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.cache[key] = &feedAnswer{answer: answer, decimals: decimals}
+	return p.cache[key], nil
+}
+
+func (p *usdPricer) answerAt(ctx context.Context, token common.Address, block *big.Int) (*big.Int, uint8, error) {
+	feed, err := p.prices.PriceFeed(ctx, token, block)
+	if err != nil {
+		return nil, 0, err
+	}
+	// 与合约 require(address(feed) != address(0), "Feed not set") 一致
+	if feed == (common.Address{}) {
+		return nil, 0, errors.New("feed not set")
+	}
+	return p.prices.LatestAnswer(ctx, feed, block)
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"lending-copy/contract/bindings"
+	"lending-copy/schedule/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+var (
+	ethToken   = common.Address{}
+	erc20Token = common.HexToAddress("0x00000000000000000000000000000000000000e2")
+	ethFeed    = common.HexToAddress("0x00000000000000000000000000000000000000f1")
+	tokenFeed  = common.HexToAddress("0x00000000000000000000000000000000000000f2")
+	seller     = common.HexToAddress("0x0000000000000000000000000000000000000051")
+	bidder1    = common.HexToAddress("0x00000000000000000000000000000000000000b1")
+	bidder2    = common.HexToAddress("0x00000000000000000000000000000000000000b2")
+)
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+}
+
+// fakePrices 按区块返回喂价；historical 为 false 时模拟非归档节点，只能读最新状态
+type fakePrices struct {
+	answers    map[common.Address]*big.Int
+	historical bool
+	feedReads  int
+}
+
+func (f *fakePrices) PriceFeed(_ context.Context, token common.Address, block *big.Int) (common.Address, error) {
+	if block != nil && !f.historical {
+		return common.Address{}, errors.New("missing trie node")
+	}
+	if token == ethToken {
+		return ethFeed, nil
+	}
+	if token == erc20Token {
+		return tokenFeed, nil
+	}
+	return common.Address{}, nil
+}
+
+func (f *fakePrices) LatestAnswer(_ context.Context, feed common.Address, _ *big.Int) (*big.Int, uint8, error) {
+	f.feedReads++
+	return f.answers[feed], 8, nil
+}
+
+func newFakePrices() *fakePrices {
+	return &fakePrices{
+		answers:    map[common.Address]*big.Int{ethFeed: big.NewInt(2000e8), tokenFeed: big.NewInt(1e8)},
+		historical: true,
+	}
+}
+
+func TestUsdPricerCachesPerTokenAndBlock(t *testing.T) {
+	prices := newFakePrices()
+	pricer := newUsdPricer(prices)
+	ctx := context.Background()
+
+	// 同一区块不同金额只读一次喂价，USD 按各自金额计算
+	if got := pricer.usdValue(ctx, ethToken, ether(1), 10); got != ether(2000).String() {
+		t.Fatalf("1 ETH = %s USD", got)
+	}
+	if got := pricer.usdValue(ctx, ethToken, ether(2), 10); got != ether(4000).String() {
+		t.Fatalf("2 ETH = %s USD, 缓存不应包含金额", got)
+	}
+	if prices.feedReads != 1 {
+		t.Fatalf("feedReads = %d, want 1", prices.feedReads)
+	}
+
+	// 换区块或换代币重新读取
+	prices.answers[ethFeed] = big.NewInt(1500e8)
+	if got := pricer.usdValue(ctx, ethToken, ether(1), 11); got != ether(1500).String() {
+		t.Fatalf("block 11: 1 ETH = %s USD", got)
+	}
+	if got := pricer.usdValue(ctx, erc20Token, ether(2500), 11); got != ether(2500).String() {
+		t.Fatalf("2500 token = %s USD", got)
+	}
+	if prices.feedReads != 3 {
+		t.Fatalf("feedReads = %d, want 3", prices.feedReads)
+	}
+}
+
+func TestUsdPricerFallbacks(t *testing.T) {
+	ctx := context.Background()
+
+	// 历史状态不可读时退回最新价格
+	prices := newFakePrices()
+	prices.historical = false
+	if got := newUsdPricer(prices).usdValue(ctx, ethToken, ether(1), 10); got != ether(2000).String() {
+		t.Fatalf("fallback = %s", got)
+	}
+
+	// 未配置喂价或价格非正时与合约一样不给出 USD 值
+	unknown := common.HexToAddress("0x00000000000000000000000000000000000000e3")
+	if got := newUsdPricer(newFakePrices()).usdValue(ctx, unknown, ether(1), 10); got != "" {
+		t.Fatalf("feed not set = %q", got)
+	}
+	prices = newFakePrices()
+	prices.answers[ethFeed] = big.NewInt(0)
+	if got := newUsdPricer(prices).usdValue(ctx, ethToken, ether(1), 10); got != "" {
+		t.Fatalf("invalid price = %q", got)
+	}
+}
+
+func TestOutbidAuction(t *testing.T) {
+	tx1 := common.HexToHash("0x01")
+	tx2 := common.HexToHash("0x02")
+	bid := func(tx common.Hash, id int64) bindings.AuctionEvent {
+		return bindings.AuctionEvent{Name: bindings.EventBidPlaced, TxHash: tx, AuctionId: big.NewInt(id)}
+	}
+	cases := []struct {
+		name string
+		rest []bindings.AuctionEvent
+		want *int64
+	}{
+		{"same tx bid", []bindings.AuctionEvent{bid(tx1, 3)}, int64Ptr(3)},
+		{"skip other events in tx", []bindings.AuctionEvent{{Name: bindings.EventRefundQueued, TxHash: tx1}, bid(tx1, 4)}, int64Ptr(4)},
+		{"next tx", []bindings.AuctionEvent{bid(tx2, 3)}, nil},
+		{"last event", nil, nil},
+	}
+	for _, c := range cases {
+		got := outbidAuction(c.rest, tx1)
+		if (got == nil) != (c.want == nil) || (got != nil && *got != *c.want) {
+			t.Errorf("%s: outbidAuction = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// memAuctions 在内存中按 models.Auction 的写库语义记录状态
+type memAuctions struct {
+	auctions map[int64]*models.Auction
+	bids     []*models.AuctionBid
+	refunds  []*models.AuctionRefund
+	synced   uint64
+}
+
+func newMemAuctions() *memAuctions {
+	return &memAuctions{auctions: map[int64]*models.Auction{}}
+}
+
+func (m *memAuctions) CreateAuction(_ *gorm.DB, auction *models.Auction) error {
+	if _, ok := m.auctions[auction.AuctionId]; !ok {
+		m.auctions[auction.AuctionId] = auction
+	}
+	return nil
+}
+
+func (m *memAuctions) SaveBid(_ *gorm.DB, bid *models.AuctionBid) error {
+	m.bids = append(m.bids, bid)
+	a := m.auctions[bid.AuctionId]
+	a.BidToken, a.HighestBid, a.HighestBidUsd, a.HighestBidder = bid.BidToken, bid.Amount, bid.UsdValue, bid.Bidder
+	a.BidCount++
+	return nil
+}
+
+func (m *memAuctions) EndAuction(_ *gorm.DB, _, _ string, auctionId int64, ended *models.Auction) error {
+	a := m.auctions[auctionId]
+	a.Ended, a.Winner, a.FinalAmount, a.FinalUsd = true, ended.Winner, ended.FinalAmount, ended.FinalUsd
+	a.EndedBlock, a.EndedTx = ended.EndedBlock, ended.EndedTx
+	return nil
+}
+
+func (m *memAuctions) SaveRefund(_ *gorm.DB, refund *models.AuctionRefund) error {
+	m.refunds = append(m.refunds, refund)
+	return nil
+}
+
+func (m *memAuctions) SaveSyncedBlock(_ *gorm.DB, _, _ string, block uint64) error {
+	m.synced = block
+	return nil
+}
+
+// 出价 → 被更高出价顶替（同一笔交易先 RefundQueued 再 BidPlaced）→ 结束 → 提取退款
+func TestWriteAuctionEventsOutbidAndRefund(t *testing.T) {
+	id := big.NewInt(0)
+	txCreate, txBid1, txBid2 := common.HexToHash("0xc0"), common.HexToHash("0xb1"), common.HexToHash("0xb2")
+	txEnd, txWithdraw := common.HexToHash("0xe0"), common.HexToHash("0xd0")
+	events := []bindings.AuctionEvent{
+		{Name: bindings.EventAuctionCreated, BlockNumber: 100, TxHash: txCreate, AuctionId: id, Seller: seller, NftAddress: seller, TokenId: big.NewInt(1), EndTime: big.NewInt(1700000000)},
+		{Name: bindings.EventBidPlaced, BlockNumber: 101, TxHash: txBid1, AuctionId: id, Bidder: bidder1, BidToken: ethToken, Amount: ether(1), UsdValue: ether(2000)},
+		{Name: bindings.EventRefundQueued, BlockNumber: 102, TxHash: txBid2, LogIndex: 0, Bidder: bidder1, RefundToken: ethToken, Amount: ether(1)},
+		{Name: bindings.EventBidPlaced, BlockNumber: 102, TxHash: txBid2, LogIndex: 1, AuctionId: id, Bidder: bidder2, BidToken: erc20Token, Amount: ether(2500), UsdValue: ether(2500)},
+		{Name: bindings.EventAuctionEnded, BlockNumber: 103, TxHash: txEnd, AuctionId: id, Winner: bidder2, BidToken: erc20Token, Amount: ether(2500)},
+		{Name: bindings.EventRefundWithdrawn, BlockNumber: 104, TxHash: txWithdraw, Bidder: bidder1, RefundToken: ethToken, Amount: ether(1)},
+	}
+	usdValues := []string{"", ether(2000).String(), ether(2000).String(), ether(2500).String(), ether(2500).String(), ether(2000).String()}
+	blockTimes := map[uint64]int64{100: 1000, 101: 1010, 102: 1020, 103: 1030, 104: 1040}
+
+	mem := newMemAuctions()
+	if err := writeAuctionEvents(nil, mem, "97", "0xauction", events, usdValues, blockTimes, 110); err != nil {
+		t.Fatal(err)
+	}
+
+	a := mem.auctions[0]
+	if a == nil {
+		t.Fatal("拍卖未创建")
+	}
+	if a.BidCount != 2 || a.HighestBidder != bidder2.Hex() || a.BidToken != erc20Token.Hex() || a.HighestBidUsd != ether(2500).String() {
+		t.Fatalf("最高出价 = %+v", a)
+	}
+	if !a.Ended || a.Winner != bidder2.Hex() || a.FinalAmount != ether(2500).String() || a.FinalUsd != ether(2500).String() || a.EndedTx != txEnd.Hex() {
+		t.Fatalf("结束状态 = %+v", a)
+	}
+	if len(mem.bids) != 2 || mem.bids[1].BlockTime != 1020 || mem.bids[1].LogIndex != 1 {
+		t.Fatalf("bids = %+v", mem.bids)
+	}
+
+	if len(mem.refunds) != 2 {
+		t.Fatalf("refunds = %d, want 2", len(mem.refunds))
+	}
+	queued, withdrawn := mem.refunds[0], mem.refunds[1]
+	if queued.Action != models.RefundActionQueued || queued.AuctionId == nil || *queued.AuctionId != 0 ||
+		queued.Bidder != bidder1.Hex() || queued.UsdValue != ether(2000).String() || queued.BlockTime != 1020 {
+		t.Fatalf("queued = %+v", queued)
+	}
+	// 提取退款不对应某一场拍卖
+	if withdrawn.Action != models.RefundActionWithdrawn || withdrawn.AuctionId != nil || withdrawn.TxHash != txWithdraw.Hex() {
+		t.Fatalf("withdrawn = %+v", withdrawn)
+	}
+	if mem.synced != 110 {
+		t.Fatalf("synced = %d, want 110", mem.synced)
+	}
+}
+
+// 写库失败时不推进同步进度，事务整体回滚
+func TestWriteAuctionEventsStopsOnError(t *testing.T) {
+	mem := newMemAuctions()
+	events := []bindings.AuctionEvent{
+		{Name: bindings.EventRefundWithdrawn, BlockNumber: 1, Bidder: bidder1, RefundToken: ethToken, Amount: ether(1)},
+	}
+	err := writeAuctionEvents(nil, failingRefunds{mem}, "97", "0xauction", events, []string{""}, nil, 5)
+	if err == nil || mem.synced != 0 {
+		t.Fatalf("err = %v, synced = %d", err, mem.synced)
+	}
+}
+
+type failingRefunds struct {
+	*memAuctions
+}
+
+func (failingRefunds) SaveRefund(*gorm.DB, *models.AuctionRefund) error {
+	return errors.New("duplicate")
+}
+
+// fakeAuctionEvents 记录每次查询的区块段；fail 返回非 nil 时该次查询失败
+type fakeAuctionEvents struct {
+	ranges [][2]uint64
+	fail   func(from, to uint64) error
+}
+
+func (f *fakeAuctionEvents) FilterEvents(_ context.Context, from, to uint64) ([]bindings.AuctionEvent, error) {
+	f.ranges = append(f.ranges, [2]uint64{from, to})
+	if f.fail != nil {
+		if err := f.fail(from, to); err != nil {
+			return nil, err
+		}
+	}
+	return []bindings.AuctionEvent{{BlockNumber: from}}, nil
+}
+
+func TestAuctionScannerShrinksOnRangeLimit(t *testing.T) {
+	filterer := &fakeAuctionEvents{fail: func(from, to uint64) error {
+		if to-from+1 > 500 {
+			return errors.New("eth_getLogs is limited to a 500 block range")
+		}
+		return nil
+	}}
+	scanner := &auctionScanner{c: filterer, chunk: 2000}
+	events, to, err := scanner.next(context.Background(), 1, 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2000 → 1000 → 500，缩小后的 chunk 留给后续区块段
+	if to != 500 || len(events) != 1 || scanner.chunk != 500 || len(filterer.ranges) != 3 {
+		t.Fatalf("to = %d, chunk = %d, ranges = %v", to, scanner.chunk, filterer.ranges)
+	}
+	if _, to, err = scanner.next(context.Background(), 501, 10000); err != nil || to != 1000 || len(filterer.ranges) != 4 {
+		t.Fatalf("to = %d, err = %v, ranges = %v", to, err, filterer.ranges)
+	}
+}
+
+func TestAuctionScannerReturnsOtherErrors(t *testing.T) {
+	filterer := &fakeAuctionEvents{fail: func(uint64, uint64) error { return errors.New("429 Too Many Requests") }}
+	scanner := &auctionScanner{c: filterer, chunk: 2000}
+	if _, _, err := scanner.next(context.Background(), 1, 10000); err == nil {
+		t.Fatal("期望返回错误")
+	}
+	if len(filterer.ranges) != 1 || scanner.chunk != 2000 {
+		t.Fatalf("非范围限制错误不应缩小区块段重试: chunk = %d, ranges = %v", scanner.chunk, filterer.ranges)
+	}
+}
+
+func TestAuctionScannerStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	filterer := &fakeAuctionEvents{fail: func(uint64, uint64) error {
+		cancel()
+		return errors.New("query returned more than 10000 results")
+	}}
+	scanner := &auctionScanner{c: filterer, chunk: 2000}
+	_, _, err := scanner.next(ctx, 1, 10000)
+	if !errors.Is(err, context.Canceled) || len(filterer.ranges) != 1 {
+		t.Fatalf("err = %v, ranges = %v", err, filterer.ranges)
+	}
+}
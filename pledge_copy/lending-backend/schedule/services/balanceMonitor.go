@@ -6,9 +6,9 @@ import (
 
 	"lending-copy/config"
 	"lending-copy/log"
+	"lending-copy/metrics"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 type BalanceMonitor struct{}
@@ -17,32 +17,35 @@ func NewBalanceMonitor() *BalanceMonitor {
 	return &BalanceMonitor{}
 }
 
-// Monitor 定时检查借贷合约地址原生币余额，低于阈值时打日志（pledge-backend 为邮件告警）
-func (s *BalanceMonitor) Monitor() {
-	net := config.Config.TestNet.NetUrl
-	addr := common.HexToAddress(config.Config.TestNet.LendingPoolAddr)
+// Monitor 定时检查借贷合约地址原生币余额，低于阈值时打日志（pledge-backend 为邮件告警），返回检查的合约数
+func (s *BalanceMonitor) Monitor(ctx context.Context) (int, error) {
+	conf := config.Current()
+	net := conf.TestNet.NetUrl
+	addr := common.HexToAddress(conf.TestNet.LendingPoolAddr)
 	if addr == (common.Address{}) {
-		return
+		return 0, nil
 	}
-	bal, err := s.nativeBalance(net, addr)
+	bal, err := s.nativeBalance(ctx, net, addr)
 	if err != nil {
-		log.Logger.Sugar().Error("BalanceMonitor ", err)
-		return
+		return 0, err
 	}
-	th, ok := new(big.Int).SetString(config.Config.Threshold.LendingPoolNativeThreshold, 10)
+	th, ok := new(big.Int).SetString(conf.Threshold.LendingPoolNativeThreshold, 10)
 	if !ok {
-		return
+		metrics.NativeBalance(conf.TestNet.ChainId, addr.Hex(), bal, nil)
+		return 1, nil
 	}
+	metrics.NativeBalance(conf.TestNet.ChainId, addr.Hex(), bal, th)
 	if bal.Cmp(th) <= 0 {
 		log.Logger.Sugar().Warn("lending pool native balance low: contract=", addr.Hex(), " balance_wei=", bal.String(), " threshold=", th.String())
 	}
+	return 1, nil
 }
 
-func (s *BalanceMonitor) nativeBalance(netURL string, token common.Address) (*big.Int, error) {
-	c, err := ethclient.Dial(netURL)
+func (s *BalanceMonitor) nativeBalance(ctx context.Context, netURL string, token common.Address) (*big.Int, error) {
+	c, err := metrics.DialEth(netURL)
 	if err != nil {
 		return nil, err
 	}
 	defer c.Close()
-	return c.BalanceAt(context.Background(), token, nil)
+	return c.BalanceAt(ctx, token, nil)
 }
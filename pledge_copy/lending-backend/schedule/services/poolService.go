@@ -3,15 +3,22 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"lending-copy/config"
 	"lending-copy/contract/bindings"
 	"lending-copy/db"
 	"lending-copy/log"
+	"lending-copy/metrics"
 	"lending-copy/schedule/models"
+	"lending-copy/tracing"
 	"lending-copy/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type poolService struct{}
@@ -20,49 +27,58 @@ func NewPool() *poolService {
 	return &poolService{}
 }
 
-func (s *poolService) UpdateAllPoolInfo() {
-	s.UpdatePoolInfo(config.Config.TestNet.LendingPoolAddr, config.Config.TestNet.NetUrl, config.Config.TestNet.ChainId)
+// UpdateAllPoolInfo 返回本轮发生变化的池子数
+func (s *poolService) UpdateAllPoolInfo(ctx context.Context) (int, error) {
+	testNet := config.Current().TestNet
+	return s.UpdatePoolInfo(ctx, testNet.LendingPoolAddr, testNet.NetUrl, testNet.ChainId)
 }
 
-func (s *poolService) UpdatePoolInfo(contractAddress, network, chainId string) {
+// UpdatePoolInfo 同步全部池子的基础信息与数据，返回发生变化的池子数；单个池子失败时继续同步其余池子，最后汇总为错误返回
+func (s *poolService) UpdatePoolInfo(ctx context.Context, contractAddress, network, chainId string) (changed int, err error) {
 	if contractAddress == "" || contractAddress == "0x0000000000000000000000000000000000000000" {
 		log.Logger.Sugar().Warn("UpdatePoolInfo skipped: lending_pool_addr not configured")
-		return
+		return 0, nil
 	}
-	log.Logger.Sugar().Info("UpdatePoolInfo ", contractAddress, network)
+	// 每轮同步一个根 span，链上调用、Redis 与写库都挂在它下面
+	ctx, span := tracing.Tracer().Start(ctx, "UpdatePoolInfo", trace.WithAttributes(attribute.String("chain_id", chainId)))
+	defer func() { tracing.End(span, err) }()
+	log.Ctx(ctx).Sugar().Info("UpdatePoolInfo ", contractAddress, network)
 	cli, err := bindings.Dial(network, contractAddress)
 	if err != nil {
-		log.Logger.Error(err.Error())
-		return
+		return 0, fmt.Errorf("dial: %w", err)
 	}
 	defer cli.Close()
 
-	ctx := context.Background()
 	borrowFee, err := cli.BorrowFee(ctx)
 	if err != nil {
-		log.Logger.Sugar().Error("BorrowFee ", err)
-		return
+		return 0, fmt.Errorf("BorrowFee: %w", err)
 	}
 	lendFee, err := cli.LendFee(ctx)
 	if err != nil {
-		log.Logger.Sugar().Error("LendFee ", err)
-		return
+		return 0, fmt.Errorf("LendFee: %w", err)
 	}
 	pLength, err := cli.PoolLength(ctx)
 	if err != nil {
-		log.Logger.Error(err.Error())
-		return
+		return 0, fmt.Errorf("PoolLength: %w", err)
 	}
 	n := int(pLength.Int64())
+	defer func() { metrics.PoolsChanged(chainId, changed) }()
+	// 所有池子都读写成功才记为一次成功同步，/status 据此判断池子数据是否过期
+	failed := 0
 	for i := 0; i < n; i++ {
+		// 退出时在两个池子之间停下，不打断单个池子的读写
+		if ctx.Err() != nil {
+			return changed, fmt.Errorf("canceled at pool %d: %w", i, ctx.Err())
+		}
 		poolId := utils.IntToString(i + 1)
 		baseInfo, err := cli.PoolBaseInfo(ctx, big.NewInt(int64(i)))
 		if err != nil {
-			log.Logger.Sugar().Info("UpdatePoolInfo PoolBaseInfo err", poolId, err)
+			log.Ctx(ctx).Sugar().Info("UpdatePoolInfo PoolBaseInfo err", poolId, err)
+			failed++
 			continue
 		}
-		_, borrowToken := models.NewTokenInfo().GetTokenInfo(baseInfo.BorrowToken.Hex(), chainId)
-		_, lendToken := models.NewTokenInfo().GetTokenInfo(baseInfo.LendToken.Hex(), chainId)
+		_, borrowToken := models.NewTokenInfo().GetTokenInfo(ctx, baseInfo.BorrowToken.Hex(), chainId)
+		_, lendToken := models.NewTokenInfo().GetTokenInfo(ctx, baseInfo.LendToken.Hex(), chainId)
 		lendTokenJSON, _ := json.Marshal(models.LendToken{
 			LendFee:    lendFee.String(),
 			TokenLogo:  lendToken.Logo,
@@ -94,17 +110,21 @@ func (s *poolService) UpdatePoolInfo(contractAddress, network, chainId string) {
 			JpCoin:                 baseInfo.JpCoin.Hex(),
 			AutoLiquidateThreshold: baseInfo.AutoLiquidateThreshold.String(),
 		}
-		hasInfoData, byteBaseInfoStr, baseInfoMd5Str := s.GetPoolMd5(&poolBase, "base_info:lc_pool_"+chainId+"_"+poolId)
-		if !hasInfoData || baseInfoMd5Str != byteBaseInfoStr {
-			err = models.NewPoolBase().SavePoolBase(chainId, poolId, &poolBase)
+		hasInfoData, byteBaseInfoStr, baseInfoMd5Str := s.GetPoolMd5(ctx, &poolBase, "base_info:lc_pool_"+chainId+"_"+poolId)
+		baseChanged := !hasInfoData || baseInfoMd5Str != byteBaseInfoStr
+		if baseChanged {
+			changed++
+			err = models.NewPoolBase().SavePoolBase(ctx, chainId, poolId, &poolBase)
 			if err != nil {
-				log.Logger.Sugar().Error("SavePoolBase err ", chainId, poolId, err)
+				log.Ctx(ctx).Sugar().Error("SavePoolBase err ", chainId, poolId, err)
+				failed++
 			}
-			_ = db.RedisSetString("base_info:lc_pool_"+chainId+"_"+poolId, baseInfoMd5Str, 60*30)
+			_ = db.RedisSetString(ctx, "base_info:lc_pool_"+chainId+"_"+poolId, baseInfoMd5Str, 60*30)
 		}
 		dataInfo, err := cli.PoolDataInfo(ctx, big.NewInt(int64(i)))
 		if err != nil {
-			log.Logger.Sugar().Info("UpdatePoolInfo PoolDataInfo err", poolId, err)
+			log.Ctx(ctx).Sugar().Info("UpdatePoolInfo PoolDataInfo err", poolId, err)
+			failed++
 			continue
 		}
 		poolData := models.PoolData{
@@ -117,25 +137,37 @@ func (s *poolService) UpdatePoolInfo(contractAddress, network, chainId string) {
 			SettleAmountBorrow:     dataInfo.SettleAmountBorrow.String(),
 			SettleAmountLend:       dataInfo.SettleAmountLend.String(),
 		}
-		hasPoolData, byteDataInfoStr, dataInfoMd5Str := s.hashRedis("data_info:lc_pool_"+chainId+"_"+poolId, &poolData)
+		hasPoolData, byteDataInfoStr, dataInfoMd5Str := s.hashRedis(ctx, "data_info:lc_pool_"+chainId+"_"+poolId, &poolData)
 		if !hasPoolData || dataInfoMd5Str != byteDataInfoStr {
-			err = models.NewPoolData().SavePoolData(chainId, poolId, &poolData)
+			if !baseChanged {
+				changed++
+			}
+			err = models.NewPoolData().SavePoolData(ctx, chainId, poolId, &poolData)
 			if err != nil {
-				log.Logger.Sugar().Error("SavePoolData err ", chainId, poolId, err)
+				log.Ctx(ctx).Sugar().Error("SavePoolData err ", chainId, poolId, err)
+				failed++
 			}
-			_ = db.RedisSetString("data_info:lc_pool_"+chainId+"_"+poolId, dataInfoMd5Str, 60*30)
+			_ = db.RedisSetString(ctx, "data_info:lc_pool_"+chainId+"_"+poolId, dataInfoMd5Str, 60*30)
 		}
 	}
+	if failed > 0 {
+		return changed, fmt.Errorf("%d of %d pools failed", failed, n)
+	}
+	if err := models.NewSyncStatus().MarkSuccess(ctx, models.SyncJobPool, chainId, time.Now().Unix(), 0); err != nil {
+		return changed, fmt.Errorf("MarkSuccess: %w", err)
+	}
+	return changed, nil
 }
 
-func (s *poolService) GetPoolMd5(baseInfo *models.PoolBase, key string) (bool, string, string) {
-	return s.hashRedis(key, baseInfo)
+func (s *poolService) GetPoolMd5(ctx context.Context, baseInfo *models.PoolBase, key string) (bool, string, string) {
+	return s.hashRedis(ctx, key, baseInfo)
 }
 
-func (s *poolService) hashRedis(key string, v interface{}) (bool, string, string) {
+func (s *poolService) hashRedis(ctx context.Context, key string, v interface{}) (bool, string, string) {
 	b, _ := json.Marshal(v)
 	md5s := utils.Md5(string(b))
-	resInfoBytes, _ := db.RedisGet(key)
+	resInfoBytes, _ := db.RedisGet(ctx, key)
+	metrics.CacheLookup(len(resInfoBytes) > 0)
 	if len(resInfoBytes) > 0 {
 		return true, strings.Trim(string(resInfoBytes), `"`), md5s
 	}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"lending-copy/config"
+	"lending-copy/contract/bindings"
+	"lending-copy/db"
+	"lending-copy/log"
+	"lending-copy/metrics"
+	"lending-copy/schedule/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+)
+
+const proxyLogChunk = 5000
+
+type ProxyMonitor struct{}
+
+func NewProxyMonitor() *ProxyMonitor {
+	return &ProxyMonitor{}
+}
+
+// Monitor 逐个检查配置中的 UUPS 代理：记录 Upgraded 历史，实现地址意外变化或不在白名单内时告警；
+// 返回检查成功的代理数，单个代理失败不影响其余代理
+func (s *ProxyMonitor) Monitor(ctx context.Context) (int, error) {
+	checked := 0
+	var failed []string
+	for _, conf := range config.Current().Proxies {
+		if ctx.Err() != nil {
+			return checked, ctx.Err()
+		}
+		if !common.IsHexAddress(conf.Address) || common.HexToAddress(conf.Address) == (common.Address{}) {
+			continue
+		}
+		if err := s.check(ctx, conf); err != nil {
+			log.Logger.Sugar().Error("ProxyMonitor ", conf.Name, " ", err)
+			failed = append(failed, conf.Name)
+			continue
+		}
+		checked++
+	}
+	if len(failed) > 0 {
+		return checked, fmt.Errorf("proxies failed: %s", strings.Join(failed, ", "))
+	}
+	return checked, nil
+}
+
+func (s *ProxyMonitor) check(ctx context.Context, conf config.ProxyConfig) error {
+	c, err := metrics.DialEth(conf.NetUrl)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	proxy := common.HexToAddress(conf.Address)
+	head, err := c.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if head < conf.Confirmations {
+		return nil
+	}
+	// 事件与存储槽都以同一个已确认区块为准，避免事件还没扫到而槽已变化导致误报
+	safeHead := head - conf.Confirmations
+
+	prev, err := models.NewProxyState().GetProxyState(db.Mysql, conf.ChainId, proxy.Hex())
+	if err != nil {
+		return err
+	}
+	from := conf.StartBlock
+	if prev != nil {
+		from = prev.LastBlock + 1
+	}
+	allowlist := map[common.Address]bool{}
+	for _, a := range conf.AllowedImplementations {
+		allowlist[common.HexToAddress(a)] = true
+	}
+	allowed := func(impl common.Address) bool {
+		return len(allowlist) == 0 || allowlist[impl]
+	}
+
+	upgrades, err := s.scanUpgrades(ctx, c, proxy, from, safeHead)
+	if err != nil {
+		return err
+	}
+	rows := make([]*models.ProxyUpgrade, 0, len(upgrades))
+	for _, ev := range upgrades {
+		header, err := c.HeaderByNumber(ctx, new(big.Int).SetUint64(ev.BlockNumber))
+		if err != nil {
+			return err
+		}
+		row := &models.ProxyUpgrade{
+			ChainId:        conf.ChainId,
+			Proxy:          proxy.Hex(),
+			Implementation: ev.Implementation.Hex(),
+			Allowed:        allowed(ev.Implementation),
+			BlockNumber:    ev.BlockNumber,
+			BlockTime:      int64(header.Time),
+			TxHash:         ev.TxHash.Hex(),
+			LogIndex:       ev.LogIndex,
+		}
+		rows = append(rows, row)
+		log.Logger.Sugar().Warn("ProxyMonitor upgraded: name=", conf.Name, " proxy=", proxy.Hex(), " implementation=", row.Implementation, " tx=", row.TxHash)
+	}
+
+	block := new(big.Int).SetUint64(safeHead)
+	impl, admin, err := bindings.ProxySlots(ctx, c, proxy, block)
+	if err != nil {
+		return err
+	}
+	version, ok, err := bindings.ProxyVersion(ctx, c, proxy, block)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		version = ""
+	}
+
+	for _, alert := range proxyAlerts(prev, impl, admin, upgrades, allowed) {
+		proxyAlert(conf, alert.msg, alert.keysAndValues...)
+	}
+	if prev != nil && prev.Version != version {
+		log.Logger.Sugar().Info("ProxyMonitor version changed: name=", conf.Name, " from=", prev.Version, " to=", version)
+	}
+
+	state := &models.ProxyState{
+		ChainId:        conf.ChainId,
+		Proxy:          proxy.Hex(),
+		Name:           conf.Name,
+		Implementation: impl.Hex(),
+		Admin:          admin.Hex(),
+		Version:        version,
+		LastBlock:      safeHead,
+	}
+	return db.Mysql.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			if err := models.NewProxyState().SaveUpgrade(tx, row); err != nil {
+				return err
+			}
+		}
+		return models.NewProxyState().SaveProxyState(tx, state)
+	})
+}
+
+// alertMsg 一条待发出的代理告警
+type alertMsg struct {
+	msg           string
+	keysAndValues []interface{}
+}
+
+// proxyAlerts 根据本轮扫到的 Upgraded 事件、已确认区块上的存储槽与上次记录的状态判断需要告警的情况；prev 为 nil 表示首次检查
+func proxyAlerts(prev *models.ProxyState, impl, admin common.Address, upgrades []bindings.UpgradedEvent, allowed func(common.Address) bool) []alertMsg {
+	var alerts []alertMsg
+	for _, ev := range upgrades {
+		if !allowed(ev.Implementation) {
+			alerts = append(alerts, alertMsg{"upgraded to implementation not in allowlist", []interface{}{"implementation", ev.Implementation.Hex(), "tx", ev.TxHash.Hex()}})
+		}
+	}
+	switch {
+	case impl == (common.Address{}):
+		alerts = append(alerts, alertMsg{"implementation slot is empty, not an EIP-1967 proxy", nil})
+	case !allowed(impl):
+		alerts = append(alerts, alertMsg{"implementation not in allowlist", []interface{}{"implementation", impl.Hex()}})
+	}
+	if len(upgrades) > 0 && upgrades[len(upgrades)-1].Implementation != impl {
+		alerts = append(alerts, alertMsg{"implementation slot does not match latest Upgraded event",
+			[]interface{}{"implementation", impl.Hex(), "upgraded", upgrades[len(upgrades)-1].Implementation.Hex()}})
+	}
+	if prev != nil {
+		if prev.Implementation != impl.Hex() && len(upgrades) == 0 {
+			alerts = append(alerts, alertMsg{"implementation changed without Upgraded event", []interface{}{"from", prev.Implementation, "to", impl.Hex()}})
+		}
+		if prev.Admin != admin.Hex() {
+			alerts = append(alerts, alertMsg{"admin slot changed", []interface{}{"from", prev.Admin, "to", admin.Hex()}})
+		}
+	}
+	return alerts
+}
+
+func (s *ProxyMonitor) scanUpgrades(ctx context.Context, c bindings.LogFilterer, proxy common.Address, from, to uint64) ([]bindings.UpgradedEvent, error) {
+	var all []bindings.UpgradedEvent
+	chunk := uint64(proxyLogChunk)
+	for from <= to {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		end := from + chunk - 1
+		if end > to {
+			end = to
+		}
+		events, err := bindings.FilterUpgraded(ctx, c, proxy, from, end)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// 只有节点限制 eth_getLogs 的范围或结果条数时才缩小区块段重试，其余错误直接返回
+			if chunk > 1 && isLogRangeError(err) {
+				chunk /= 2
+				log.Logger.Sugar().Warn("ProxyMonitor FilterUpgraded ", from, "-", end, " ", err, ", retry with chunk ", chunk)
+				continue
+			}
+			return nil, fmt.Errorf("FilterUpgraded %d-%d: %w", from, end, err)
+		}
+		all = append(all, events...)
+		from = end + 1
+	}
+	return all, nil
+}
+
+// 常见节点对 eth_getLogs 区块范围 / 结果条数限制的报错片段（geth、Infura、Alchemy、QuickNode、BSC 等）
+var logRangeErrors = []string{
+	"query returned more than",
+	"block range",
+	"range is too",
+	"range too large",
+	"too many blocks",
+	"too many results",
+	"response size",
+	"is limited to",
+}
+
+func isLogRangeError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range logRangeErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyAlert 与余额监控一样以日志告警（pledge-backend 为邮件告警），level=error 便于日志平台检索
+func proxyAlert(conf config.ProxyConfig, msg string, keysAndValues ...interface{}) {
+	fields := append([]interface{}{"name", conf.Name, "chain_id", conf.ChainId, "proxy", conf.Address}, keysAndValues...)
+	log.Logger.Sugar().Errorw("proxy alert: "+msg, fields...)
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"lending-copy/contract/bindings"
+	"lending-copy/schedule/models"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	implV1 = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	implV2 = common.HexToAddress("0x00000000000000000000000000000000000000a2")
+	rogue  = common.HexToAddress("0x00000000000000000000000000000000000000ee")
+)
+
+func TestProxyAlerts(t *testing.T) {
+	allowlist := func(impl common.Address) bool { return impl == implV1 || impl == implV2 }
+	anyImpl := func(common.Address) bool { return true }
+	upgraded := func(impls ...common.Address) []bindings.UpgradedEvent {
+		events := make([]bindings.UpgradedEvent, len(impls))
+		for i, impl := range impls {
+			events[i] = bindings.UpgradedEvent{Implementation: impl, TxHash: common.HexToHash("0x01")}
+		}
+		return events
+	}
+	state := func(impl common.Address) *models.ProxyState {
+		return &models.ProxyState{Implementation: impl.Hex(), Admin: common.Address{}.Hex()}
+	}
+	cases := []struct {
+		name     string
+		prev     *models.ProxyState
+		impl     common.Address
+		admin    common.Address
+		upgrades []bindings.UpgradedEvent
+		allowed  func(common.Address) bool
+		want     []string
+	}{
+		{"first check", nil, implV1, common.Address{}, nil, allowlist, nil},
+		{"unchanged", state(implV1), implV1, common.Address{}, nil, allowlist, nil},
+		{"allowed upgrade", state(implV1), implV2, common.Address{}, upgraded(implV2), allowlist, nil},
+		{"empty allowlist allows any", state(implV1), rogue, common.Address{}, upgraded(rogue), anyImpl, nil},
+		{"upgrade outside allowlist", state(implV1), rogue, common.Address{}, upgraded(rogue), allowlist,
+			[]string{"upgraded to implementation not in allowlist", "implementation not in allowlist"}},
+		{"slot outside allowlist on first check", nil, rogue, common.Address{}, nil, allowlist,
+			[]string{"implementation not in allowlist"}},
+		{"not a proxy", nil, common.Address{}, common.Address{}, nil, anyImpl,
+			[]string{"implementation slot is empty, not an EIP-1967 proxy"}},
+		// 槽以最后一个 Upgraded 为准
+		{"slot mismatches last event", state(implV1), implV1, common.Address{}, upgraded(implV2), allowlist,
+			[]string{"implementation slot does not match latest Upgraded event"}},
+		{"upgrade then rollback", state(implV1), implV1, common.Address{}, upgraded(implV2, implV1), allowlist, nil},
+		{"changed without event", state(implV1), implV2, common.Address{}, nil, allowlist,
+			[]string{"implementation changed without Upgraded event"}},
+		{"admin changed", state(implV1), implV1, rogue, nil, allowlist,
+			[]string{"admin slot changed"}},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, alert := range proxyAlerts(c.prev, c.impl, c.admin, c.upgrades, c.allowed) {
+			got = append(got, alert.msg)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: alerts = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+// fakeLogs 记录每次查询的区块段；fail 返回非 nil 时该次查询失败
+type fakeLogs struct {
+	ranges [][2]uint64
+	fail   func(from, to uint64) error
+	logs   map[uint64]common.Address
+}
+
+func (f *fakeLogs) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	f.ranges = append(f.ranges, [2]uint64{from, to})
+	if f.fail != nil {
+		if err := f.fail(from, to); err != nil {
+			return nil, err
+		}
+	}
+	var logs []types.Log
+	for block := from; block <= to; block++ {
+		if impl, ok := f.logs[block]; ok {
+			logs = append(logs, types.Log{BlockNumber: block, Topics: []common.Hash{bindings.UpgradedTopic, common.BytesToHash(impl.Bytes())}})
+		}
+	}
+	return logs, nil
+}
+
+func TestScanUpgradesShrinksOnRangeLimit(t *testing.T) {
+	logs := &fakeLogs{
+		logs: map[uint64]common.Address{100: implV1, 4000: implV2},
+		fail: func(from, to uint64) error {
+			if to-from+1 > 2000 {
+				return errors.New("query returned more than 10000 results")
+			}
+			return nil
+		},
+	}
+	events, err := NewProxyMonitor().scanUpgrades(context.Background(), logs, rogue, 1, 6000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Implementation != implV1 || events[1].Implementation != implV2 {
+		t.Fatalf("events = %+v", events)
+	}
+	// 5000 → 2500 → 1250 后不再放大，按 1250 扫完
+	if len(logs.ranges) != 7 || logs.ranges[2] != [2]uint64{1, 1250} || logs.ranges[6] != [2]uint64{5001, 6000} {
+		t.Fatalf("ranges = %v", logs.ranges)
+	}
+}
+
+func TestScanUpgradesReturnsOtherErrors(t *testing.T) {
+	logs := &fakeLogs{fail: func(uint64, uint64) error { return errors.New("429 Too Many Requests") }}
+	if _, err := NewProxyMonitor().scanUpgrades(context.Background(), logs, rogue, 1, 6000); err == nil {
+		t.Fatal("期望返回错误")
+	}
+	if len(logs.ranges) != 1 {
+		t.Fatalf("非范围限制错误不应缩小区块段重试: %v", logs.ranges)
+	}
+}
+
+func TestScanUpgradesStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	logs := &fakeLogs{fail: func(uint64, uint64) error {
+		cancel()
+		return context.Canceled
+	}}
+	_, err := NewProxyMonitor().scanUpgrades(ctx, logs, rogue, 1, 6000)
+	if !errors.Is(err, context.Canceled) || len(logs.ranges) != 1 {
+		t.Fatalf("err = %v, ranges = %v", err, logs.ranges)
+	}
+}
+
+func TestIsLogRangeError(t *testing.T) {
+	cases := map[string]bool{
+		"query returned more than 10000 results":                        true,
+		"exceed maximum block range: 5000":                              true,
+		"Log response size exceeded. You can make eth_getLogs requests": true,
+		"eth_getLogs is limited to a 10,000 range":                      true,
+		"block range is too wide":                                       true,
+		"429 Too Many Requests":                                         false,
+		"connection refused":                                            false,
+		"context deadline exceeded":                                     false,
+	}
+	for msg, want := range cases {
+		if got := isLogRangeError(errors.New(msg)); got != want {
+			t.Errorf("isLogRangeError(%q) = %v, want %v", msg, got, want)
+		}
+	}
+}
@@ -1,24 +1,118 @@
 package tasks
 
 import (
+	"context"
+	"sync"
+	"time"
+
+	"lending-copy/config"
 	"lending-copy/db"
+	"lending-copy/log"
+	"lending-copy/schedule/jobs"
+	"lending-copy/schedule/models"
 	"lending-copy/schedule/services"
-	"time"
 
-	"github.com/jasonlvhit/gocron"
+	"github.com/robfig/cron/v3"
 )
 
-func Task() {
-	err := db.RedisFlushDB()
+// triggerRetry 触发频道订阅断开后的重连间隔
+const triggerRetry = 5 * time.Second
+
+// Scheduler 定时任务调度；Shutdown 时停止调度、取消进行中的任务并等待它们返回
+type Scheduler struct {
+	runner *jobs.Runner
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Start 清空 Redis 并立即执行一轮全部任务，然后按配置调度，并监听管理接口的手动触发；
+// ctx 取消后不再启动新任务，进行中的任务在安全点退出
+func Start(ctx context.Context) *Scheduler {
+	err := db.RedisFlushDB(ctx)
 	if err != nil {
 		panic("clear redis error " + err.Error())
 	}
-	services.NewPool().UpdateAllPoolInfo()
-	services.NewBalanceMonitor().Monitor()
-
-	s := gocron.NewScheduler()
-	s.ChangeLoc(time.UTC)
-	_ = s.Every(2).Minutes().From(gocron.NextTick()).Do(services.NewPool().UpdateAllPoolInfo)
-	_ = s.Every(30).Minutes().From(gocron.NextTick()).Do(services.NewBalanceMonitor().Monitor)
-	<-s.Start()
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Scheduler{runner: jobs.NewRunner(ctx, models.NewJob()), cancel: cancel}
+	all := build(config.Current())
+	s.runner.Schedule(all)
+	for _, j := range all {
+		_ = s.runner.Trigger(j.Name, models.JobSourceStartup)
+	}
+	config.OnReload(func(old, cur *config.Conf) {
+		if old.Schedule != cur.Schedule || old.Jobs != cur.Jobs {
+			log.Logger.Sugar().Info("Task reschedule jobs with new config")
+			s.runner.Schedule(build(cur))
+		}
+	})
+	s.wg.Add(1)
+	go s.listen(ctx)
+	return s
+}
+
+// Shutdown 停止调度并取消进行中的任务，等待它们返回或 ctx 超时
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.cancel()
+	err := s.runner.Shutdown(ctx)
+	s.wg.Wait()
+	return err
+}
+
+// listen 订阅手动触发频道，断线后重连，ctx 结束时返回
+func (s *Scheduler) listen(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		err := db.RedisSubscribe(ctx, models.JobTriggerChannel, func(name string) {
+			log.Logger.Sugar().Info("Task manual trigger ", name)
+			if err := s.runner.Trigger(name, models.JobSourceManual); err != nil {
+				log.Logger.Sugar().Warn("Task manual trigger ", name, " ", err)
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Logger.Sugar().Error("Task subscribe ", models.JobTriggerChannel, " ", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(triggerRetry):
+		}
+	}
+}
+
+// build 全部定时任务；未配置 cron 的任务按 [schedule] 中的间隔执行
+func build(conf *config.Conf) []jobs.Job {
+	defs := []struct {
+		name     string
+		interval config.Duration
+		conf     config.JobConfig
+		run      func(context.Context) (int, error)
+	}{
+		{models.SyncJobPool, conf.Schedule.PoolSyncInterval, conf.Jobs.PoolSync, services.NewPool().UpdateAllPoolInfo},
+		{"balance_monitor", conf.Schedule.BalanceMonitorInterval, conf.Jobs.BalanceMonitor, services.NewBalanceMonitor().Monitor},
+		{models.SyncJobAuction, conf.Schedule.AuctionSyncInterval, conf.Jobs.AuctionSync, services.NewAuctionIndexer().Sync},
+		{"proxy_monitor", conf.Schedule.ProxyMonitorInterval, conf.Jobs.ProxyMonitor, services.NewProxyMonitor().Monitor},
+	}
+	all := make([]jobs.Job, 0, len(defs))
+	for _, d := range defs {
+		spec := d.conf.Cron
+		if spec == "" {
+			spec = "@every " + d.interval.String()
+		}
+		sched, err := cron.ParseStandard(spec)
+		if err != nil {
+			log.Logger.Sugar().Error("Task job ", d.name, " invalid schedule ", spec, " ", err)
+			continue
+		}
+		all = append(all, jobs.Job{
+			Name:     d.name,
+			Spec:     spec,
+			Schedule: sched,
+			Timeout:  d.conf.Timeout.Duration,
+			Jitter:   d.conf.Jitter.Duration,
+			Overlap:  d.conf.Overlap,
+			Run:      d.run,
+		})
+	}
+	return all
 }
@@ -0,0 +1,36 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"lending-copy/config"
+)
+
+func TestBuild(t *testing.T) {
+	conf := &config.Conf{}
+	conf.Schedule.PoolSyncInterval.Duration = 2 * time.Minute
+	conf.Schedule.BalanceMonitorInterval.Duration = 30 * time.Minute
+	conf.Schedule.AuctionSyncInterval.Duration = time.Minute
+	conf.Schedule.ProxyMonitorInterval.Duration = 5 * time.Minute
+	conf.Jobs.BalanceMonitor = config.JobConfig{Cron: "0 * * * *", Overlap: "queue"}
+	conf.Jobs.ProxyMonitor.Cron = "not a cron"
+
+	got := build(conf)
+	if len(got) != 3 {
+		t.Fatalf("jobs = %d, want invalid schedule dropped", len(got))
+	}
+	if got[0].Name != "pool_sync" || got[0].Spec != "@every 2m0s" {
+		t.Fatalf("pool_sync = %+v", got[0])
+	}
+	from := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	if next := got[0].Schedule.Next(from); !next.Equal(from.Add(2 * time.Minute)) {
+		t.Fatalf("pool_sync next = %s", next)
+	}
+	if got[1].Name != "balance_monitor" || got[1].Overlap != "queue" {
+		t.Fatalf("balance_monitor = %+v", got[1])
+	}
+	if next := got[1].Schedule.Next(from); !next.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Fatalf("balance_monitor next = %s", next)
+	}
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// Local 本地磁盘存储，目录由 gin 静态路由对外提供
+type Local struct {
+	dir       string
+	urlPrefix string
+}
+
+func NewLocal(dir, urlPrefix string) *Local {
+	return &Local{dir: dir, urlPrefix: urlPrefix}
+}
+
+// Put 先写临时文件再重命名，避免静态路由读到写了一半的文件
+func (s *Local) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *Local) Exists(ctx context.Context, key string) (bool, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *Local) URL(key string) string {
+	return s.urlPrefix + key
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+// Object Memory 中保存的一个对象
+type Object struct {
+	Data        []byte
+	ContentType string
+}
+
+// Memory 进程内存储，用于测试替代本地磁盘或 S3
+type Memory struct {
+	mu        sync.RWMutex
+	objects   map[string]Object
+	urlPrefix string
+}
+
+func NewMemory(urlPrefix string) *Memory {
+	return &Memory{objects: map[string]Object{}, urlPrefix: urlPrefix}
+}
+
+func (s *Memory) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = Object{Data: append([]byte(nil), data...), ContentType: contentType}
+	return nil
+}
+
+func (s *Memory) Exists(ctx context.Context, key string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.objects[key]
+	return ok, nil
+}
+
+func (s *Memory) URL(key string) string {
+	return s.urlPrefix + key
+}
+
+// Get 返回已保存的对象，供测试断言
+func (s *Memory) Get(key string) (Object, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	obj, ok := s.objects[key]
+	return obj, ok
+}
+
+func (s *Memory) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.objects)
+}
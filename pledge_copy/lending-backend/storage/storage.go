@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"lending-copy/config"
+)
+
+// Storage 上传文件的对象存储；key 为 / 分隔的相对路径，由调用方按内容哈希生成，同一 key 内容不变
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	// URL 对外访问地址；本地存储返回相对服务根路径的地址，由接口按服务地址补全
+	URL(key string) string
+}
+
+var Store Storage
+
+var ErrInvalidKey = errors.New("storage: invalid key")
+
+// InitStorage staticDir 为 /storage/ 对应的本地目录；driver 目前只支持 local，后续可接入 S3 兼容存储
+func InitStorage(staticDir string) Storage {
+	switch config.Config.Storage.Driver {
+	case "", "local":
+		Store = NewLocal(staticDir, "storage/")
+	default:
+		panic("unsupported storage driver: " + config.Config.Storage.Driver)
+	}
+	return Store
+}
+
+// cleanKey 拒绝绝对路径与 .. 等越出存储根目录的 key
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean(key)
+	if key == "" || cleaned != key || strings.HasPrefix(cleaned, "/") || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
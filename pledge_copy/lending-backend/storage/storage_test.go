@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalPutExists(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := NewLocal(dir, "storage/")
+
+	ok, err := s.Exists(ctx, "img/tokens/a.png")
+	if err != nil || ok {
+		t.Fatalf("Exists before put = %v, %v", ok, err)
+	}
+	if err := s.Put(ctx, "img/tokens/a.png", []byte("png"), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	ok, err = s.Exists(ctx, "img/tokens/a.png")
+	if err != nil || !ok {
+		t.Fatalf("Exists after put = %v, %v", ok, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "img", "tokens", "a.png"))
+	if err != nil || !bytes.Equal(data, []byte("png")) {
+		t.Fatalf("file content = %q, %v", data, err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, "img", "tokens"))
+	if len(entries) != 1 {
+		t.Fatalf("temp files left behind: %d entries", len(entries))
+	}
+	if got := s.URL("img/tokens/a.png"); got != "storage/img/tokens/a.png" {
+		t.Fatalf("URL = %s", got)
+	}
+}
+
+func TestInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	stores := map[string]Storage{
+		"local":  NewLocal(t.TempDir(), ""),
+		"memory": NewMemory(""),
+	}
+	for name, s := range stores {
+		for _, key := range []string{"", "/etc/passwd", "../a.png", "img/../../a.png", "img//a.png", "."} {
+			if err := s.Put(ctx, key, []byte("x"), "text/plain"); err != ErrInvalidKey {
+				t.Errorf("%s Put(%q) err = %v, want ErrInvalidKey", name, key, err)
+			}
+		}
+	}
+}
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemory("https://cdn.example.com/")
+	data := []byte("logo")
+	if err := s.Put(ctx, "img/a.png", data, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	data[0] = 'X'
+	obj, ok := s.Get("img/a.png")
+	if !ok || string(obj.Data) != "logo" || obj.ContentType != "image/png" {
+		t.Fatalf("Get = %+v, %v", obj, ok)
+	}
+	if ok, _ := s.Exists(ctx, "img/a.png"); !ok {
+		t.Fatal("Exists = false")
+	}
+	if got := s.URL("img/a.png"); got != "https://cdn.example.com/img/a.png" {
+		t.Fatalf("URL = %s", got)
+	}
+}
@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"lending-copy/log"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/utils/tests"
+)
+
+var exporter = tracetest.NewInMemoryExporter()
+
+func TestMain(m *testing.M) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	os.Exit(m.Run())
+}
+
+// dryRunDialector 在 DummyDialector 基础上注册默认回调，DryRun 下只生成 SQL 不执行
+type dryRunDialector struct{ tests.DummyDialector }
+
+func (dryRunDialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+
+func attr(s tracetest.SpanStub, key attribute.Key) string {
+	for _, kv := range s.Attributes {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestGormPluginSpans(t *testing.T) {
+	exporter.Reset()
+	db, err := gorm.Open(dryRunDialector{}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, root := Tracer().Start(context.Background(), "root")
+	var rows []struct{ PoolId int }
+	db.WithContext(ctx).Table("poolbases").Where("chain_id = ?", "97").Find(&rows)
+	db.WithContext(ctx).Table("pooldata").Where("pool_id = ?", 1).Update("state", "1")
+	root.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+	rootSpan := spans[2]
+	for i, c := range []struct{ name, table, verb string }{
+		{"gorm.query", "poolbases", "SELECT"},
+		{"gorm.update", "pooldata", "UPDATE"},
+	} {
+		s := spans[i]
+		if s.Name != c.name || s.Parent.SpanID() != rootSpan.SpanContext.SpanID() {
+			t.Errorf("span %d = %s (parent %s), want %s under root", i, s.Name, s.Parent.SpanID(), c.name)
+		}
+		if got := attr(s, "db.sql.table"); got != c.table {
+			t.Errorf("%s table = %q, want %q", c.name, got, c.table)
+		}
+		if stmt := attr(s, "db.statement"); !strings.HasPrefix(stmt, c.verb) || strings.Contains(stmt, "97") {
+			t.Errorf("%s statement = %q", c.name, stmt)
+		}
+	}
+}
+
+func TestLogCtxAddsTraceIds(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	prev := log.Logger
+	log.Logger = zap.New(core)
+	defer func() { log.Logger = prev }()
+
+	log.Ctx(context.Background()).Info("no span")
+	ctx, span := Tracer().Start(context.Background(), "root")
+	log.Ctx(ctx).Info("in span")
+	span.End()
+
+	entries := logs.All()
+	if _, ok := entries[0].ContextMap()["trace_id"]; ok {
+		t.Fatalf("trace_id logged without a span: %v", entries[0].ContextMap())
+	}
+	fields := entries[1].ContextMap()
+	if fields["trace_id"] != span.SpanContext().TraceID().String() || fields["span_id"] != span.SpanContext().SpanID().String() {
+		t.Fatalf("fields = %v, want ids of %v", fields, span.SpanContext())
+	}
+}